@@ -0,0 +1,602 @@
+package certmanager
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lucabrasi83/vscan-agent/logging"
+	"golang.org/x/crypto/acme"
+)
+
+// acmeTimeout is the maximum amount of time allowed for a certificate enrollment
+const acmeTimeout = 5 * time.Minute
+
+// ACMEConfig represents the settings used to enroll the agent server certificate from an ACME CA
+type ACMEConfig struct {
+	// DirectoryURL is the ACME directory endpoint of the CA
+	DirectoryURL string
+
+	// Email is the contact registered with the ACME account. It is optional.
+	Email string
+
+	// Domains are the DNS names or IP addresses the certificate is requested for. The first one is used as
+	// the certificate Common Name.
+	Domains []string
+
+	// HTTPChallengeAddr is the listening address of the http-01 challenge responder
+	HTTPChallengeAddr string
+
+	// AccountKeyFile holds the ACME account private key. It is generated if it does not exist.
+	AccountKeyFile string
+
+	// DirectoryCACertFile is the CA bundle used to verify the ACME server TLS certificate.
+	// The system roots are used if empty.
+	DirectoryCACertFile string
+
+	// RenewBefore is the time before expiry from which the certificate is renewed
+	RenewBefore time.Duration
+}
+
+// Enroller obtains and renews the agent server certificate from an ACME CA using http-01 challenges.
+// The private key and the certificate chain are written to the files loaded by the Manager.
+type Enroller struct {
+	cfg      ACMEConfig
+	certFile string
+	keyFile  string
+	client   *acme.Client
+
+	// registered is set once the ACME account is registered or retrieved
+	registered bool
+}
+
+// NewEnroller returns an Enroller writing the enrolled certificate and its private key to certFile and keyFile
+func NewEnroller(cfg ACMEConfig, certFile string, keyFile string) (*Enroller, error) {
+
+	if len(cfg.Domains) == 0 {
+		hostname, err := os.Hostname()
+
+		if err != nil {
+			return nil, fmt.Errorf("error while getting hostname for ACME enrollment: %v", err)
+		}
+
+		cfg.Domains = []string{hostname}
+	}
+
+	accountKey, err := loadOrGenerateKey(cfg.AccountKeyFile)
+
+	if err != nil {
+		return nil, fmt.Errorf("error while loading ACME account key: %v", err)
+	}
+
+	httpClient := http.DefaultClient
+
+	if cfg.DirectoryCACertFile != "" {
+
+		ca, err := ioutil.ReadFile(cfg.DirectoryCACertFile)
+
+		if err != nil {
+			return nil, fmt.Errorf("error while loading ACME directory CA certificate: %v", err)
+		}
+
+		certPool := x509.NewCertPool()
+
+		if ok := certPool.AppendCertsFromPEM(ca); !ok {
+			return nil, fmt.Errorf("failed to append ACME directory CA certs from %v", cfg.DirectoryCACertFile)
+		}
+
+		httpClient = &http.Client{
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: &tls.Config{RootCAs: certPool},
+			},
+			Timeout: time.Minute,
+		}
+	}
+
+	return &Enroller{
+		cfg:      cfg,
+		certFile: certFile,
+		keyFile:  keyFile,
+		client: &acme.Client{
+			Key:          accountKey,
+			DirectoryURL: cfg.DirectoryURL,
+			HTTPClient:   httpClient,
+			UserAgent:    "vscan-agent",
+		},
+	}, nil
+}
+
+// NeedsRenewal returns whether the current certificate is missing, unreadable, does not cover the enrollment
+// domains or expires within the renewal window
+func (e *Enroller) NeedsRenewal() bool {
+
+	leaf, err := e.currentCertificate()
+
+	if err != nil {
+		return true
+	}
+
+	for _, d := range e.cfg.Domains {
+		if leaf.VerifyHostname(d) != nil {
+			return true
+		}
+	}
+
+	return time.Until(leaf.NotAfter) < e.cfg.RenewBefore
+}
+
+// HasValidCertificate returns whether the current certificate exists and is not expired
+func (e *Enroller) HasValidCertificate() bool {
+
+	leaf, err := e.currentCertificate()
+
+	return err == nil && time.Now().Before(leaf.NotAfter)
+}
+
+// currentCertificate parses the certificate currently stored in the certificate file
+func (e *Enroller) currentCertificate() (*x509.Certificate, error) {
+
+	certificate, err := tls.LoadX509KeyPair(e.certFile, e.keyFile)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return x509.ParseCertificate(certificate.Certificate[0])
+}
+
+// Enroll generates a new private key and obtains a certificate for it from the ACME CA.
+// The key and the certificate chain replace the current ones once the certificate is issued.
+func (e *Enroller) Enroll(ctx context.Context) error {
+
+	ctx, cancel := context.WithTimeout(ctx, acmeTimeout)
+	defer cancel()
+
+	logging.VSCANLog("info", "enrolling VSCAN agent server certificate for %v from ACME CA %v",
+		e.cfg.Domains, e.cfg.DirectoryURL)
+
+	if err := e.register(ctx); err != nil {
+		return err
+	}
+
+	ids := make([]acme.AuthzID, 0, len(e.cfg.Domains))
+
+	for _, d := range e.cfg.Domains {
+		if net.ParseIP(d) != nil {
+			ids = append(ids, acme.AuthzID{Type: "ip", Value: d})
+		} else {
+			ids = append(ids, acme.AuthzID{Type: "dns", Value: d})
+		}
+	}
+
+	order, err := e.client.AuthorizeOrder(ctx, ids)
+
+	if err != nil {
+		return fmt.Errorf("error while creating ACME order: %v", err)
+	}
+
+	// Orders fetched afterwards do not carry their URL
+	orderURL := order.URI
+
+	responder, err := newHTTP01Responder(e.cfg.HTTPChallengeAddr)
+
+	if err != nil {
+		return err
+	}
+
+	defer responder.close()
+
+	for _, authzURL := range order.AuthzURLs {
+		if err := e.authorize(ctx, authzURL, responder); err != nil {
+			return err
+		}
+	}
+
+	order, err = e.client.WaitOrder(ctx, orderURL)
+
+	if err != nil {
+		return fmt.Errorf("error while waiting for ACME order to be ready: %v", err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	if err != nil {
+		return fmt.Errorf("error while generating VSCAN agent private key: %v", err)
+	}
+
+	csr, err := e.certificateRequest(key)
+
+	if err != nil {
+		return err
+	}
+
+	chain, err := e.finalize(ctx, orderURL, order.FinalizeURL, csr)
+
+	if err != nil {
+		return err
+	}
+
+	if err := e.store(key, chain); err != nil {
+		return err
+	}
+
+	leaf, err := x509.ParseCertificate(chain[0])
+
+	if err == nil {
+		logging.VSCANLog("info", "enrolled VSCAN agent server certificate for %v expiring on %v",
+			e.cfg.Domains, leaf.NotAfter.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// finalize submits the CSR and returns the issued certificate chain.
+// CreateOrderCert is unable to wait for the certificate when the CA does not issue it right away as the
+// finalize response has no order URL, so the order is then polled with its known URL.
+func (e *Enroller) finalize(ctx context.Context, orderURL string, finalizeURL string, csr []byte) ([][]byte, error) {
+
+	chain, _, err := e.client.CreateOrderCert(ctx, finalizeURL, csr, true)
+
+	if err == nil {
+		return chain, nil
+	}
+
+	order, errWait := e.client.WaitOrder(ctx, orderURL)
+
+	if errWait != nil || order.Status != acme.StatusValid {
+		return nil, fmt.Errorf("error while finalizing ACME order: %v", err)
+	}
+
+	chain, err = e.client.FetchCert(ctx, order.CertURL, true)
+
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching ACME certificate: %v", err)
+	}
+
+	return chain, nil
+}
+
+// register creates the ACME account, or retrieves it if it already exists for the account key
+func (e *Enroller) register(ctx context.Context) error {
+
+	if e.registered {
+		return nil
+	}
+
+	acct := &acme.Account{}
+
+	if e.cfg.Email != "" {
+		acct.Contact = []string{"mailto:" + e.cfg.Email}
+	}
+
+	_, err := e.client.Register(ctx, acct, acme.AcceptTOS)
+
+	if err != nil && !errors.Is(err, acme.ErrAccountAlreadyExists) {
+		return fmt.Errorf("error while registering ACME account: %v", err)
+	}
+
+	e.registered = true
+
+	return nil
+}
+
+// authorize completes the http-01 challenge of a pending authorization
+func (e *Enroller) authorize(ctx context.Context, authzURL string, responder *http01Responder) error {
+
+	authz, err := e.client.GetAuthorization(ctx, authzURL)
+
+	if err != nil {
+		return fmt.Errorf("error while fetching ACME authorization: %v", err)
+	}
+
+	if authz.Status == acme.StatusValid {
+		return nil
+	}
+
+	var chal *acme.Challenge
+
+	for _, c := range authz.Challenges {
+		if c.Type == "http-01" {
+			chal = c
+			break
+		}
+	}
+
+	if chal == nil {
+		return fmt.Errorf("ACME CA offered no http-01 challenge for %v", authz.Identifier.Value)
+	}
+
+	response, err := e.client.HTTP01ChallengeResponse(chal.Token)
+
+	if err != nil {
+		return fmt.Errorf("error while computing http-01 challenge response: %v", err)
+	}
+
+	responder.set(e.client.HTTP01ChallengePath(chal.Token), response)
+
+	if _, err := e.client.Accept(ctx, chal); err != nil {
+		return fmt.Errorf("error while accepting http-01 challenge for %v: %v", authz.Identifier.Value, err)
+	}
+
+	if _, err := e.client.WaitAuthorization(ctx, authz.URI); err != nil {
+		return fmt.Errorf("ACME authorization failed for %v: %v", authz.Identifier.Value, err)
+	}
+
+	return nil
+}
+
+// certificateRequest builds the DER encoded CSR for the enrollment domains
+func (e *Enroller) certificateRequest(key crypto.Signer) ([]byte, error) {
+
+	tpl := &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: e.cfg.Domains[0]},
+	}
+
+	for _, d := range e.cfg.Domains {
+		if ip := net.ParseIP(d); ip != nil {
+			tpl.IPAddresses = append(tpl.IPAddresses, ip)
+		} else {
+			tpl.DNSNames = append(tpl.DNSNames, d)
+		}
+	}
+
+	csr, err := x509.CreateCertificateRequest(rand.Reader, tpl, key)
+
+	if err != nil {
+		return nil, fmt.Errorf("error while creating certificate signing request: %v", err)
+	}
+
+	return csr, nil
+}
+
+// store writes the private key and the certificate chain. Both files are written to temporary files before any is
+// replaced so a failed write never leaves a private key not matching the certificate.
+func (e *Enroller) store(key *ecdsa.PrivateKey, chain [][]byte) error {
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+
+	if err != nil {
+		return fmt.Errorf("error while encoding VSCAN agent private key: %v", err)
+	}
+
+	var certPEM []byte
+
+	for _, der := range chain {
+		certPEM = append(certPEM, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})...)
+	}
+
+	keyTmp, err := stageFile(e.keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600)
+
+	if err != nil {
+		return fmt.Errorf("error while writing VSCAN agent private key: %v", err)
+	}
+
+	defer os.Remove(keyTmp)
+
+	certTmp, err := stageFile(e.certFile, certPEM, 0644)
+
+	if err != nil {
+		return fmt.Errorf("error while writing VSCAN agent server certificate: %v", err)
+	}
+
+	defer os.Remove(certTmp)
+
+	// Keep the current certificate to restore it if the private key cannot be replaced
+	previousCert, errPrevious := ioutil.ReadFile(e.certFile)
+
+	if err := os.Rename(certTmp, e.certFile); err != nil {
+		return fmt.Errorf("error while writing VSCAN agent server certificate: %v", err)
+	}
+
+	if err := os.Rename(keyTmp, e.keyFile); err != nil {
+
+		if errPrevious == nil {
+			if errRestore := writeFileAtomic(e.certFile, previousCert, 0644); errRestore != nil {
+				logging.VSCANLog("error", "unable to restore previous VSCAN agent server certificate: %v",
+					errRestore)
+			}
+		}
+
+		return fmt.Errorf("error while writing VSCAN agent private key: %v", err)
+	}
+
+	return nil
+}
+
+// RenewLoop checks every interval whether the certificate must be renewed until stop is closed.
+// onRenew is called after each successful renewal.
+func (e *Enroller) RenewLoop(interval time.Duration, stop <-chan struct{}, onRenew func()) {
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				if !e.NeedsRenewal() {
+					continue
+				}
+
+				if err := e.Enroll(context.Background()); err != nil {
+					logging.VSCANLog("error", "unable to renew VSCAN agent server certificate: %v", err)
+					continue
+				}
+
+				onRenew()
+			}
+		}
+	}()
+}
+
+// loadOrGenerateKey loads the PEM encoded EC private key from file or generates and stores a new one
+func loadOrGenerateKey(file string) (crypto.Signer, error) {
+
+	content, err := ioutil.ReadFile(file)
+
+	if err == nil {
+
+		block, _ := pem.Decode(content)
+
+		if block == nil {
+			return nil, fmt.Errorf("no PEM data found in %v", file)
+		}
+
+		return x509.ParseECPrivateKey(block.Bytes)
+	}
+
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	if err != nil {
+		return nil, err
+	}
+
+	der, err := x509.MarshalECPrivateKey(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := writeFileAtomic(file, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}),
+		0600); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// writeFileAtomic writes the content to a temporary file renamed to file so readers never see partial content
+func writeFileAtomic(file string, content []byte, perm os.FileMode) error {
+
+	tmp, err := stageFile(file, content, perm)
+
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp)
+
+	return os.Rename(tmp, file)
+}
+
+// stageFile writes the content to a temporary file in the directory of file and returns the temporary file name.
+// The caller renames it to file, or removes it.
+func stageFile(file string, content []byte, perm os.FileMode) (string, error) {
+
+	dir := filepath.Dir(file)
+
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return "", err
+	}
+
+	tmp, err := ioutil.TempFile(dir, "."+filepath.Base(file)+".tmp")
+
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return "", err
+	}
+
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return "", err
+	}
+
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+
+	return tmp.Name(), nil
+}
+
+// http01Responder serves the http-01 challenge responses during an enrollment
+type http01Responder struct {
+	server *http.Server
+
+	mu        sync.Mutex
+	responses map[string]string
+}
+
+func newHTTP01Responder(addr string) (*http01Responder, error) {
+
+	lis, err := net.Listen("tcp", addr)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to listen on %v for ACME http-01 challenges: %v", addr, err)
+	}
+
+	r := &http01Responder{responses: make(map[string]string)}
+
+	r.server = &http.Server{
+		Handler:      r,
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		if err := r.server.Serve(lis); err != nil && err != http.ErrServerClosed {
+			logging.VSCANLog("error", "ACME http-01 challenge responder failed: %v", err)
+		}
+	}()
+
+	return r, nil
+}
+
+func (r *http01Responder) set(path string, response string) {
+	r.mu.Lock()
+	r.responses[path] = response
+	r.mu.Unlock()
+}
+
+func (r *http01Responder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+
+	if !strings.HasPrefix(req.URL.Path, "/.well-known/acme-challenge/") {
+		http.NotFound(w, req)
+		return
+	}
+
+	r.mu.Lock()
+	response, ok := r.responses[req.URL.Path]
+	r.mu.Unlock()
+
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	_, _ = w.Write([]byte(response))
+}
+
+func (r *http01Responder) close() {
+	_ = r.server.Close()
+}
@@ -0,0 +1,434 @@
+package certmanager
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/acme"
+)
+
+// acmeStandIn is an in-process ACME CA implementing the subset of RFC 8555 used by the Enroller: a single account,
+// order, authorization and http-01 challenge. JWS signatures are not verified.
+type acmeStandIn struct {
+	t      *testing.T
+	server *httptest.Server
+
+	// challengeAddr is the address of the Enroller http-01 challenge responder
+	challengeAddr string
+
+	// accountKey is the public key of the Enroller ACME account, used to compute the expected key authorization
+	accountKey crypto.PublicKey
+
+	caKey  *ecdsa.PrivateKey
+	caCert *x509.Certificate
+
+	mu          sync.Mutex
+	nonce       int
+	identifiers []acme.AuthzID
+	authzStatus string
+	chain       []byte
+}
+
+const acmeChallengeToken = "stand-in-token"
+
+func newACMEStandIn(t *testing.T, challengeAddr string) *acmeStandIn {
+
+	t.Helper()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	if err != nil {
+		t.Fatalf("unable to generate stand-in CA key: %v", err)
+	}
+
+	tpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ACME stand-in CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tpl, tpl, caKey.Public(), caKey)
+
+	if err != nil {
+		t.Fatalf("unable to create stand-in CA certificate: %v", err)
+	}
+
+	caCert, _ := x509.ParseCertificate(der)
+
+	s := &acmeStandIn{
+		t:             t,
+		challengeAddr: challengeAddr,
+		caKey:         caKey,
+		caCert:        caCert,
+		authzStatus:   acme.StatusPending,
+	}
+
+	s.server = httptest.NewServer(s)
+	t.Cleanup(s.server.Close)
+
+	return s
+}
+
+func (s *acmeStandIn) url(path string) string {
+	return s.server.URL + path
+}
+
+func (s *acmeStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nonce++
+	w.Header().Set("Replay-Nonce", fmt.Sprintf("nonce-%d", s.nonce))
+
+	switch r.URL.Path {
+	case "/directory":
+		s.reply(w, http.StatusOK, map[string]string{
+			"newNonce":   s.url("/new-nonce"),
+			"newAccount": s.url("/new-account"),
+			"newOrder":   s.url("/new-order"),
+		})
+
+	case "/new-nonce":
+		w.WriteHeader(http.StatusOK)
+
+	case "/new-account":
+		w.Header().Set("Location", s.url("/account/1"))
+		s.reply(w, http.StatusCreated, map[string]string{"status": acme.StatusValid})
+
+	case "/new-order":
+		var req struct {
+			Identifiers []acme.AuthzID `json:"identifiers"`
+		}
+
+		s.payload(r, &req)
+		s.identifiers = req.Identifiers
+
+		w.Header().Set("Location", s.url("/order/1"))
+		s.reply(w, http.StatusCreated, s.order())
+
+	case "/order/1", "/finalize/1":
+		if r.URL.Path == "/finalize/1" {
+			var req struct {
+				CSR string `json:"csr"`
+			}
+
+			s.payload(r, &req)
+			s.issue(req.CSR)
+		}
+
+		w.Header().Set("Location", s.url("/order/1"))
+		s.reply(w, http.StatusOK, s.order())
+
+	case "/authz/1":
+		s.reply(w, http.StatusOK, s.authorization())
+
+	case "/challenge/1":
+		s.validate()
+		s.reply(w, http.StatusOK, s.authorization()["challenges"].([]map[string]string)[0])
+
+	case "/cert/1":
+		w.Header().Set("Content-Type", "application/pem-certificate-chain")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(s.chain)
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *acmeStandIn) reply(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// payload decodes the payload of a flattened JWS request body
+func (s *acmeStandIn) payload(r *http.Request, v interface{}) {
+
+	var jws struct {
+		Payload string `json:"payload"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&jws); err != nil {
+		s.t.Errorf("stand-in ACME CA received an invalid JWS: %v", err)
+		return
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(jws.Payload)
+
+	if err != nil {
+		s.t.Errorf("stand-in ACME CA received an invalid JWS payload: %v", err)
+		return
+	}
+
+	if err := json.Unmarshal(payload, v); err != nil {
+		s.t.Errorf("stand-in ACME CA received an invalid request: %v", err)
+	}
+}
+
+func (s *acmeStandIn) order() map[string]interface{} {
+
+	status := acme.StatusPending
+
+	switch {
+	case s.chain != nil:
+		status = acme.StatusValid
+	case s.authzStatus == acme.StatusValid:
+		status = acme.StatusReady
+	case s.authzStatus == acme.StatusInvalid:
+		status = acme.StatusInvalid
+	}
+
+	order := map[string]interface{}{
+		"status":         status,
+		"identifiers":    s.identifiers,
+		"authorizations": []string{s.url("/authz/1")},
+		"finalize":       s.url("/finalize/1"),
+	}
+
+	if s.chain != nil {
+		order["certificate"] = s.url("/cert/1")
+	}
+
+	return order
+}
+
+func (s *acmeStandIn) authorization() map[string]interface{} {
+
+	var identifier acme.AuthzID
+
+	if len(s.identifiers) > 0 {
+		identifier = s.identifiers[0]
+	}
+
+	return map[string]interface{}{
+		"status":     s.authzStatus,
+		"identifier": identifier,
+		"challenges": []map[string]string{{
+			"type":   "http-01",
+			"url":    s.url("/challenge/1"),
+			"token":  acmeChallengeToken,
+			"status": s.authzStatus,
+		}},
+	}
+}
+
+// validate fetches the http-01 challenge response from the Enroller responder
+func (s *acmeStandIn) validate() {
+
+	s.authzStatus = acme.StatusInvalid
+
+	res, err := http.Get("http://" + s.challengeAddr + "/.well-known/acme-challenge/" + acmeChallengeToken)
+
+	if err != nil {
+		s.t.Errorf("stand-in ACME CA unable to reach the http-01 responder: %v", err)
+		return
+	}
+
+	defer res.Body.Close()
+
+	body, _ := ioutil.ReadAll(res.Body)
+
+	thumbprint, err := acme.JWKThumbprint(s.accountKey)
+
+	if err != nil {
+		s.t.Errorf("unable to compute account key thumbprint: %v", err)
+		return
+	}
+
+	if want := acmeChallengeToken + "." + thumbprint; string(body) != want {
+		s.t.Errorf("http-01 challenge response = %q, want %q", body, want)
+		return
+	}
+
+	s.authzStatus = acme.StatusValid
+}
+
+// issue signs the certificate requested by the base64url encoded CSR
+func (s *acmeStandIn) issue(encodedCSR string) {
+
+	der, err := base64.RawURLEncoding.DecodeString(encodedCSR)
+
+	if err != nil {
+		s.t.Errorf("stand-in ACME CA received an invalid CSR encoding: %v", err)
+		return
+	}
+
+	csr, err := x509.ParseCertificateRequest(der)
+
+	if err != nil {
+		s.t.Errorf("stand-in ACME CA received an invalid CSR: %v", err)
+		return
+	}
+
+	tpl := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      csr.Subject,
+		DNSNames:     csr.DNSNames,
+		IPAddresses:  csr.IPAddresses,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(90 * 24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	leaf, err := x509.CreateCertificate(rand.Reader, tpl, s.caCert, csr.PublicKey, s.caKey)
+
+	if err != nil {
+		s.t.Errorf("stand-in ACME CA unable to issue certificate: %v", err)
+		return
+	}
+
+	s.chain = append(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leaf}),
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.caCert.Raw})...)
+}
+
+// freeAddr returns a loopback address with a port free at the time of the call
+func freeAddr(t *testing.T) string {
+
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatalf("unable to find a free port: %v", err)
+	}
+
+	defer lis.Close()
+
+	return lis.Addr().String()
+}
+
+func newTestEnroller(t *testing.T, dir string) (*Enroller, *acmeStandIn) {
+
+	t.Helper()
+
+	challengeAddr := freeAddr(t)
+
+	ca := newACMEStandIn(t, challengeAddr)
+
+	e, err := NewEnroller(ACMEConfig{
+		DirectoryURL:      ca.url("/directory"),
+		Domains:           []string{"agent.vscan.test"},
+		HTTPChallengeAddr: challengeAddr,
+		AccountKeyFile:    filepath.Join(dir, "acme-account.key"),
+		RenewBefore:       30 * 24 * time.Hour,
+	}, filepath.Join(dir, "agent.crt"), filepath.Join(dir, "agent.key"))
+
+	if err != nil {
+		t.Fatalf("unable to create enroller: %v", err)
+	}
+
+	ca.accountKey = e.client.Key.Public()
+
+	return e, ca
+}
+
+func TestEnrollerEnroll(t *testing.T) {
+
+	dir := t.TempDir()
+
+	e, _ := newTestEnroller(t, dir)
+
+	if !e.NeedsRenewal() {
+		t.Fatalf("enroller without certificate does not need renewal")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := e.Enroll(ctx); err != nil {
+		t.Fatalf("enrollment failed: %v", err)
+	}
+
+	pair, err := tls.LoadX509KeyPair(e.certFile, e.keyFile)
+
+	if err != nil {
+		t.Fatalf("enrolled key and certificate do not match: %v", err)
+	}
+
+	if len(pair.Certificate) != 2 {
+		t.Errorf("enrolled certificate chain holds %v certificate(s), want 2", len(pair.Certificate))
+	}
+
+	if e.NeedsRenewal() || !e.HasValidCertificate() {
+		t.Errorf("enrolled certificate is not valid for %v", e.cfg.Domains)
+	}
+
+	info, err := os.Stat(e.keyFile)
+
+	if err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("private key file mode = %v, want 0600 (%v)", info.Mode().Perm(), err)
+	}
+}
+
+func TestEnrollerStoreKeepsMatchingPair(t *testing.T) {
+
+	dir := t.TempDir()
+
+	e, _ := newTestEnroller(t, dir)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := e.Enroll(ctx); err != nil {
+		t.Fatalf("enrollment failed: %v", err)
+	}
+
+	previousKey, _ := ioutil.ReadFile(e.keyFile)
+	previousCert, _ := ioutil.ReadFile(e.certFile)
+
+	// A directory in place of the certificate file makes the certificate replacement fail
+	e.certFile = filepath.Join(dir, "unwritable.crt")
+
+	if err := os.MkdirAll(filepath.Join(e.certFile, "child"), 0750); err != nil {
+		t.Fatal(err)
+	}
+
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	block, _ := pem.Decode(previousCert)
+
+	if err := e.store(key, [][]byte{block.Bytes}); err == nil {
+		t.Fatalf("store succeeded with an unwritable certificate file")
+	}
+
+	if currentKey, _ := ioutil.ReadFile(e.keyFile); !bytes.Equal(currentKey, previousKey) {
+		t.Errorf("private key replaced although the certificate could not be written")
+	}
+
+	entries, _ := ioutil.ReadDir(dir)
+
+	for _, entry := range entries {
+		if strings.Contains(entry.Name(), ".tmp") {
+			t.Errorf("temporary file %v left behind", entry.Name())
+		}
+	}
+}
@@ -0,0 +1,283 @@
+// Package certmanager handles the gRPC server TLS certificates and client Certificate Authority bundle.
+// Certificates are reloaded without restarting the VSCAN Agent when the files change on disk.
+package certmanager
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/lucabrasi83/vscan-agent/logging"
+)
+
+// reloadDelay is the time to wait after the last file change before reloading the certificates.
+// Certificate, key and CA bundle are usually rotated together and written in several steps.
+const reloadDelay = 2 * time.Second
+
+// Manager holds the TLS configuration currently served and reloads it from the certificate files
+type Manager struct {
+	certFile   string
+	keyFile    string
+	caCertFile string
+
+	// current holds the *tls.Config handed out to new TLS connections
+	current atomic.Value
+
+	// reloadMu serializes reloads triggered by file changes and SIGHUP
+	reloadMu sync.Mutex
+
+	// expiries holds the server certificate expiry followed by the served intermediate certificates and the CA bundle
+	// certificates expiries
+	expiryMu sync.RWMutex
+	expiries []CertificateExpiry
+}
+
+// New loads the server certificate, its private key and the client CA bundle.
+// It returns an error if any of them cannot be loaded.
+func New(certFile string, keyFile string, caCertFile string) (*Manager, error) {
+
+	m := &Manager{
+		certFile:   certFile,
+		keyFile:    keyFile,
+		caCertFile: caCertFile,
+	}
+
+	if err := m.Reload(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// TLSConfig returns the server TLS configuration. Each TLS handshake uses the certificates loaded last.
+func (m *Manager) TLSConfig() *tls.Config {
+	return &tls.Config{
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return m.current.Load().(*tls.Config), nil
+		},
+	}
+}
+
+// ClientTLSConfig returns a client TLS configuration presenting the certificate loaded last and verifying the
+// server certificate against the CA bundle, used when the agent dials out to the controller.
+// The client certificate and the CA bundle are looked up on each handshake so long-lived clients, such as the
+// controller gRPC connection, present the certificate and trust the CA bundle loaded last.
+func (m *Manager) ClientTLSConfig(serverName string) *tls.Config {
+	return &tls.Config{
+		ServerName: serverName,
+		MinVersion: tls.VersionTLS12,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return &m.current.Load().(*tls.Config).Certificates[0], nil
+		},
+
+		// The default verification uses a fixed RootCAs pool: it is skipped for verifyServer, which verifies the
+		// server certificate chain and name against the CA bundle loaded last
+		InsecureSkipVerify: true,
+		VerifyConnection:   m.verifyServer,
+	}
+}
+
+// verifyServer verifies the server certificate chain of a client TLS connection against the CA bundle loaded last,
+// as the default verification would against RootCAs
+func (m *Manager) verifyServer(cs tls.ConnectionState) error {
+
+	if cs.ServerName == "" {
+		return fmt.Errorf("server name is required to verify the server certificate")
+	}
+
+	if len(cs.PeerCertificates) == 0 {
+		return fmt.Errorf("server presented no certificate")
+	}
+
+	intermediates := x509.NewCertPool()
+
+	for _, cert := range cs.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	_, err := cs.PeerCertificates[0].Verify(x509.VerifyOptions{
+		DNSName:       cs.ServerName,
+		Roots:         m.current.Load().(*tls.Config).ClientCAs,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+
+	return err
+}
+
+// Reload loads the certificate files and swaps the TLS configuration in use.
+// The previous configuration is kept if any of the files cannot be loaded.
+// Established connections are not affected.
+func (m *Manager) Reload() error {
+
+	m.reloadMu.Lock()
+	defer m.reloadMu.Unlock()
+
+	certificate, err := tls.LoadX509KeyPair(m.certFile, m.keyFile)
+
+	if err != nil {
+		return fmt.Errorf("error while loading VSCAN agent server certificate: %v", err)
+	}
+
+	leaf, err := x509.ParseCertificate(certificate.Certificate[0])
+
+	if err != nil {
+		return fmt.Errorf("error while parsing VSCAN agent server certificate: %v", err)
+	}
+
+	certificate.Leaf = leaf
+
+	ca, err := ioutil.ReadFile(m.caCertFile)
+
+	if err != nil {
+		return fmt.Errorf("error while loading VSCAN agent root Certificate Authority: %v", err)
+	}
+
+	// Create a certificate pool from the certificate authority
+	certPool := x509.NewCertPool()
+
+	if ok := certPool.AppendCertsFromPEM(ca); !ok {
+		return fmt.Errorf("failed to append Root CA certs from %v", m.caCertFile)
+	}
+
+	m.current.Store(&tls.Config{
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		Certificates: []tls.Certificate{certificate},
+		ClientCAs:    certPool,
+		NextProtos:   []string{"h2"},
+	})
+
+	expiries := []CertificateExpiry{expiryOf("server", leaf)}
+
+	logging.VSCANLog("info", "loaded VSCAN agent server certificate %v (subject %v) expiring on %v",
+		m.certFile, leaf.Subject.CommonName, leaf.NotAfter.Format(time.RFC3339))
+
+	// Clients reject the served chain once any intermediate certificate expired, even with a valid leaf
+	for _, der := range certificate.Certificate[1:] {
+
+		intermediate, err := x509.ParseCertificate(der)
+
+		if err != nil {
+			return fmt.Errorf("error while parsing VSCAN agent intermediate certificate: %v", err)
+		}
+
+		expiries = append(expiries, expiryOf("intermediate "+intermediate.Subject.CommonName, intermediate))
+
+		logging.VSCANLog("info", "loaded intermediate certificate %v expiring on %v",
+			intermediate.Subject.CommonName, intermediate.NotAfter.Format(time.RFC3339))
+	}
+
+	for _, caCert := range parseCertificates(ca) {
+
+		expiries = append(expiries, expiryOf("CA "+caCert.Subject.CommonName, caCert))
+
+		logging.VSCANLog("info", "loaded root Certificate Authority %v expiring on %v",
+			caCert.Subject.CommonName, caCert.NotAfter.Format(time.RFC3339))
+	}
+
+	m.expiryMu.Lock()
+	m.expiries = expiries
+	m.expiryMu.Unlock()
+
+	return nil
+}
+
+// Watch reloads the certificates whenever the certificate files change until stop is closed.
+// The parent directories are watched rather than the files themselves so that files replaced by rename
+// or symlink swap (as done by Kubernetes secret volumes) are picked up.
+func (m *Manager) Watch(stop <-chan struct{}) error {
+
+	watcher, err := fsnotify.NewWatcher()
+
+	if err != nil {
+		return fmt.Errorf("error while creating certificates watcher: %v", err)
+	}
+
+	dirs := make(map[string]bool)
+
+	for _, f := range []string{m.certFile, m.keyFile, m.caCertFile} {
+		dirs[filepath.Dir(f)] = true
+	}
+
+	for dir := range dirs {
+		if err := watcher.Add(dir); err != nil {
+			watcher.Close()
+			return fmt.Errorf("error while watching certificates directory %v: %v", dir, err)
+		}
+	}
+
+	go func() {
+		defer watcher.Close()
+
+		var reload <-chan time.Time
+
+		for {
+			select {
+			case <-stop:
+				return
+
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+
+				if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename|fsnotify.Remove) != 0 {
+					reload = time.After(reloadDelay)
+				}
+
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+
+				logging.VSCANLog("error", "certificates watcher error: %v", err)
+
+			case <-reload:
+				reload = nil
+
+				logging.VSCANLog("info", "certificate files changed, reloading TLS certificates...")
+
+				if err := m.Reload(); err != nil {
+					logging.VSCANLog("error", "unable to reload TLS certificates, keeping previous ones: %v", err)
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// parseCertificates returns the certificates found in a PEM bundle. Invalid blocks are skipped.
+func parseCertificates(bundle []byte) []*x509.Certificate {
+
+	var certs []*x509.Certificate
+
+	for {
+		var block *pem.Block
+
+		block, bundle = pem.Decode(bundle)
+
+		if block == nil {
+			return certs
+		}
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+
+		if err != nil {
+			continue
+		}
+
+		certs = append(certs, cert)
+	}
+}
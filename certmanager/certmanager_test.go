@@ -0,0 +1,259 @@
+package certmanager
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// testCertificate is a certificate with its private key, signed by a test CA or self-signed
+type testCertificate struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	tls  tls.Certificate
+}
+
+// newTestCertificate issues a certificate valid for a year for the common name, used as DNS name of the non CA
+// certificates. The certificate is self-signed when issuer is nil.
+func newTestCertificate(t *testing.T, commonName string, serial int64, isCA bool,
+	issuer *testCertificate) *testCertificate {
+
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(365 * 24 * time.Hour),
+	}
+
+	if isCA {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign
+	} else {
+		template.DNSNames = []string{commonName}
+		template.KeyUsage = x509.KeyUsageDigitalSignature
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}
+	}
+
+	parent, parentKey := template, key
+
+	if issuer != nil {
+		parent, parentKey = issuer.cert, issuer.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &testCertificate{
+		cert: cert,
+		key:  key,
+		tls:  tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: cert},
+	}
+}
+
+func (c *testCertificate) certPEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.cert.Raw})
+}
+
+// writeFiles writes the agent certificate followed by the intermediate certificates, its private key and the CA
+// bundle loaded by the Manager
+func writeFiles(t *testing.T, dir string, agent *testCertificate, ca *testCertificate,
+	intermediates ...*testCertificate) {
+
+	t.Helper()
+
+	keyDER, err := x509.MarshalECPrivateKey(agent.key)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chain := agent.certPEM()
+
+	for _, c := range intermediates {
+		chain = append(chain, c.certPEM()...)
+	}
+
+	files := map[string][]byte{
+		"agent.crt": chain,
+		"agent.key": pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		"ca.crt":    ca.certPEM(),
+	}
+
+	for name, content := range files {
+		if err := writeFileAtomic(filepath.Join(dir, name), content, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// serveController accepts TLS connections presenting the controller certificate and reports the serial number of
+// the client certificate of each completed handshake
+func serveController(t *testing.T, controller *testCertificate) (string, <-chan *big.Int) {
+
+	t.Helper()
+
+	lis, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{controller.tls},
+		ClientAuth:   tls.RequireAnyClientCert,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { lis.Close() })
+
+	clientSerials := make(chan *big.Int, 4)
+
+	go func() {
+		for {
+			conn, err := lis.Accept()
+
+			if err != nil {
+				return
+			}
+
+			tlsConn := conn.(*tls.Conn)
+
+			if err := tlsConn.Handshake(); err == nil {
+				clientSerials <- tlsConn.ConnectionState().PeerCertificates[0].SerialNumber
+			}
+
+			_, _ = ioutil.ReadAll(tlsConn)
+			tlsConn.Close()
+		}
+	}()
+
+	return lis.Addr().String(), clientSerials
+}
+
+func TestClientTLSConfigReload(t *testing.T) {
+
+	dir := t.TempDir()
+
+	oldCA := newTestCertificate(t, "VSCAN Root CA 1", 1, true, nil)
+	newCA := newTestCertificate(t, "VSCAN Root CA 2", 2, true, nil)
+
+	oldAgent := newTestCertificate(t, "agent.vscan.internal", 10, false, oldCA)
+	newAgent := newTestCertificate(t, "agent.vscan.internal", 20, false, newCA)
+
+	// The controller certificate is issued by the new CA, not yet trusted by the agent
+	controllerAddr, clientSerials := serveController(t,
+		newTestCertificate(t, "controller.vscan.internal", 30, false, newCA))
+
+	writeFiles(t, dir, oldAgent, oldCA)
+
+	m, err := New(filepath.Join(dir, "agent.crt"), filepath.Join(dir, "agent.key"), filepath.Join(dir, "ca.crt"))
+
+	if err != nil {
+		t.Fatalf("unable to load certificates: %v", err)
+	}
+
+	// The configuration is built once, as done for the long-lived controller gRPC connection
+	clientConfig := m.ClientTLSConfig("controller.vscan.internal")
+
+	dial := func() error {
+
+		conn, err := tls.DialWithDialer(&net.Dialer{Timeout: 5 * time.Second}, "tcp", controllerAddr, clientConfig)
+
+		if err != nil {
+			return err
+		}
+
+		return conn.Close()
+	}
+
+	if err := dial(); err == nil {
+		t.Fatalf("controller certificate issued by an untrusted CA accepted")
+	}
+
+	writeFiles(t, dir, newAgent, newCA)
+
+	if err := m.Reload(); err != nil {
+		t.Fatalf("unable to reload certificates: %v", err)
+	}
+
+	if err := dial(); err != nil {
+		t.Fatalf("controller certificate issued by the reloaded CA rejected: %v", err)
+	}
+
+	select {
+	case serial := <-clientSerials:
+		if serial.Cmp(newAgent.cert.SerialNumber) != 0 {
+			t.Errorf("client certificate serial = %v, want the reloaded certificate serial %v", serial,
+				newAgent.cert.SerialNumber)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("controller did not complete the handshake")
+	}
+
+	// The server name is verified against the reloaded CA bundle as well
+	clientConfig = m.ClientTLSConfig("other.vscan.internal")
+
+	if err := dial(); err == nil {
+		t.Errorf("controller certificate accepted for another server name")
+	}
+}
+
+func TestExpiriesIntermediates(t *testing.T) {
+
+	dir := t.TempDir()
+
+	root := newTestCertificate(t, "VSCAN Root CA", 1, true, nil)
+	intermediate := newTestCertificate(t, "VSCAN Issuing CA", 2, true, root)
+	agent := newTestCertificate(t, "agent.vscan.internal", 3, false, intermediate)
+
+	writeFiles(t, dir, agent, root, intermediate)
+
+	m, err := New(filepath.Join(dir, "agent.crt"), filepath.Join(dir, "agent.key"), filepath.Join(dir, "ca.crt"))
+
+	if err != nil {
+		t.Fatalf("unable to load certificates: %v", err)
+	}
+
+	var names []string
+
+	for _, e := range m.Expiries() {
+		names = append(names, e.Name)
+	}
+
+	want := []string{"server", "intermediate VSCAN Issuing CA", "CA VSCAN Root CA"}
+
+	if len(names) != len(want) {
+		t.Fatalf("expiries = %q, want %q", names, want)
+	}
+
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("expiries = %q, want %q", names, want)
+		}
+	}
+}
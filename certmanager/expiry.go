@@ -0,0 +1,121 @@
+package certmanager
+
+import (
+	"crypto/x509"
+	"math"
+	"time"
+
+	"github.com/lucabrasi83/vscan-agent/logging"
+)
+
+// criticalExpiryDays is the number of days before expiry from which the expiry warnings are logged as errors
+const criticalExpiryDays = 7
+
+// CertificateExpiry represents the expiry details of a loaded certificate
+type CertificateExpiry struct {
+	// Name identifies the certificate: "server", "intermediate <Common Name>" or "CA <Common Name>"
+	Name    string
+	Subject string
+
+	// Serial is the hexadecimal serial number, distinguishing the old and new CA certificates of a CA rotation
+	Serial   string
+	NotAfter time.Time
+}
+
+// DaysToExpiry returns the number of days left before the certificate expires. It is negative once expired.
+func (e CertificateExpiry) DaysToExpiry() int {
+	return int(math.Floor(time.Until(e.NotAfter).Hours() / 24))
+}
+
+// Expired returns whether the certificate is expired
+func (e CertificateExpiry) Expired() bool {
+	return time.Now().After(e.NotAfter)
+}
+
+// expiryOf returns the expiry details of a certificate
+func expiryOf(name string, cert *x509.Certificate) CertificateExpiry {
+	return CertificateExpiry{
+		Name:     name,
+		Subject:  cert.Subject.String(),
+		Serial:   cert.SerialNumber.Text(16),
+		NotAfter: cert.NotAfter,
+	}
+}
+
+// Expiries returns the expiry details of the server certificate followed by the served intermediate certificates
+// and the CA bundle certificates
+func (m *Manager) Expiries() []CertificateExpiry {
+
+	m.expiryMu.RLock()
+	defer m.expiryMu.RUnlock()
+
+	return append([]CertificateExpiry(nil), m.expiries...)
+}
+
+// ServerDaysToExpiry returns the number of days left before the server certificate expires.
+// It returns -1 if no server certificate is loaded.
+func (m *Manager) ServerDaysToExpiry() int {
+
+	m.expiryMu.RLock()
+	defer m.expiryMu.RUnlock()
+
+	if len(m.expiries) == 0 {
+		return -1
+	}
+
+	return m.expiries[0].DaysToExpiry()
+}
+
+// ServerExpired returns whether the server certificate is expired. It returns true if no server certificate is
+// loaded.
+func (m *Manager) ServerExpired() bool {
+
+	m.expiryMu.RLock()
+	defer m.expiryMu.RUnlock()
+
+	if len(m.expiries) == 0 {
+		return true
+	}
+
+	return m.expiries[0].Expired()
+}
+
+// CheckExpiry logs the expiry of the loaded certificates with escalating severity as expiry approaches:
+// warning within warningDays days, error within a week and once expired.
+func (m *Manager) CheckExpiry(warningDays int) {
+
+	for _, e := range m.Expiries() {
+
+		days := e.DaysToExpiry()
+
+		switch {
+		case e.Expired():
+			logging.VSCANLog("error", "%v certificate %v EXPIRED on %v. TLS clients will reject the agent",
+				e.Name, e.Subject, e.NotAfter.Format(time.RFC3339))
+		case days < criticalExpiryDays:
+			logging.VSCANLog("error", "%v certificate %v expires in %d day(s) on %v. Renew it immediately",
+				e.Name, e.Subject, days, e.NotAfter.Format(time.RFC3339))
+		case days < warningDays:
+			logging.VSCANLog("warning", "%v certificate %v expires in %d day(s) on %v",
+				e.Name, e.Subject, days, e.NotAfter.Format(time.RFC3339))
+		}
+	}
+}
+
+// MonitorExpiry checks the certificates expiry every interval until stop is closed
+func (m *Manager) MonitorExpiry(interval time.Duration, warningDays int, stop <-chan struct{}) {
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				m.CheckExpiry(warningDays)
+			}
+		}
+	}()
+}
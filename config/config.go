@@ -0,0 +1,720 @@
+// Package config handles the VSCAN Agent configuration loaded from a YAML file with environment variable overrides
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"os"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// defaultConfigFile is the configuration file loaded if not specified in environment variable VSCAN_AGENT_CONFIG
+const defaultConfigFile = "/opt/config/vscan-agent.yml"
+
+// SSH host key policies supported by the VSCAN Agent
+const (
+	// HostKeyPolicyStrict only accepts host keys found in the known_hosts file or pinned in the request
+	HostKeyPolicyStrict = "strict"
+
+	// HostKeyPolicyTOFU records unknown host keys in the known_hosts file on the first authenticated connection
+	// (Trust On First Use)
+	HostKeyPolicyTOFU = "tofu"
+
+	// HostKeyPolicyInsecure accepts any host key unless fingerprints are pinned in the request
+	HostKeyPolicyInsecure = "insecure"
+)
+
+// Config represents the VSCAN Agent configuration.
+// Each setting can be overridden by the environment variable set in its env tag.
+// Settings with a secret tag are masked when printed.
+type Config struct {
+	Server     Server     `yaml:"server"`
+	TLS        TLS        `yaml:"tls"`
+	ACME       ACME       `yaml:"acme"`
+	Joval      Joval      `yaml:"joval"`
+	SSH        SSH        `yaml:"ssh"`
+	Admission  Admission  `yaml:"admission"`
+	RateLimit  RateLimit  `yaml:"rate_limit"`
+	Authz      Authz      `yaml:"authz"`
+	Metrics    Metrics    `yaml:"metrics"`
+	Tracing    Tracing    `yaml:"tracing"`
+	Reverse    Reverse    `yaml:"reverse"`
+	Controller Controller `yaml:"controller"`
+}
+
+// Server represents the gRPC server settings
+type Server struct {
+	BindPort   string `yaml:"bind_port" env:"VSCAN_AGENT_BIND_PORT"`
+	BannerFile string `yaml:"banner_file" env:"VSCAN_AGENT_BANNER_FILE"`
+
+	// HealthCheckInterval is the interval between two evaluations of the readiness checks
+	HealthCheckInterval time.Duration `yaml:"health_check_interval" env:"VSCAN_AGENT_HEALTH_CHECK_INTERVAL"`
+
+	// DrainGracePeriod is the time given to running scan jobs to finish once the agent is draining
+	DrainGracePeriod time.Duration `yaml:"drain_grace_period" env:"VSCAN_AGENT_DRAIN_GRACE_PERIOD"`
+
+	// InterruptedJobsFile records the scan jobs cancelled at the end of the drain grace period
+	InterruptedJobsFile string `yaml:"interrupted_jobs_file" env:"VSCAN_AGENT_INTERRUPTED_JOBS_FILE"`
+}
+
+// TLS represents the gRPC server mutual TLS settings
+type TLS struct {
+	CertFile   string `yaml:"cert_file" env:"VSCAN_AGENT_TLS_CERT_FILE"`
+	KeyFile    string `yaml:"key_file" env:"VSCAN_AGENT_TLS_KEY_FILE"`
+	CACertFile string `yaml:"ca_cert_file" env:"VSCAN_AGENT_TLS_CA_CERT_FILE"`
+
+	// Certificates expiry monitoring
+	ExpiryCheckInterval time.Duration `yaml:"expiry_check_interval" env:"VSCAN_AGENT_TLS_EXPIRY_CHECK_INTERVAL"`
+	ExpiryWarningDays   int           `yaml:"expiry_warning_days" env:"VSCAN_AGENT_TLS_EXPIRY_WARNING_DAYS"`
+	RefuseExpired       bool          `yaml:"refuse_expired" env:"VSCAN_AGENT_TLS_REFUSE_EXPIRED"`
+}
+
+// ACME represents the settings to enroll and renew the gRPC server certificate from an ACME CA.
+// The enrolled certificate and private key are written to the tls cert_file and key_file.
+type ACME struct {
+	Enabled             bool          `yaml:"enabled" env:"VSCAN_AGENT_ACME_ENABLED"`
+	DirectoryURL        string        `yaml:"directory_url" env:"VSCAN_AGENT_ACME_DIRECTORY_URL"`
+	DirectoryCACertFile string        `yaml:"directory_ca_cert_file" env:"VSCAN_AGENT_ACME_DIRECTORY_CA_CERT_FILE"`
+	Email               string        `yaml:"email" env:"VSCAN_AGENT_ACME_EMAIL"`
+	Domains             []string      `yaml:"domains" env:"VSCAN_AGENT_ACME_DOMAINS"`
+	HTTPChallengeAddr   string        `yaml:"http_challenge_addr" env:"VSCAN_AGENT_ACME_HTTP_CHALLENGE_ADDR"`
+	AccountKeyFile      string        `yaml:"account_key_file" env:"VSCAN_AGENT_ACME_ACCOUNT_KEY_FILE"`
+	RenewBefore         time.Duration `yaml:"renew_before" env:"VSCAN_AGENT_ACME_RENEW_BEFORE"`
+	CheckInterval       time.Duration `yaml:"check_interval" env:"VSCAN_AGENT_ACME_CHECK_INTERVAL"`
+}
+
+// Joval represents the Joval scanner settings
+type Joval struct {
+	JavaBin           string        `yaml:"java_bin" env:"VSCAN_AGENT_JAVA_BIN"`
+	JarFile           string        `yaml:"jar_file" env:"VSCAN_AGENT_JOVAL_JAR_FILE"`
+	LicenseFile       string        `yaml:"license_file" env:"VSCAN_AGENT_JOVAL_LICENSE_FILE"`
+	ScanJobsDir       string        `yaml:"scan_jobs_dir" env:"VSCAN_AGENT_SCAN_JOBS_DIR"`
+	ReportXSLFile     string        `yaml:"report_xsl_file" env:"VSCAN_AGENT_REPORT_XSL_FILE"`
+	LogStreamInterval time.Duration `yaml:"log_stream_interval" env:"VSCAN_AGENT_LOG_STREAM_INTERVAL"`
+}
+
+// SSH represents the settings of the SSH connections established by the VSCAN Agent.
+// Host keys of devices reached through an SSH gateway are recorded in the known_hosts file as
+// "<gateway IP>/<device IP>" since sites behind different gateways often reuse the same private addresses.
+type SSH struct {
+	DialTimeout    time.Duration `yaml:"dial_timeout" env:"VSCAN_AGENT_SSH_DIAL_TIMEOUT"`
+	HostKeyPolicy  string        `yaml:"host_key_policy" env:"VSCAN_AGENT_SSH_HOST_KEY_POLICY"`
+	KnownHostsFile string        `yaml:"known_hosts_file" env:"VSCAN_AGENT_SSH_KNOWN_HOSTS"`
+	ProxyURL       string        `yaml:"proxy_url" env:"VSCAN_AGENT_SSH_PROXY" secret:"true"`
+}
+
+// Admission represents the resource-aware admission control settings.
+// The default policy applies to the RPCs without a dedicated policy in methods, keyed by full or short method
+// name. Method policies set in the configuration file are merged with the default ones.
+type Admission struct {
+	// RetryAfter is the retry delay hint returned to rejected clients
+	RetryAfter time.Duration `yaml:"retry_after" env:"VSCAN_AGENT_ADMISSION_RETRY_AFTER"`
+
+	// DiskPath is the partition checked for free disk space. It defaults to joval scan_jobs_dir.
+	DiskPath string `yaml:"disk_path" env:"VSCAN_AGENT_ADMISSION_DISK_PATH"`
+
+	Default AdmissionPolicy            `yaml:"default"`
+	Methods map[string]AdmissionPolicy `yaml:"methods"`
+}
+
+// AdmissionPolicy represents the resource thresholds above which requests are rejected.
+// A zero threshold disables the corresponding check. The environment variables only apply to the default policy.
+type AdmissionPolicy struct {
+	Exempt bool `yaml:"exempt"`
+
+	// MaxLoadPerCPU is the 5 minutes Average System Load per CPU core
+	MaxLoadPerCPU     float64 `yaml:"max_load_per_cpu" env:"VSCAN_AGENT_MAX_LOAD_LIMIT"`
+	MaxMemoryPercent  float64 `yaml:"max_memory_percent" env:"VSCAN_AGENT_ADMISSION_MAX_MEMORY_PERCENT"`
+	MinFreeDiskMB     int     `yaml:"min_free_disk_mb" env:"VSCAN_AGENT_ADMISSION_MIN_FREE_DISK_MB"`
+	MaxJovalProcesses int     `yaml:"max_joval_processes" env:"VSCAN_AGENT_ADMISSION_MAX_JOVAL_PROCESSES"`
+}
+
+// RateLimit represents the per client identity rate limiting settings.
+// Identities map a client certificate Common Name or Subject Alternative Name to its quota. Other clients get the
+// default quota, tracked per Common Name.
+// ExemptMethods lists the RPCs not rate limited. By default, the controller can always call GetAgentInfo to pick
+// agents and Drain to take an agent out of rotation.
+type RateLimit struct {
+	Enabled bool `yaml:"enabled" env:"VSCAN_AGENT_RATE_LIMIT_ENABLED"`
+
+	ExemptMethods []string `yaml:"exempt_methods" env:"VSCAN_AGENT_RATE_LIMIT_EXEMPT_METHODS"`
+
+	// RetryAfter is the retry delay hint returned to clients exceeding their concurrent scans quota
+	RetryAfter time.Duration `yaml:"retry_after" env:"VSCAN_AGENT_RATE_LIMIT_RETRY_AFTER"`
+
+	Default    ClientQuota            `yaml:"default"`
+	Identities map[string]ClientQuota `yaml:"identities"`
+}
+
+// ClientQuota represents the request rate and concurrent scans allowed to a client identity.
+// A zero requests_per_second or max_concurrent_scans disables the corresponding limit.
+// The environment variables only apply to the default quota.
+type ClientQuota struct {
+	RequestsPerSecond  float64 `yaml:"requests_per_second" env:"VSCAN_AGENT_RATE_LIMIT_RPS"`
+	Burst              int     `yaml:"burst" env:"VSCAN_AGENT_RATE_LIMIT_BURST"`
+	MaxConcurrentScans int     `yaml:"max_concurrent_scans" env:"VSCAN_AGENT_RATE_LIMIT_MAX_CONCURRENT_SCANS"`
+}
+
+// Authz represents the client identity authorization settings.
+// Policies map an RPC name, or "*" for any other RPC, to the client certificate identities (Common Name or
+// Subject Alternative Names, glob patterns allowed) allowed to call it.
+// Patterns follow path.Match where "*" does not match "/": URI SANs such as SPIFFE IDs need one "*" per path
+// segment, e.g. "spiffe://vscan.internal/ns/*/sa/controller". A "*" pattern alone allows any client certificate.
+// Drain requires its own policy entry and is refused while authz is disabled.
+type Authz struct {
+	Enabled  bool                `yaml:"enabled" env:"VSCAN_AGENT_AUTHZ_ENABLED"`
+	Policies map[string][]string `yaml:"policies"`
+}
+
+// Metrics represents the Prometheus metrics endpoint settings.
+// The endpoint is served over plaintext HTTP and listens on the loopback interface by default: set ListenAddr to
+// e.g. ":9273" to expose it to a remote Prometheus server.
+type Metrics struct {
+	Enabled    bool   `yaml:"enabled" env:"VSCAN_AGENT_METRICS_ENABLED"`
+	ListenAddr string `yaml:"listen_addr" env:"VSCAN_AGENT_METRICS_LISTEN_ADDR"`
+}
+
+// Tracing represents the OpenTelemetry traces export settings
+type Tracing struct {
+	Enabled bool `yaml:"enabled" env:"VSCAN_AGENT_TRACING_ENABLED"`
+
+	// Endpoint is the host:port of the OTLP gRPC collector
+	Endpoint    string  `yaml:"endpoint" env:"VSCAN_AGENT_TRACING_ENDPOINT"`
+	Insecure    bool    `yaml:"insecure" env:"VSCAN_AGENT_TRACING_INSECURE"`
+	CACertFile  string  `yaml:"ca_cert_file" env:"VSCAN_AGENT_TRACING_CA_CERT_FILE"`
+	SampleRatio float64 `yaml:"sample_ratio" env:"VSCAN_AGENT_TRACING_SAMPLE_RATIO"`
+	ServiceName string  `yaml:"service_name" env:"VSCAN_AGENT_TRACING_SERVICE_NAME"`
+}
+
+// Reverse represents the reverse-connect settings where the agent dials out to the controller and serves its gRPC
+// service over the outbound connections.
+// The RPCs received over the tunnels carry the controller certificate as the client identity, not the identity of
+// the caller behind the controller: authz rules and rate_limit quotas keyed by client identity apply to the
+// controller identity as a whole for those RPCs.
+type Reverse struct {
+	Enabled        bool   `yaml:"enabled" env:"VSCAN_AGENT_REVERSE_ENABLED"`
+	ControllerAddr string `yaml:"controller_addr" env:"VSCAN_AGENT_REVERSE_CONTROLLER_ADDR"`
+
+	// ServerName is verified against the controller certificate. It defaults to the controller_addr host.
+	ServerName string `yaml:"server_name" env:"VSCAN_AGENT_REVERSE_SERVER_NAME"`
+
+	// Tunnels is the number of connections kept established with the controller
+	Tunnels     int           `yaml:"tunnels" env:"VSCAN_AGENT_REVERSE_TUNNELS"`
+	DialTimeout time.Duration `yaml:"dial_timeout" env:"VSCAN_AGENT_REVERSE_DIAL_TIMEOUT"`
+	MinBackoff  time.Duration `yaml:"min_backoff" env:"VSCAN_AGENT_REVERSE_MIN_BACKOFF"`
+	MaxBackoff  time.Duration `yaml:"max_backoff" env:"VSCAN_AGENT_REVERSE_MAX_BACKOFF"`
+
+	// DisableInbound stops the agent from listening on server bind_port
+	DisableInbound bool `yaml:"disable_inbound" env:"VSCAN_AGENT_REVERSE_DISABLE_INBOUND"`
+}
+
+// Controller represents the settings of the agent registration and heartbeats to the controller
+type Controller struct {
+	Enabled bool   `yaml:"enabled" env:"VSCAN_AGENT_CONTROLLER_ENABLED"`
+	Addr    string `yaml:"addr" env:"VSCAN_AGENT_CONTROLLER_ADDR"`
+
+	// ServerName is verified against the controller certificate. It defaults to the addr host.
+	ServerName string `yaml:"server_name" env:"VSCAN_AGENT_CONTROLLER_SERVER_NAME"`
+
+	// AdvertiseAddr is the host:port the controller uses to reach the agent.
+	// It defaults to the agent hostname and server bind_port unless inbound connections are disabled.
+	AdvertiseAddr string `yaml:"advertise_addr" env:"VSCAN_AGENT_CONTROLLER_ADVERTISE_ADDR"`
+
+	HeartbeatInterval time.Duration `yaml:"heartbeat_interval" env:"VSCAN_AGENT_CONTROLLER_HEARTBEAT_INTERVAL"`
+	RequestTimeout    time.Duration `yaml:"request_timeout" env:"VSCAN_AGENT_CONTROLLER_REQUEST_TIMEOUT"`
+}
+
+// Field represents a single configuration setting as printed at startup
+type Field struct {
+	Name  string
+	Value string
+}
+
+// lightAdmissionPolicy is the default admission policy of the RPCs not running the Joval scanner
+var lightAdmissionPolicy = AdmissionPolicy{MaxLoadPerCPU: 2, MaxMemoryPercent: 98}
+
+// current is the configuration in use. It holds the default settings until Load is called.
+var current = defaultConfig()
+
+// defaultConfig returns the VSCAN Agent default settings
+func defaultConfig() *Config {
+	return &Config{
+		Server: Server{
+			BindPort:   "50051",
+			BannerFile: "/opt/banner.txt",
+
+			HealthCheckInterval: 15 * time.Second,
+			DrainGracePeriod:    10 * time.Minute,
+			InterruptedJobsFile: "/opt/joval/interrupted-jobs.json",
+		},
+		TLS: TLS{
+			CertFile:   "/opt/certs/vscan-agent.pem",
+			KeyFile:    "/opt/certs/vscan-agent.key",
+			CACertFile: "/opt/certs/TCL-ENT-CA.pem",
+
+			ExpiryCheckInterval: 12 * time.Hour,
+			ExpiryWarningDays:   30,
+		},
+		ACME: ACME{
+			HTTPChallengeAddr: ":80",
+			AccountKeyFile:    "/opt/certs/acme-account.key",
+			RenewBefore:       30 * 24 * time.Hour,
+			CheckInterval:     12 * time.Hour,
+		},
+		Joval: Joval{
+			JavaBin:           "java",
+			JarFile:           "/opt/joval/Joval-Utilities.jar",
+			LicenseFile:       "/opt/joval/tatacommunications.com.sig.xml",
+			ScanJobsDir:       "/opt/joval/scanjobs",
+			ReportXSLFile:     "/opt/joval/tools/arf_xccdf_results_to_json_events.xsl",
+			LogStreamInterval: 500 * time.Millisecond,
+		},
+		SSH: SSH{
+			DialTimeout:    30 * time.Second,
+			HostKeyPolicy:  HostKeyPolicyTOFU,
+			KnownHostsFile: "/opt/ssh/known_hosts",
+		},
+		Admission: Admission{
+			RetryAfter: 30 * time.Second,
+			Default: AdmissionPolicy{
+				MaxLoadPerCPU:     0.9,
+				MaxMemoryPercent:  90,
+				MinFreeDiskMB:     1024,
+				MaxJovalProcesses: 8,
+			},
+			// Connectivity tests and discovery are short-lived SSH sessions tolerating a higher load
+			Methods: map[string]AdmissionPolicy{
+				"SSHConnectivityTest":     lightAdmissionPolicy,
+				"DeviceConnectivityTest":  lightAdmissionPolicy,
+				"DiscoverDevices":         lightAdmissionPolicy,
+				"BulkSSHConnectivityTest": lightAdmissionPolicy,
+
+				// The controller relies on GetAgentInfo to find out an agent is overloaded
+				"GetAgentInfo": {Exempt: true},
+				"Drain":        {Exempt: true},
+			},
+		},
+		RateLimit: RateLimit{
+			RetryAfter:    30 * time.Second,
+			ExemptMethods: []string{"GetAgentInfo", "Drain"},
+			Default: ClientQuota{
+				RequestsPerSecond:  10,
+				Burst:              20,
+				MaxConcurrentScans: 4,
+			},
+		},
+		Metrics: Metrics{
+			Enabled:    true,
+			ListenAddr: "127.0.0.1:9273",
+		},
+		Tracing: Tracing{
+			Endpoint:    "localhost:4317",
+			SampleRatio: 1.0,
+			ServiceName: "vscan-agent",
+		},
+		Controller: Controller{
+			HeartbeatInterval: 30 * time.Second,
+			RequestTimeout:    10 * time.Second,
+		},
+		Reverse: Reverse{
+			Tunnels:     1,
+			DialTimeout: 10 * time.Second,
+			MinBackoff:  time.Second,
+			MaxBackoff:  time.Minute,
+		},
+	}
+}
+
+// Get returns the VSCAN Agent configuration in use
+func Get() *Config {
+	return current
+}
+
+// Load reads the configuration file set in environment variable VSCAN_AGENT_CONFIG, or the default
+// configuration file if present, applies the environment variable overrides and validates the result.
+// It returns the name of the configuration file loaded, if any, and any error encountered.
+func Load() (string, error) {
+
+	cfg := defaultConfig()
+
+	cfgFile := os.Getenv("VSCAN_AGENT_CONFIG")
+
+	// The default configuration file is optional while one explicitly set must exist
+	if cfgFile == "" {
+		if _, err := os.Stat(defaultConfigFile); err == nil {
+			cfgFile = defaultConfigFile
+		}
+	}
+
+	if cfgFile != "" {
+
+		content, err := ioutil.ReadFile(cfgFile)
+
+		if err != nil {
+			return cfgFile, fmt.Errorf("unable to read configuration file %v: %v", cfgFile, err)
+		}
+
+		if err := yaml.UnmarshalStrict(content, cfg); err != nil {
+			return cfgFile, fmt.Errorf("unable to parse configuration file %v: %v", cfgFile, err)
+		}
+	}
+
+	if err := applyEnv(reflect.ValueOf(cfg).Elem()); err != nil {
+		return cfgFile, err
+	}
+
+	cfg.applyDefaults()
+
+	if err := cfg.validate(); err != nil {
+		return cfgFile, fmt.Errorf("invalid configuration: %v", err)
+	}
+
+	current = cfg
+
+	return cfgFile, nil
+}
+
+// applyEnv walks the configuration struct and overrides the settings for which the environment variable is set
+func applyEnv(v reflect.Value) error {
+
+	for i := 0; i < v.NumField(); i++ {
+
+		field := v.Field(i)
+		structField := v.Type().Field(i)
+
+		if field.Kind() == reflect.Struct {
+			if err := applyEnv(field); err != nil {
+				return err
+			}
+			continue
+		}
+
+		envName := structField.Tag.Get("env")
+		envValue, ok := os.LookupEnv(envName)
+
+		if envName == "" || !ok {
+			continue
+		}
+
+		if err := setField(field, envValue); err != nil {
+			return fmt.Errorf("invalid value for environment variable %v: %v", envName, err)
+		}
+	}
+
+	return nil
+}
+
+// setField parses the string value into the configuration setting according to its type
+func setField(field reflect.Value, value string) error {
+
+	switch {
+	case field.Type() == reflect.TypeOf(time.Duration(0)):
+		d, err := time.ParseDuration(value)
+
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+
+	case field.Kind() == reflect.String:
+		field.SetString(value)
+
+	case field.Type() == reflect.TypeOf([]string(nil)):
+		var values []string
+
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+		field.Set(reflect.ValueOf(values))
+
+	case field.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(value)
+
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+
+	case field.Kind() == reflect.Int:
+		i, err := strconv.Atoi(value)
+
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(i))
+
+	case field.Kind() == reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+
+	default:
+		return fmt.Errorf("unsupported setting type %v", field.Type())
+	}
+
+	return nil
+}
+
+// applyDefaults normalizes the settings and sets the defaults derived from other settings, once the configuration
+// file and environment variables are applied
+func (c *Config) applyDefaults() {
+
+	c.SSH.HostKeyPolicy = strings.ToLower(c.SSH.HostKeyPolicy)
+
+	if c.Admission.DiskPath == "" {
+		c.Admission.DiskPath = c.Joval.ScanJobsDir
+	}
+
+	if host, _, err := net.SplitHostPort(c.Reverse.ControllerAddr); err == nil && c.Reverse.ServerName == "" {
+		c.Reverse.ServerName = host
+	}
+
+	if host, _, err := net.SplitHostPort(c.Controller.Addr); err == nil && c.Controller.ServerName == "" {
+		c.Controller.ServerName = host
+	}
+}
+
+// validate checks the consistency of the configuration settings. It does not modify them.
+func (c *Config) validate() error {
+
+	port, err := strconv.Atoi(c.Server.BindPort)
+
+	if err != nil || port < 1 || port > 65535 {
+		return fmt.Errorf("server.bind_port %q is not a valid TCP port", c.Server.BindPort)
+	}
+
+	if c.Server.HealthCheckInterval <= 0 {
+		return fmt.Errorf("server.health_check_interval must be positive")
+	}
+
+	if c.Server.DrainGracePeriod < 0 {
+		return fmt.Errorf("server.drain_grace_period must not be negative")
+	}
+
+	required := map[string]string{
+		"server.interrupted_jobs_file": c.Server.InterruptedJobsFile,
+		"tls.cert_file":                c.TLS.CertFile,
+		"tls.key_file":                 c.TLS.KeyFile,
+		"tls.ca_cert_file":             c.TLS.CACertFile,
+		"joval.java_bin":               c.Joval.JavaBin,
+		"joval.jar_file":               c.Joval.JarFile,
+		"joval.license_file":           c.Joval.LicenseFile,
+		"joval.scan_jobs_dir":          c.Joval.ScanJobsDir,
+		"joval.report_xsl_file":        c.Joval.ReportXSLFile,
+		"ssh.known_hosts_file":         c.SSH.KnownHostsFile,
+	}
+
+	for name, value := range required {
+		if strings.TrimSpace(value) == "" {
+			return fmt.Errorf("%v must be set", name)
+		}
+	}
+
+	if c.TLS.ExpiryCheckInterval <= 0 {
+		return fmt.Errorf("tls.expiry_check_interval must be positive")
+	}
+
+	if c.TLS.ExpiryWarningDays < 0 {
+		return fmt.Errorf("tls.expiry_warning_days must not be negative")
+	}
+
+	if c.ACME.Enabled {
+
+		if c.ACME.DirectoryURL == "" {
+			return fmt.Errorf("acme.directory_url must be set when acme is enabled")
+		}
+
+		if c.ACME.AccountKeyFile == "" || c.ACME.HTTPChallengeAddr == "" {
+			return fmt.Errorf("acme.account_key_file and acme.http_challenge_addr must be set when acme is enabled")
+		}
+
+		if c.ACME.RenewBefore <= 0 || c.ACME.CheckInterval <= 0 {
+			return fmt.Errorf("acme.renew_before and acme.check_interval must be positive")
+		}
+	}
+
+	if c.Joval.LogStreamInterval <= 0 {
+		return fmt.Errorf("joval.log_stream_interval must be positive")
+	}
+
+	if c.SSH.DialTimeout <= 0 {
+		return fmt.Errorf("ssh.dial_timeout must be positive")
+	}
+
+	switch c.SSH.HostKeyPolicy {
+	case HostKeyPolicyStrict, HostKeyPolicyTOFU, HostKeyPolicyInsecure:
+	default:
+		return fmt.Errorf("ssh.host_key_policy %q is not supported. Supported policies: %v, %v, %v",
+			c.SSH.HostKeyPolicy, HostKeyPolicyStrict, HostKeyPolicyTOFU, HostKeyPolicyInsecure)
+	}
+
+	if c.SSH.ProxyURL != "" {
+
+		u, err := url.Parse(c.SSH.ProxyURL)
+
+		if err != nil || u.Host == "" {
+			return fmt.Errorf("ssh.proxy_url is not a valid URL")
+		}
+
+		switch u.Scheme {
+		case "socks5", "socks5h", "http":
+		default:
+			return fmt.Errorf("ssh.proxy_url scheme %q is not supported. Supported schemes: socks5, http", u.Scheme)
+		}
+	}
+
+	if c.Admission.RetryAfter <= 0 {
+		return fmt.Errorf("admission.retry_after must be positive")
+	}
+
+	if err := c.Admission.Default.validate("admission.default"); err != nil {
+		return err
+	}
+
+	for method, policy := range c.Admission.Methods {
+		if err := policy.validate("admission.methods." + method); err != nil {
+			return err
+		}
+	}
+
+	if c.Metrics.Enabled && c.Metrics.ListenAddr == "" {
+		return fmt.Errorf("metrics.listen_addr must be set when metrics are enabled")
+	}
+
+	if c.Tracing.Enabled && (c.Tracing.Endpoint == "" || c.Tracing.ServiceName == "") {
+		return fmt.Errorf("tracing.endpoint and tracing.service_name must be set when tracing is enabled")
+	}
+
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		return fmt.Errorf("tracing.sample_ratio must be between 0 and 1")
+	}
+
+	if c.Reverse.Enabled {
+
+		if _, _, err := net.SplitHostPort(c.Reverse.ControllerAddr); err != nil {
+			return fmt.Errorf("reverse.controller_addr %q is not a valid host:port address", c.Reverse.ControllerAddr)
+		}
+
+		if c.Reverse.Tunnels < 1 {
+			return fmt.Errorf("reverse.tunnels must be at least 1")
+		}
+
+		if c.Reverse.DialTimeout <= 0 || c.Reverse.MinBackoff <= 0 || c.Reverse.MaxBackoff < c.Reverse.MinBackoff {
+			return fmt.Errorf("reverse.dial_timeout and reverse.min_backoff must be positive and " +
+				"reverse.max_backoff must not be lower than reverse.min_backoff")
+		}
+	}
+
+	if c.Controller.Enabled {
+
+		if _, _, err := net.SplitHostPort(c.Controller.Addr); err != nil {
+			return fmt.Errorf("controller.addr %q is not a valid host:port address", c.Controller.Addr)
+		}
+
+		if c.Controller.HeartbeatInterval <= 0 || c.Controller.RequestTimeout <= 0 {
+			return fmt.Errorf("controller.heartbeat_interval and controller.request_timeout must be positive")
+		}
+	}
+
+	if c.RateLimit.RetryAfter <= 0 {
+		return fmt.Errorf("rate_limit.retry_after must be positive")
+	}
+
+	if err := c.RateLimit.Default.validate("rate_limit.default"); err != nil {
+		return err
+	}
+
+	for identity, quota := range c.RateLimit.Identities {
+		if err := quota.validate("rate_limit.identities." + identity); err != nil {
+			return err
+		}
+	}
+
+	if c.Authz.Enabled && len(c.Authz.Policies) == 0 {
+		return fmt.Errorf("authz.policies must be set when authz is enabled")
+	}
+
+	// Drain takes the agent out of rotation for good: it is never allowed through the "*" fallback policy
+	if c.Authz.Enabled && len(c.Authz.Policies["Drain"]) == 0 &&
+		len(c.Authz.Policies["/agentpb.VscanAgentService/Drain"]) == 0 {
+		return fmt.Errorf("authz.policies must hold a Drain entry listing the identities allowed to drain the agent")
+	}
+
+	for rpc, patterns := range c.Authz.Policies {
+		for _, pattern := range patterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("authz.policies %v pattern %q is invalid: %v", rpc, pattern, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validate checks the admission policy thresholds
+func (p AdmissionPolicy) validate(name string) error {
+
+	if p.MaxLoadPerCPU < 0 || p.MinFreeDiskMB < 0 || p.MaxJovalProcesses < 0 {
+		return fmt.Errorf("%v thresholds must not be negative", name)
+	}
+
+	if p.MaxMemoryPercent < 0 || p.MaxMemoryPercent > 100 {
+		return fmt.Errorf("%v max_memory_percent must be between 0 and 100", name)
+	}
+
+	return nil
+}
+
+// validate checks the client quota limits
+func (q ClientQuota) validate(name string) error {
+
+	if q.RequestsPerSecond < 0 || q.Burst < 0 || q.MaxConcurrentScans < 0 {
+		return fmt.Errorf("%v limits must not be negative", name)
+	}
+
+	return nil
+}
+
+// Fields returns the configuration settings in declaration order, with secret settings masked
+func (c *Config) Fields() []Field {
+	return appendFields(nil, "", reflect.ValueOf(c).Elem())
+}
+
+func appendFields(fields []Field, prefix string, v reflect.Value) []Field {
+
+	for i := 0; i < v.NumField(); i++ {
+
+		field := v.Field(i)
+		structField := v.Type().Field(i)
+
+		name := prefix + structField.Tag.Get("yaml")
+
+		if field.Kind() == reflect.Struct {
+			fields = appendFields(fields, name+".", field)
+			continue
+		}
+
+		value := fmt.Sprint(field.Interface())
+
+		if structField.Tag.Get("secret") == "true" && value != "" {
+			value = "********"
+		}
+
+		fields = append(fields, Field{Name: name, Value: value})
+	}
+
+	return fields
+}
@@ -9,6 +9,8 @@ import (
 	"strings"
 
 	"github.com/go-ini/ini"
+	"github.com/lucabrasi83/vscan-agent/config"
+	"github.com/lucabrasi83/vscan-agent/logging"
 	agentpb "github.com/lucabrasi83/vscan-agent/proto"
 )
 
@@ -46,7 +48,7 @@ func BuildIni(jobID string, dev []*agentpb.Device, jovalSource string, sshGW *ag
 		input.type = xccdf_results
 		output.extension = json 
 		transform.file =` + filepath.FromSlash(
-				"/opt/joval/tools/arf_xccdf_results_to_json_events.xsl")))
+				config.Get().Joval.ReportXSLFile)))
 
 	if err != nil {
 		return nil, fmt.Errorf("error while loading default ini content for job ID %v: %v", jobID, err)
@@ -85,7 +87,7 @@ func BuildIni(jobID string, dev []*agentpb.Device, jovalSource string, sshGW *ag
 			XccdfVersion: 0,
 		},
 		Logs{
-			ExportDir:       filepath.FromSlash("/opt/joval/scanjobs/" + jobID + "/logs"),
+			ExportDir:       filepath.Join(config.Get().Joval.ScanJobsDir, jobID, "logs"),
 			Level:           "off",
 			OutputExtension: ".log",
 		},
@@ -101,7 +103,7 @@ func BuildIni(jobID string, dev []*agentpb.Device, jovalSource string, sshGW *ag
 	}
 
 	// Assigns directory name per scan job ID
-	dir := filepath.FromSlash("/opt/joval/scanjobs/" + jobID)
+	dir := filepath.Join(config.Get().Joval.ScanJobsDir, jobID)
 
 	// Check whether the directory to be created already exists. If not, we create it with Unix permission 0750
 	if _, errDirNotExist := os.Stat(dir); os.IsNotExist(errDirNotExist) {
@@ -124,6 +126,10 @@ func BuildIni(jobID string, dev []*agentpb.Device, jovalSource string, sshGW *ag
 
 func buildSSHGatewaySections(cfg *ini.File, sshGW *agentpb.SSHGateway) error {
 
+	if sshGW.GetGatewayCertificate() != "" && sshGW.GetGatewayPrivateKey() == "" {
+		return fmt.Errorf("SSH gateway %v certificate requires the private key it certifies", sshGW.GetGatewayName())
+	}
+
 	sshGWCredSec, err := cfg.NewSection("Credential: " + "ssh-gateway")
 
 	if err != nil {
@@ -141,8 +147,15 @@ func buildSSHGatewaySections(cfg *ini.File, sshGW *agentpb.SSHGateway) error {
 		return fmt.Errorf("error while setting SSH gateway username key in config.ini: %v ", err)
 	}
 
-	if sshGW.GatewayPassword != "" {
-		_, err = sshGWCredSec.NewKey("password", sshGW.GetGatewayPassword())
+	// Joval replies to keyboard-interactive password prompts with the credentials password
+	gwPassword, err := gatewayKeyboardInteractivePassword(sshGW)
+
+	if err != nil {
+		return err
+	}
+
+	if gwPassword != "" {
+		_, err = sshGWCredSec.NewKey("password", gwPassword)
 
 		if err != nil {
 			return fmt.Errorf("error while setting SSH gateway password key in config.ini: %v ", err)
@@ -160,6 +173,22 @@ func buildSSHGatewaySections(cfg *ini.File, sshGW *agentpb.SSHGateway) error {
 			return fmt.Errorf("error while setting SSH gateway private key in config.ini: %v ", err)
 		}
 
+		if sshGW.GatewayPrivateKeyPassphrase != "" {
+			_, err = sshGWCredSec.NewKey("passphrase", sshGW.GetGatewayPrivateKeyPassphrase())
+
+			if err != nil {
+				return fmt.Errorf("error while setting SSH gateway private key passphrase in config.ini: %v ", err)
+			}
+		}
+
+		if sshGW.GatewayCertificate != "" {
+			_, err = sshGWCredSec.NewKey("certificate", strings.TrimSpace(sshGW.GetGatewayCertificate()))
+
+			if err != nil {
+				return fmt.Errorf("error while setting SSH gateway certificate in config.ini: %v ", err)
+			}
+		}
+
 	}
 
 	sshGwSec, err := cfg.NewSection("Gateway: " + sshGW.GetGatewayName())
@@ -184,8 +213,43 @@ func buildSSHGatewaySections(cfg *ini.File, sshGW *agentpb.SSHGateway) error {
 
 }
 
+// gatewayKeyboardInteractivePassword returns the SSH gateway password or, if not set, the keyboard-interactive
+// answer to the password prompt. Other keyboard-interactive prompts are not supported by Joval.
+// Prompt patterns are matched the same way as by the SSH connectivity test.
+func gatewayKeyboardInteractivePassword(sshGW *agentpb.SSHGateway) (string, error) {
+
+	password := sshGW.GetGatewayPassword()
+
+	for _, a := range sshGW.GetGatewayKeyboardInteractiveAnswers() {
+
+		prompt, err := CompilePromptPattern(a.GetPromptPattern())
+
+		if err != nil {
+			return "", fmt.Errorf("error while setting SSH gateway keyboard-interactive answers: %v", err)
+		}
+
+		if isPasswordPrompt(prompt) {
+			if password == "" {
+				password = a.GetAnswer()
+			}
+			continue
+		}
+
+		logging.VSCANLog("warning",
+			"SSH gateway %v keyboard-interactive prompt %q is not supported by Joval and will not be answered",
+			sshGW.GetGatewayName(), a.GetPromptPattern())
+	}
+
+	return password, nil
+}
+
 func buildDeviceCredentialsSections(cfg *ini.File, creds *agentpb.UserDeviceCredentials) error {
 
+	if creds.GetCertificate() != "" && creds.GetPrivateKey() == "" {
+		return fmt.Errorf("SSH device credentials %v certificate requires the private key it certifies",
+			creds.GetCredentialsName())
+	}
+
 	deviceCredSec, err := cfg.NewSection("Credential: " + creds.GetCredentialsName())
 
 	if err != nil {
@@ -211,12 +275,9 @@ func buildDeviceCredentialsSections(cfg *ini.File, creds *agentpb.UserDeviceCred
 		}
 	}
 
-	if creds.CredentialsDeviceVendor == "CISCO" && creds.GetIosEnablePassword() != "" {
-		_, err = deviceCredSec.NewKey("ios_enable_password", creds.GetIosEnablePassword())
-
-		if err != nil {
-			return fmt.Errorf("error while setting SSH device IOS enable password key in config.ini: %v ", err)
-		}
+	// Add vendor specific privilege escalation keys (enable, sudo, su)
+	if err = buildPrivilegeKeys(deviceCredSec, creds); err != nil {
+		return err
 	}
 
 	if creds.PrivateKey != "" {
@@ -230,6 +291,22 @@ func buildDeviceCredentialsSections(cfg *ini.File, creds *agentpb.UserDeviceCred
 			return fmt.Errorf("error when setting SSH device private key in config.ini: %v ", err)
 		}
 
+		if creds.PrivateKeyPassphrase != "" {
+			_, err = deviceCredSec.NewKey("passphrase", creds.GetPrivateKeyPassphrase())
+
+			if err != nil {
+				return fmt.Errorf("error when setting SSH device private key passphrase in config.ini: %v ", err)
+			}
+		}
+
+		if creds.Certificate != "" {
+			_, err = deviceCredSec.NewKey("certificate", strings.TrimSpace(creds.GetCertificate()))
+
+			if err != nil {
+				return fmt.Errorf("error when setting SSH device certificate in config.ini: %v ", err)
+			}
+		}
+
 	}
 
 	return nil
@@ -240,7 +317,7 @@ func buildDeviceCredentialsSections(cfg *ini.File, creds *agentpb.UserDeviceCred
 func dynaIniGen(cfg *ini.File, jobID string, dev []*agentpb.Device, sshGWName string, credsName string) error {
 
 	_, err := cfg.Section(
-		"Report: JSON").NewKey("export.dir", filepath.FromSlash("/opt/joval/scanjobs/"+jobID+"/reports"))
+		"Report: JSON").NewKey("export.dir", filepath.Join(config.Get().Joval.ScanJobsDir, jobID, "reports"))
 
 	if err != nil {
 		return fmt.Errorf("error while setting reports directory in config.ini: %v ", err)
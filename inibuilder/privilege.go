@@ -0,0 +1,140 @@
+package inibuilder
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-ini/ini"
+	agentpb "github.com/lucabrasi83/vscan-agent/proto"
+)
+
+// jovalPrivilegeKeys maps each supported vendor / privilege method pair to the Joval credential keys it requires.
+// The value of the key is resolved by privilegeKeyValue.
+// A vendor / method pair not present in the map is not supported by Joval and rejected.
+var jovalPrivilegeKeys = map[agentpb.DeviceVendor]map[agentpb.PrivilegeMethod][]string{
+	agentpb.DeviceVendor_CISCO: {
+		agentpb.PrivilegeMethod_ENABLE: {"ios_enable_password"},
+	},
+	agentpb.DeviceVendor_ARISTA: {
+		agentpb.PrivilegeMethod_ENABLE: {"ios_enable_password"},
+	},
+	agentpb.DeviceVendor_LINUX: {
+		agentpb.PrivilegeMethod_SUDO: {"privilege_escalation"},
+		agentpb.PrivilegeMethod_SU:   {"privilege_escalation", "root_password"},
+	},
+}
+
+// loginPrivilegeVendors are the vendors Joval scans with the privileges of the login account, which must hold the
+// operational read permissions. Junos and PAN-OS have no privilege escalation command Joval could run, so any
+// privilege method set for them is rejected with errLoginPrivilegeOnly.
+var loginPrivilegeVendors = map[agentpb.DeviceVendor]bool{
+	agentpb.DeviceVendor_JUNIPER:   true,
+	agentpb.DeviceVendor_PALO_ALTO: true,
+}
+
+// errLoginPrivilegeOnly is returned for a privilege method set on a vendor scanned with the login account privileges
+var errLoginPrivilegeOnly = errors.New("privilege escalation is not supported")
+
+// jovalPrivilegeEscalation maps the privilege methods to the privilege_escalation values of the Joval SSH
+// credential section: "SUDO" runs the commands with sudo and "SU" switches to root with the root_password.
+// The values are set explicitly rather than from the enum names so renaming an enum value does not change them.
+var jovalPrivilegeEscalation = map[agentpb.PrivilegeMethod]string{
+	agentpb.PrivilegeMethod_SUDO: "SUDO",
+	agentpb.PrivilegeMethod_SU:   "SU",
+}
+
+// CredentialsVendor returns the vendor the credentials apply to.
+// The device_vendor enum takes precedence over the legacy credentials_device_vendor string.
+func CredentialsVendor(creds *agentpb.UserDeviceCredentials) agentpb.DeviceVendor {
+
+	if creds.GetDeviceVendor() != agentpb.DeviceVendor_VENDOR_UNSPECIFIED {
+		return creds.GetDeviceVendor()
+	}
+
+	legacyVendor := strings.ToUpper(strings.TrimSpace(creds.GetCredentialsDeviceVendor()))
+	legacyVendor = strings.NewReplacer(" ", "_", "-", "_").Replace(legacyVendor)
+
+	if legacyVendor == "PALOALTO" {
+		legacyVendor = "PALO_ALTO"
+	}
+
+	return agentpb.DeviceVendor(agentpb.DeviceVendor_value[legacyVendor])
+}
+
+// CredentialsPrivilege returns the effective privilege method and password of the credentials, as used in the
+// generated config.ini. Cisco credentials without privilege method but with a privilege_password or the legacy
+// ios_enable_password are treated as ENABLE privilege method.
+func CredentialsPrivilege(creds *agentpb.UserDeviceCredentials) (agentpb.PrivilegeMethod, string) {
+
+	vendor := CredentialsVendor(creds)
+
+	privPassword := creds.GetPrivilegePassword()
+
+	if privPassword == "" {
+		privPassword = creds.GetIosEnablePassword()
+	}
+
+	method := creds.GetPrivilegeMethod()
+
+	if method == agentpb.PrivilegeMethod_PRIVILEGE_NONE && vendor == agentpb.DeviceVendor_CISCO && privPassword != "" {
+		method = agentpb.PrivilegeMethod_ENABLE
+	}
+
+	return method, privPassword
+}
+
+// privilegeKeyValue returns the value to set for a given Joval privilege key
+func privilegeKeyValue(key string, method agentpb.PrivilegeMethod, privPassword string) string {
+
+	if key == "privilege_escalation" {
+		return jovalPrivilegeEscalation[method]
+	}
+
+	return privPassword
+}
+
+// buildPrivilegeKeys adds the vendor specific privilege escalation keys to the device credentials section
+func buildPrivilegeKeys(deviceCredSec *ini.Section, creds *agentpb.UserDeviceCredentials) error {
+
+	vendor := CredentialsVendor(creds)
+
+	method, privPassword := CredentialsPrivilege(creds)
+
+	if method == agentpb.PrivilegeMethod_PRIVILEGE_NONE {
+		return nil
+	}
+
+	if loginPrivilegeVendors[vendor] {
+		return fmt.Errorf("%w for device vendor %v: Joval scans it with the login account privileges. "+
+			"Set privilege method %v and grant the login account the required permissions", errLoginPrivilegeOnly,
+			vendor, agentpb.PrivilegeMethod_PRIVILEGE_NONE)
+	}
+
+	vendorKeys, ok := jovalPrivilegeKeys[vendor]
+
+	if !ok {
+		return fmt.Errorf("privilege method %v requires a supported device vendor, got %q",
+			method, creds.GetCredentialsDeviceVendor())
+	}
+
+	keys, ok := vendorKeys[method]
+
+	if !ok {
+		return fmt.Errorf("privilege method %v is not supported for device vendor %v", method, vendor)
+	}
+
+	if method != agentpb.PrivilegeMethod_SUDO && privPassword == "" {
+		return fmt.Errorf("privilege method %v requires a privilege password", method)
+	}
+
+	for _, k := range keys {
+		_, err := deviceCredSec.NewKey(k, privilegeKeyValue(k, method, privPassword))
+
+		if err != nil {
+			return fmt.Errorf("error while setting SSH device %v key in config.ini: %v ", k, err)
+		}
+	}
+
+	return nil
+}
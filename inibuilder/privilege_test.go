@@ -0,0 +1,194 @@
+package inibuilder
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/go-ini/ini"
+	"github.com/lucabrasi83/vscan-agent/config"
+	agentpb "github.com/lucabrasi83/vscan-agent/proto"
+)
+
+// errUnsupported marks the test cases expecting any error
+var errUnsupported = errors.New("unsupported")
+
+func TestCredentialsPrivilege(t *testing.T) {
+
+	tests := []struct {
+		name         string
+		creds        *agentpb.UserDeviceCredentials
+		wantMethod   agentpb.PrivilegeMethod
+		wantPassword string
+	}{
+		{
+			name:       "no privilege",
+			creds:      &agentpb.UserDeviceCredentials{DeviceVendor: agentpb.DeviceVendor_CISCO},
+			wantMethod: agentpb.PrivilegeMethod_PRIVILEGE_NONE,
+		},
+		{
+			name: "explicit method",
+			creds: &agentpb.UserDeviceCredentials{DeviceVendor: agentpb.DeviceVendor_LINUX,
+				PrivilegeMethod: agentpb.PrivilegeMethod_SU, PrivilegePassword: "root"},
+			wantMethod:   agentpb.PrivilegeMethod_SU,
+			wantPassword: "root",
+		},
+		{
+			name: "legacy Cisco enable password",
+			creds: &agentpb.UserDeviceCredentials{CredentialsDeviceVendor: "cisco",
+				IosEnablePassword: "enable"},
+			wantMethod:   agentpb.PrivilegeMethod_ENABLE,
+			wantPassword: "enable",
+		},
+		{
+			name: "Cisco privilege password without method",
+			creds: &agentpb.UserDeviceCredentials{DeviceVendor: agentpb.DeviceVendor_CISCO,
+				PrivilegePassword: "enable"},
+			wantMethod:   agentpb.PrivilegeMethod_ENABLE,
+			wantPassword: "enable",
+		},
+		{
+			name: "privilege password takes precedence",
+			creds: &agentpb.UserDeviceCredentials{DeviceVendor: agentpb.DeviceVendor_CISCO,
+				PrivilegeMethod: agentpb.PrivilegeMethod_ENABLE, PrivilegePassword: "new",
+				IosEnablePassword: "legacy"},
+			wantMethod:   agentpb.PrivilegeMethod_ENABLE,
+			wantPassword: "new",
+		},
+		{
+			name: "password without method on other vendors",
+			creds: &agentpb.UserDeviceCredentials{DeviceVendor: agentpb.DeviceVendor_ARISTA,
+				PrivilegePassword: "enable"},
+			wantMethod:   agentpb.PrivilegeMethod_PRIVILEGE_NONE,
+			wantPassword: "enable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			method, password := CredentialsPrivilege(tt.creds)
+
+			if method != tt.wantMethod || password != tt.wantPassword {
+				t.Errorf("CredentialsPrivilege() = %v, %q, want %v, %q", method, password, tt.wantMethod,
+					tt.wantPassword)
+			}
+		})
+	}
+}
+
+// useScanJobsDir sets a temporary scan jobs directory for the config.ini files generated by the test
+func useScanJobsDir(t *testing.T) {
+
+	t.Helper()
+
+	jovalConfig := &config.Get().Joval
+	previous := *jovalConfig
+
+	jovalConfig.ScanJobsDir = t.TempDir()
+
+	t.Cleanup(func() { *jovalConfig = previous })
+}
+
+func TestBuildIniPrivilege(t *testing.T) {
+
+	useScanJobsDir(t)
+
+	tests := []struct {
+		name     string
+		vendor   agentpb.DeviceVendor
+		method   agentpb.PrivilegeMethod
+		password string
+		wantKeys map[string]string
+		wantErr  error
+	}{
+		{name: "Cisco enable", vendor: agentpb.DeviceVendor_CISCO, method: agentpb.PrivilegeMethod_ENABLE,
+			password: "enable", wantKeys: map[string]string{"ios_enable_password": "enable"}},
+		{name: "Cisco sudo", vendor: agentpb.DeviceVendor_CISCO, method: agentpb.PrivilegeMethod_SUDO,
+			wantErr: errUnsupported},
+		{name: "Arista enable", vendor: agentpb.DeviceVendor_ARISTA, method: agentpb.PrivilegeMethod_ENABLE,
+			password: "enable", wantKeys: map[string]string{"ios_enable_password": "enable"}},
+		{name: "Arista enable without password", vendor: agentpb.DeviceVendor_ARISTA,
+			method: agentpb.PrivilegeMethod_ENABLE, wantErr: errUnsupported},
+		{name: "Arista su", vendor: agentpb.DeviceVendor_ARISTA, method: agentpb.PrivilegeMethod_SU,
+			password: "root", wantErr: errUnsupported},
+		{name: "Linux sudo", vendor: agentpb.DeviceVendor_LINUX, method: agentpb.PrivilegeMethod_SUDO,
+			wantKeys: map[string]string{"privilege_escalation": "SUDO"}},
+		{name: "Linux su", vendor: agentpb.DeviceVendor_LINUX, method: agentpb.PrivilegeMethod_SU,
+			password: "root", wantKeys: map[string]string{"privilege_escalation": "SU", "root_password": "root"}},
+		{name: "Linux su without password", vendor: agentpb.DeviceVendor_LINUX, method: agentpb.PrivilegeMethod_SU,
+			wantErr: errUnsupported},
+		{name: "Linux enable", vendor: agentpb.DeviceVendor_LINUX, method: agentpb.PrivilegeMethod_ENABLE,
+			password: "enable", wantErr: errUnsupported},
+		{name: "Juniper login privileges", vendor: agentpb.DeviceVendor_JUNIPER,
+			method: agentpb.PrivilegeMethod_PRIVILEGE_NONE, wantKeys: map[string]string{}},
+		{name: "Juniper sudo", vendor: agentpb.DeviceVendor_JUNIPER, method: agentpb.PrivilegeMethod_SUDO,
+			wantErr: errLoginPrivilegeOnly},
+		{name: "Palo Alto login privileges", vendor: agentpb.DeviceVendor_PALO_ALTO,
+			method: agentpb.PrivilegeMethod_PRIVILEGE_NONE, wantKeys: map[string]string{}},
+		{name: "Palo Alto enable", vendor: agentpb.DeviceVendor_PALO_ALTO, method: agentpb.PrivilegeMethod_ENABLE,
+			password: "enable", wantErr: errLoginPrivilegeOnly},
+		{name: "unspecified vendor", method: agentpb.PrivilegeMethod_SUDO, wantErr: errUnsupported},
+	}
+
+	privilegeKeys := []string{"ios_enable_password", "privilege_escalation", "root_password"}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			creds := &agentpb.UserDeviceCredentials{
+				CredentialsName:   "device-creds",
+				Username:          "vscan",
+				Password:          "login",
+				DeviceVendor:      tt.vendor,
+				PrivilegeMethod:   tt.method,
+				PrivilegePassword: tt.password,
+			}
+
+			r, err := BuildIni("job-1", []*agentpb.Device{{DeviceName: "dev-1", IpAddress: "192.0.2.1"}},
+				"source.xml", nil, creds)
+
+			if tt.wantErr != nil {
+				if err == nil || (tt.wantErr != errUnsupported && !errors.Is(err, tt.wantErr)) {
+					t.Fatalf("BuildIni() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("BuildIni() error = %v", err)
+			}
+
+			content, err := ioutil.ReadAll(r)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := ini.Load(bytes.TrimSuffix(content, []byte("#EOF")))
+
+			if err != nil {
+				t.Fatalf("generated config.ini does not parse: %v", err)
+			}
+
+			sec := cfg.Section("Credential: device-creds")
+
+			for _, k := range privilegeKeys {
+
+				want, ok := tt.wantKeys[k]
+
+				if !sec.HasKey(k) {
+					if ok {
+						t.Errorf("key %v not set, want %q", k, want)
+					}
+					continue
+				}
+
+				if got := sec.Key(k).String(); !ok || got != want {
+					t.Errorf("key %v = %q, want %q (set %v)", k, got, want, ok)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package inibuilder
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// passwordPromptSamples are the usual keyboard-interactive password prompts of SSH servers
+var passwordPromptSamples = []string{"Password:", "Password: ", "Password for user@device: "}
+
+// CompilePromptPattern compiles a keyboard-interactive prompt pattern. Patterns are case-insensitive regular
+// expressions matched anywhere in the prompt.
+func CompilePromptPattern(pattern string) (*regexp.Regexp, error) {
+
+	re, err := regexp.Compile("(?i)" + pattern)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid keyboard-interactive prompt pattern %q: %v", pattern, err)
+	}
+
+	return re, nil
+}
+
+// isPasswordPrompt returns whether the compiled prompt pattern answers the password prompt
+func isPasswordPrompt(prompt *regexp.Regexp) bool {
+
+	for _, sample := range passwordPromptSamples {
+		if prompt.MatchString(sample) {
+			return true
+		}
+	}
+
+	return false
+}
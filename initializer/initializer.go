@@ -9,6 +9,7 @@ import (
 	"runtime"
 	"strconv"
 
+	"github.com/lucabrasi83/vscan-agent/config"
 	"github.com/lucabrasi83/vscan-agent/logging"
 	"github.com/shirou/gopsutil/cpu"
 	"github.com/shirou/gopsutil/disk"
@@ -24,15 +25,24 @@ var (
 )
 
 func Initialize() {
+
+	cfgFile, err := config.Load()
+
 	printBanner()
 	printReleaseDetails()
+
+	if err != nil {
+		logging.VSCANLog("fatal", "unable to load VSCAN Agent configuration: %v", err)
+	}
+
+	printConfigDetails(cfgFile)
 	printPlatformDetails()
 }
 
 func printBanner() {
 
 	fmt.Printf("\n")
-	banner, err := os.Open("/opt/banner.txt")
+	banner, err := os.Open(config.Get().Server.BannerFile)
 	if err != nil {
 		logging.VSCANLog("error", "Not able to load banner: %v", err)
 	}
@@ -58,6 +68,23 @@ func printReleaseDetails() {
 	fmt.Printf("\n")
 }
 
+// printConfigDetails displays the configuration file loaded, if any, and the VSCAN Agent settings in use.
+// Secret settings are masked.
+func printConfigDetails(cfgFile string) {
+
+	if cfgFile == "" {
+		cfgFile = "none (defaults and environment variables)"
+	}
+
+	fmt.Println(logging.UnderlineText("Configuration File:"), logging.InfoMessage(cfgFile))
+
+	for _, f := range config.Get().Fields() {
+		fmt.Println(logging.UnderlineText(f.Name+":"), logging.InfoMessage(f.Value))
+	}
+
+	fmt.Printf("\n")
+}
+
 // printPlatformDetails is called as part of init() function and display local platform details such as
 // CPU info, OS & kernel Version, disk usage on partition "/",...
 func printPlatformDetails() {
@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"github.com/lucabrasi83/vscan-agent/certmanager"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// certificateExpiryCollector reports the days left before the TLS certificates of the agent expire
+type certificateExpiryCollector struct {
+	certManager  *certmanager.Manager
+	daysToExpiry *prometheus.Desc
+}
+
+// RegisterCertificateExpiry exposes the days left before the server certificate, its intermediate certificates and
+// the CA bundle certificates loaded by the certificate manager expire
+func RegisterCertificateExpiry(certManager *certmanager.Manager) {
+	registry.MustRegister(newCertificateExpiryCollector(certManager))
+}
+
+func newCertificateExpiryCollector(certManager *certmanager.Manager) *certificateExpiryCollector {
+	return &certificateExpiryCollector{
+		certManager: certManager,
+		daysToExpiry: prometheus.NewDesc(prometheus.BuildFQName(namespace, "certificate", "days_to_expiry"),
+			"Days left before the TLS certificate expires. Negative once expired.",
+			[]string{"certificate", "subject", "serial"}, nil),
+	}
+}
+
+func (c *certificateExpiryCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.daysToExpiry
+}
+
+func (c *certificateExpiryCollector) Collect(ch chan<- prometheus.Metric) {
+
+	// A CA bundle may hold the same certificate twice, which would fail the whole scrape with duplicate series
+	seen := make(map[[3]string]bool)
+
+	for _, e := range c.certManager.Expiries() {
+
+		key := [3]string{e.Name, e.Subject, e.Serial}
+
+		if seen[key] {
+			continue
+		}
+
+		seen[key] = true
+
+		ch <- prometheus.MustNewConstMetric(c.daysToExpiry, prometheus.GaugeValue, float64(e.DaysToExpiry()),
+			e.Name, e.Subject, e.Serial)
+	}
+}
@@ -0,0 +1,94 @@
+package metrics
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shirou/gopsutil/process"
+)
+
+// jovalCollector reports the resource usage of the running Joval processes
+var jovalCollector = &jovalProcessCollector{
+	pids: make(map[int32]bool),
+
+	processes: prometheus.NewDesc(prometheus.BuildFQName(namespace, "joval", "processes"),
+		"Number of running Joval processes.", nil, nil),
+	cpuSeconds: prometheus.NewDesc(prometheus.BuildFQName(namespace, "joval", "cpu_seconds"),
+		"User and system CPU time spent by the running Joval processes in seconds.", nil, nil),
+	residentMemory: prometheus.NewDesc(prometheus.BuildFQName(namespace, "joval", "resident_memory_bytes"),
+		"Resident memory size of the running Joval processes in bytes.", nil, nil),
+}
+
+// jovalProcessCollector collects the CPU and memory usage of the tracked Joval processes on each scrape
+type jovalProcessCollector struct {
+	mu   sync.Mutex
+	pids map[int32]bool
+
+	processes      *prometheus.Desc
+	cpuSeconds     *prometheus.Desc
+	residentMemory *prometheus.Desc
+}
+
+// TrackJovalProcess adds a running Joval process to the resource usage metrics
+func TrackJovalProcess(pid int) {
+	jovalCollector.mu.Lock()
+	jovalCollector.pids[int32(pid)] = true
+	jovalCollector.mu.Unlock()
+}
+
+// UntrackJovalProcess removes a Joval process from the resource usage metrics once it exited
+func UntrackJovalProcess(pid int) {
+	jovalCollector.mu.Lock()
+	delete(jovalCollector.pids, int32(pid))
+	jovalCollector.mu.Unlock()
+}
+
+// JovalProcessCount returns the number of tracked Joval processes
+func JovalProcessCount() int {
+	jovalCollector.mu.Lock()
+	defer jovalCollector.mu.Unlock()
+
+	return len(jovalCollector.pids)
+}
+
+func (c *jovalProcessCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.processes
+	ch <- c.cpuSeconds
+	ch <- c.residentMemory
+}
+
+func (c *jovalProcessCollector) Collect(ch chan<- prometheus.Metric) {
+
+	c.mu.Lock()
+	pids := make([]int32, 0, len(c.pids))
+	for pid := range c.pids {
+		pids = append(pids, pid)
+	}
+	c.mu.Unlock()
+
+	var running int
+	var cpuSeconds, residentMemory float64
+
+	for _, pid := range pids {
+
+		p, err := process.NewProcess(pid)
+
+		if err != nil {
+			continue
+		}
+
+		running++
+
+		if times, err := p.Times(); err == nil {
+			cpuSeconds += times.User + times.System
+		}
+
+		if mem, err := p.MemoryInfo(); err == nil {
+			residentMemory += float64(mem.RSS)
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.processes, prometheus.GaugeValue, float64(running))
+	ch <- prometheus.MustNewConstMetric(c.cpuSeconds, prometheus.GaugeValue, cpuSeconds)
+	ch <- prometheus.MustNewConstMetric(c.residentMemory, prometheus.GaugeValue, residentMemory)
+}
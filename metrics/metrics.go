@@ -0,0 +1,197 @@
+// Package metrics handles the VSCAN Agent Prometheus metrics exposed over a dedicated HTTP listener
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/lucabrasi83/vscan-agent/logging"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// namespace prefixes every VSCAN Agent metric name
+const namespace = "vscan_agent"
+
+// registry holds the VSCAN Agent metrics along with the Go runtime and agent process metrics
+var registry = prometheus.NewRegistry()
+
+var (
+	rpcRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "grpc_requests_total",
+		Help:      "Number of gRPC requests handled per method and status code.",
+	}, []string{"method", "code"})
+
+	rpcDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "grpc_request_duration_seconds",
+		Help:      "Duration of gRPC requests per method and status code.",
+		Buckets:   []float64{.01, .05, .1, .5, 1, 5, 10, 30, 60, 300, 900, 1800, 3600},
+	}, []string{"method", "code"})
+
+	scansStarted = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "scans_started_total",
+		Help:      "Number of scan jobs started.",
+	})
+
+	scansCompleted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "scans_completed_total",
+		Help:      "Number of scan jobs completed per result (succeeded or failed).",
+	}, []string{"result"})
+
+	scanDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "scan_duration_seconds",
+		Help:      "Duration of scan jobs per result (succeeded or failed).",
+		Buckets:   []float64{10, 30, 60, 120, 300, 600, 900, 1800, 3600, 7200},
+	}, []string{"result"})
+
+	scansInProgress = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "scans_in_progress",
+		Help:      "Number of scan jobs currently queued or running on the agent.",
+	})
+
+	devicesScanned = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "devices_scanned_total",
+		Help:      "Number of devices of the scan jobs completed successfully.",
+	})
+
+	reportBytes = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "report_bytes_streamed_total",
+		Help:      "Number of scan report bytes streamed to the controller.",
+	})
+
+	rateLimiterRejections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rate_limiter_rejections_total",
+		Help:      "Number of requests rejected by the rate limiter and admission control per method and reason.",
+	}, []string{"method", "reason"})
+)
+
+func init() {
+	registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		rpcRequests,
+		rpcDuration,
+		scansStarted,
+		scansCompleted,
+		scanDuration,
+		scansInProgress,
+		devicesScanned,
+		reportBytes,
+		rateLimiterRejections,
+		jovalCollector,
+	)
+}
+
+// MustRegister registers additional collectors in the VSCAN Agent metrics registry
+func MustRegister(collectors ...prometheus.Collector) {
+	registry.MustRegister(collectors...)
+}
+
+// StartServer exposes the metrics on path /metrics of a dedicated HTTP listener.
+// The returned server must be shut down with Shutdown.
+func StartServer(addr string) *http.Server {
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+	srv := &http.Server{
+		Addr:         addr,
+		Handler:      mux,
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 30 * time.Second,
+	}
+
+	go func() {
+		logging.VSCANLog("info", "starting VSCAN Agent metrics endpoint on %v/metrics", addr)
+
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logging.VSCANLog("error", "unable to start metrics HTTP server: %v", err)
+		}
+	}()
+
+	return srv
+}
+
+// Shutdown stops the metrics HTTP listener
+func Shutdown(srv *http.Server) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := srv.Shutdown(ctx); err != nil {
+		logging.VSCANLog("error", "unable to stop metrics HTTP server: %v", err)
+	}
+}
+
+// ScanStarted records the start of a scan job
+func ScanStarted() {
+	scansStarted.Inc()
+	scansInProgress.Inc()
+}
+
+// ScanFinished records the completion of a scan job for the given number of devices
+func ScanFinished(duration time.Duration, devices int, succeeded bool) {
+
+	scansInProgress.Dec()
+
+	result := "failed"
+
+	if succeeded {
+		result = "succeeded"
+		devicesScanned.Add(float64(devices))
+	}
+
+	scansCompleted.WithLabelValues(result).Inc()
+	scanDuration.WithLabelValues(result).Observe(duration.Seconds())
+}
+
+// ReportStreamed records the size of a scan report sent to the controller
+func ReportStreamed(bytes int) {
+	reportBytes.Add(float64(bytes))
+}
+
+// RateLimiterRejected records a request rejected by the rate limiter or admission control
+func RateLimiterRejected(method string, reason string) {
+	rateLimiterRejections.WithLabelValues(method, reason).Inc()
+}
+
+// observeRPC records a handled gRPC request
+func observeRPC(method string, start time.Time, err error) {
+
+	code := status.Code(err).String()
+
+	rpcRequests.WithLabelValues(method, code).Inc()
+	rpcDuration.WithLabelValues(method, code).Observe(time.Since(start).Seconds())
+}
+
+// UnaryServerInterceptor returns a new unary server interceptor that records the gRPC requests metrics.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		observeRPC(info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns a new stream server interceptor that records the gRPC requests metrics.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, stream)
+		observeRPC(info.FullMethod, start, err)
+		return err
+	}
+}
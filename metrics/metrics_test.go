@@ -0,0 +1,212 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lucabrasi83/vscan-agent/certmanager"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerInterceptorLabels(t *testing.T) {
+
+	const method = "/agentpb.VscanAgentService/BuildScanConfig"
+
+	tests := []struct {
+		err  error
+		code string
+	}{
+		{err: nil, code: "OK"},
+		{err: status.Error(codes.ResourceExhausted, "rate limited"), code: "ResourceExhausted"},
+		{err: fmt.Errorf("not a gRPC status"), code: "Unknown"},
+	}
+
+	interceptor := UnaryServerInterceptor()
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+
+			before := testutil.ToFloat64(rpcRequests.WithLabelValues(method, tt.code))
+
+			_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: method},
+				func(context.Context, interface{}) (interface{}, error) { return nil, tt.err })
+
+			if err != tt.err {
+				t.Fatalf("interceptor error = %v, want the handler error %v", err, tt.err)
+			}
+
+			if got := testutil.ToFloat64(rpcRequests.WithLabelValues(method, tt.code)); got != before+1 {
+				t.Errorf("requests with code %v = %v, want %v", tt.code, got, before+1)
+			}
+
+			if testutil.CollectAndCount(rpcDuration, "vscan_agent_grpc_request_duration_seconds") == 0 {
+				t.Errorf("request duration not observed")
+			}
+		})
+	}
+}
+
+func TestJovalProcessCollector(t *testing.T) {
+
+	expect := func(processes int) {
+
+		t.Helper()
+
+		want := fmt.Sprintf(`
+# HELP vscan_agent_joval_processes Number of running Joval processes.
+# TYPE vscan_agent_joval_processes gauge
+vscan_agent_joval_processes %d
+`, processes)
+
+		if err := testutil.CollectAndCompare(jovalCollector, strings.NewReader(want),
+			"vscan_agent_joval_processes"); err != nil {
+			t.Error(err)
+		}
+
+		if JovalProcessCount() != processes {
+			t.Errorf("JovalProcessCount() = %v, want %v", JovalProcessCount(), processes)
+		}
+	}
+
+	expect(0)
+
+	// The test process stands in for a running Joval process
+	TrackJovalProcess(os.Getpid())
+
+	expect(1)
+
+	if testutil.CollectAndCount(jovalCollector) != 3 {
+		t.Errorf("Joval collector did not report the CPU and memory usage")
+	}
+
+	UntrackJovalProcess(os.Getpid())
+
+	expect(0)
+}
+
+// writeCertificate signs a certificate expiring in the given number of days, with parent and parentKey or
+// self-signed when parent is nil, and appends it PEM encoded to file
+func writeCertificate(t *testing.T, file string, template *x509.Certificate, days int, parent *x509.Certificate,
+	parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Duration(days)*24*time.Hour + 12*time.Hour)
+
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.OpenFile(file, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer f.Close()
+
+	if err := pem.Encode(f, &pem.Block{Type: "CERTIFICATE", Bytes: der}); err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cert, key
+}
+
+func TestCertificateExpiryCollector(t *testing.T) {
+
+	dir := t.TempDir()
+
+	certFile := filepath.Join(dir, "server.crt")
+	keyFile := filepath.Join(dir, "server.key")
+	caFile := filepath.Join(dir, "ca.crt")
+
+	ca, caKey := writeCertificate(t, caFile, &x509.Certificate{
+		SerialNumber:          big.NewInt(0xca),
+		Subject:               pkix.Name{CommonName: "VSCAN Root CA"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, 365, nil, nil)
+
+	_, key := writeCertificate(t, certFile, &x509.Certificate{
+		SerialNumber: big.NewInt(0x1),
+		Subject:      pkix.Name{CommonName: "agent.vscan.internal"},
+		DNSNames:     []string{"agent.vscan.internal"},
+	}, 10, ca, caKey)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// The CA bundle holds the CA certificate twice, which must not report duplicate series
+	caPEM, err := ioutil.ReadFile(caFile)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(caFile, bytes.Repeat(caPEM, 2), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	certManager, err := certmanager.New(certFile, keyFile, caFile)
+
+	if err != nil {
+		t.Fatalf("unable to load certificates: %v", err)
+	}
+
+	want := `
+# HELP vscan_agent_certificate_days_to_expiry Days left before the TLS certificate expires. Negative once expired.
+# TYPE vscan_agent_certificate_days_to_expiry gauge
+vscan_agent_certificate_days_to_expiry{certificate="CA VSCAN Root CA",serial="ca",subject="CN=VSCAN Root CA"} 365
+vscan_agent_certificate_days_to_expiry{certificate="server",serial="1",subject="CN=agent.vscan.internal"} 10
+`
+
+	if err := testutil.CollectAndCompare(newCertificateExpiryCollector(certManager), strings.NewReader(want),
+		"vscan_agent_certificate_days_to_expiry"); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,279 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/lucabrasi83/vscan-agent/logging"
+	"github.com/shirou/gopsutil/disk"
+	"github.com/shirou/gopsutil/load"
+	"github.com/shirou/gopsutil/mem"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AdmissionErrorDomain is the domain of the ErrorInfo details attached to admission and rate limiting rejections
+const AdmissionErrorDomain = "vscan-agent"
+
+// Admission rejection reasons reported in the ErrorInfo details
+const (
+	ReasonSystemLoad     = "SYSTEM_LOAD_HIGH"
+	ReasonMemoryPressure = "MEMORY_PRESSURE"
+	ReasonDiskSpace      = "DISK_SPACE_LOW"
+	ReasonJovalProcesses = "TOO_MANY_SCANNERS"
+)
+
+// AdmissionPolicy represents the resource thresholds above which requests are rejected.
+// A zero threshold disables the corresponding check.
+type AdmissionPolicy struct {
+	// Exempt disables admission control for the RPC
+	Exempt bool
+
+	// MaxLoadPerCPU is the 5 minutes Average System Load per CPU core
+	MaxLoadPerCPU float64
+
+	MaxMemoryPercent  float64
+	MinFreeDiskBytes  uint64
+	MaxJovalProcesses int
+}
+
+// AdmissionOptions represents the admission controller settings
+type AdmissionOptions struct {
+	// Default applies to the RPCs without a policy in Methods
+	Default AdmissionPolicy
+
+	// Methods holds the per-RPC policies keyed by full method name or short method name
+	Methods map[string]AdmissionPolicy
+
+	// DiskPath is the partition checked for free disk space
+	DiskPath string
+
+	// RetryAfter is the retry delay hint returned to rejected clients
+	RetryAfter time.Duration
+
+	// JovalProcesses returns the number of running Joval processes
+	JovalProcesses func() int
+
+	// SystemLoad, MemoryPercent and FreeDiskBytes measure the 5 minutes Average System Load, the used memory
+	// percentage and the free bytes of a partition. They default to the gopsutil measures.
+	SystemLoad    func() (float64, error)
+	MemoryPercent func() (float64, error)
+	FreeDiskBytes func(path string) (uint64, error)
+}
+
+// AdmissionController rejects requests while the agent resources (System Load, memory, free disk space on the scan
+// jobs partition and running Joval processes) are above the thresholds of the RPC policy.
+// Rejected requests get a ResourceExhausted status error carrying an ErrorInfo with the reason and a RetryInfo.
+// Resources which cannot be measured are not checked.
+type AdmissionController struct {
+	opts AdmissionOptions
+}
+
+// NewAdmissionController returns an AdmissionController enforcing the given policies
+func NewAdmissionController(opts AdmissionOptions) *AdmissionController {
+
+	if opts.SystemLoad == nil {
+		opts.SystemLoad = systemLoad
+	}
+
+	if opts.MemoryPercent == nil {
+		opts.MemoryPercent = memoryPercent
+	}
+
+	if opts.FreeDiskBytes == nil {
+		opts.FreeDiskBytes = freeDiskBytes
+	}
+
+	return &AdmissionController{opts: opts}
+}
+
+// systemLoad returns the 5 minutes Average System Load
+func systemLoad() (float64, error) {
+
+	loadAverages, err := load.Avg()
+
+	if err != nil {
+		return 0, err
+	}
+
+	return loadAverages.Load5, nil
+}
+
+// memoryPercent returns the percentage of used memory
+func memoryPercent() (float64, error) {
+
+	memUsage, err := mem.VirtualMemory()
+
+	if err != nil {
+		return 0, err
+	}
+
+	return memUsage.UsedPercent, nil
+}
+
+// freeDiskBytes returns the free bytes of the partition holding path
+func freeDiskBytes(path string) (uint64, error) {
+
+	diskUsage, err := disk.Usage(path)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return diskUsage.Free, nil
+}
+
+// rejection represents the reason a request is not admitted
+type rejection struct {
+	reason    string
+	message   string
+	value     string
+	threshold string
+}
+
+// Limit verifies the agent resources allow serving the RPC.
+// Rejections carry the exceeded resource value and threshold in their metadata.
+func (a *AdmissionController) Limit(ctx context.Context, fullMethod string, req interface{}) Decision {
+
+	r := a.check(fullMethod)
+
+	if r == nil {
+		return Allow()
+	}
+
+	logging.VSCANLog("error", "Request to %v rejected: %v", fullMethod, r.message)
+
+	d := Reject(r.reason, r.message, a.opts.RetryAfter)
+	d.Metadata = map[string]string{"value": r.value, "threshold": r.threshold}
+
+	return d
+}
+
+// Check returns an error describing why the RPC would not be admitted, without recording the rejection
+func (a *AdmissionController) Check(fullMethod string) error {
+
+	if r := a.check(fullMethod); r != nil {
+		return fmt.Errorf("%v", r.message)
+	}
+
+	return nil
+}
+
+// check evaluates the policy applying to the RPC
+func (a *AdmissionController) check(fullMethod string) *rejection {
+
+	policy := a.policy(fullMethod)
+
+	if policy.Exempt {
+		return nil
+	}
+
+	return a.evaluate(policy)
+}
+
+// policy returns the policy applying to the RPC
+func (a *AdmissionController) policy(fullMethod string) AdmissionPolicy {
+
+	if policy, ok := a.opts.Methods[fullMethod]; ok {
+		return policy
+	}
+
+	if policy, ok := a.opts.Methods[shortMethodName(fullMethod)]; ok {
+		return policy
+	}
+
+	return a.opts.Default
+}
+
+// evaluate measures the resources checked by the policy and returns the first threshold exceeded, if any
+func (a *AdmissionController) evaluate(policy AdmissionPolicy) *rejection {
+
+	if policy.MaxJovalProcesses > 0 && a.opts.JovalProcesses != nil {
+
+		if running := a.opts.JovalProcesses(); running >= policy.MaxJovalProcesses {
+			return &rejection{
+				reason:    ReasonJovalProcesses,
+				message:   fmt.Sprintf("%v Joval scanner processes running", running),
+				value:     fmt.Sprint(running),
+				threshold: fmt.Sprint(policy.MaxJovalProcesses),
+			}
+		}
+	}
+
+	if policy.MaxLoadPerCPU > 0 {
+
+		load5, err := a.opts.SystemLoad()
+
+		if err != nil {
+			logging.VSCANLog("warning", "unable to read System Load, skipping admission check: %v", err)
+		} else if loadPerCPU := load5 / float64(runtime.NumCPU()); loadPerCPU >= policy.MaxLoadPerCPU {
+			return &rejection{
+				reason:    ReasonSystemLoad,
+				message:   fmt.Sprintf("high system load. Current 5 minutes Average System Load at %.2f", load5),
+				value:     fmt.Sprintf("%.2f", loadPerCPU),
+				threshold: fmt.Sprintf("%.2f", policy.MaxLoadPerCPU),
+			}
+		}
+	}
+
+	if policy.MaxMemoryPercent > 0 {
+
+		usedPercent, err := a.opts.MemoryPercent()
+
+		if err != nil {
+			logging.VSCANLog("warning", "unable to read memory usage, skipping admission check: %v", err)
+		} else if usedPercent >= policy.MaxMemoryPercent {
+			return &rejection{
+				reason:    ReasonMemoryPressure,
+				message:   fmt.Sprintf("memory usage at %.1f%%", usedPercent),
+				value:     fmt.Sprintf("%.1f", usedPercent),
+				threshold: fmt.Sprintf("%.1f", policy.MaxMemoryPercent),
+			}
+		}
+	}
+
+	if policy.MinFreeDiskBytes > 0 {
+
+		free, err := a.opts.FreeDiskBytes(a.opts.DiskPath)
+
+		if err != nil {
+			logging.VSCANLog("warning", "unable to read disk usage of %v, skipping admission check: %v",
+				a.opts.DiskPath, err)
+		} else if free < policy.MinFreeDiskBytes {
+			return &rejection{
+				reason:    ReasonDiskSpace,
+				message:   fmt.Sprintf("%v MB free on %v", free>>20, a.opts.DiskPath),
+				value:     fmt.Sprint(free),
+				threshold: fmt.Sprint(policy.MinFreeDiskBytes),
+			}
+		}
+	}
+
+	return nil
+}
+
+// resourceExhausted returns a ResourceExhausted status error carrying an ErrorInfo with the rejection reason and
+// a RetryInfo with the retry delay hint
+func resourceExhausted(message string, reason string, retryAfter time.Duration, metadata map[string]string) error {
+
+	st := status.Newf(codes.ResourceExhausted, "request is rejected by agent %v: %v", hostname, message)
+
+	detailed, err := st.WithDetails(
+		&errdetails.ErrorInfo{
+			Reason:   reason,
+			Domain:   AdmissionErrorDomain,
+			Metadata: metadata,
+		},
+		&errdetails.RetryInfo{RetryDelay: ptypes.DurationProto(retryAfter)},
+	)
+
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
@@ -0,0 +1,203 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// probes represents the resources measured by the admission controller under test
+type probes struct {
+	load5         float64
+	memoryPercent float64
+	freeDisk      uint64
+	jovalProcs    int
+	err           error
+}
+
+// newTestAdmissionController returns an AdmissionController measuring the resources from p
+func newTestAdmissionController(p *probes, def AdmissionPolicy,
+	methods map[string]AdmissionPolicy) *AdmissionController {
+
+	return NewAdmissionController(AdmissionOptions{
+		Default:        def,
+		Methods:        methods,
+		DiskPath:       "/opt/joval/scanjobs",
+		RetryAfter:     15 * time.Second,
+		JovalProcesses: func() int { return p.jovalProcs },
+		SystemLoad:     func() (float64, error) { return p.load5, p.err },
+		MemoryPercent:  func() (float64, error) { return p.memoryPercent, p.err },
+		FreeDiskBytes: func(path string) (uint64, error) {
+			if path != "/opt/joval/scanjobs" {
+				return 0, fmt.Errorf("unexpected disk path %v", path)
+			}
+			return p.freeDisk, p.err
+		},
+	})
+}
+
+func TestAdmissionControllerThresholds(t *testing.T) {
+
+	cpus := float64(runtime.NumCPU())
+
+	policy := AdmissionPolicy{MaxLoadPerCPU: 1, MaxMemoryPercent: 90, MinFreeDiskBytes: 1 << 30, MaxJovalProcesses: 2}
+
+	// idle is below every threshold of policy
+	idle := probes{load5: 0.5 * cpus, memoryPercent: 50, freeDisk: 10 << 30, jovalProcs: 1}
+
+	tests := []struct {
+		name          string
+		probes        func(p *probes)
+		wantReason    string
+		wantValue     string
+		wantThreshold string
+	}{
+		{name: "idle"},
+		{name: "system load", probes: func(p *probes) { p.load5 = 1.5 * cpus },
+			wantReason: ReasonSystemLoad, wantValue: "1.50", wantThreshold: "1.00"},
+		{name: "memory pressure", probes: func(p *probes) { p.memoryPercent = 95 },
+			wantReason: ReasonMemoryPressure, wantValue: "95.0", wantThreshold: "90.0"},
+		{name: "disk space", probes: func(p *probes) { p.freeDisk = 512 << 20 },
+			wantReason: ReasonDiskSpace, wantValue: fmt.Sprint(512 << 20), wantThreshold: fmt.Sprint(1 << 30)},
+		{name: "Joval processes", probes: func(p *probes) { p.jovalProcs = 2 },
+			wantReason: ReasonJovalProcesses, wantValue: "2", wantThreshold: "2"},
+		{name: "Joval processes checked first", probes: func(p *probes) { p.jovalProcs, p.memoryPercent = 3, 99 },
+			wantReason: ReasonJovalProcesses, wantValue: "3", wantThreshold: "2"},
+		{name: "unmeasurable resources not checked", probes: func(p *probes) {
+			p.load5, p.memoryPercent, p.freeDisk, p.err = 99*cpus, 99, 0, errors.New("no /proc")
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			p := idle
+
+			if tt.probes != nil {
+				tt.probes(&p)
+			}
+
+			d := newTestAdmissionController(&p, policy, nil).Limit(context.Background(), scanFullMethod, nil)
+
+			if tt.wantReason == "" {
+				if !d.Allowed {
+					t.Fatalf("request rejected: %v", d.Message)
+				}
+				return
+			}
+
+			if d.Allowed || d.Reason != tt.wantReason {
+				t.Fatalf("decision allowed %v with reason %q, want rejection with %q", d.Allowed, d.Reason,
+					tt.wantReason)
+			}
+
+			if d.Metadata["value"] != tt.wantValue || d.Metadata["threshold"] != tt.wantThreshold {
+				t.Errorf("rejection value %v and threshold %v, want %v and %v", d.Metadata["value"],
+					d.Metadata["threshold"], tt.wantValue, tt.wantThreshold)
+			}
+
+			if d.RetryAfter != 15*time.Second {
+				t.Errorf("retry after %v, want %v", d.RetryAfter, 15*time.Second)
+			}
+		})
+	}
+}
+
+func TestAdmissionControllerMethodPolicies(t *testing.T) {
+
+	// The agent runs 3 Joval processes and uses 95% of its memory
+	p := &probes{memoryPercent: 95, freeDisk: 10 << 30, jovalProcs: 3}
+
+	a := newTestAdmissionController(p, AdmissionPolicy{MaxMemoryPercent: 90, MaxJovalProcesses: 4},
+		map[string]AdmissionPolicy{
+			scanFullMethod:    {MaxJovalProcesses: 2},
+			"DiscoverDevices": {MaxMemoryPercent: 98},
+			"GetAgentInfo":    {Exempt: true, MaxMemoryPercent: 10},
+		})
+
+	tests := []struct {
+		fullMethod string
+		wantReason string
+	}{
+		{fullMethod: scanFullMethod, wantReason: ReasonJovalProcesses},
+		{fullMethod: "/agentpb.VscanAgentService/DiscoverDevices"},
+		{fullMethod: "/agentpb.VscanAgentService/GetAgentInfo"},
+		{fullMethod: "/agentpb.VscanAgentService/SSHConnectivityTest", wantReason: ReasonMemoryPressure},
+	}
+
+	for _, tt := range tests {
+
+		d := a.Limit(context.Background(), tt.fullMethod, nil)
+
+		if d.Reason != tt.wantReason || d.Allowed != (tt.wantReason == "") {
+			t.Errorf("%v allowed %v with reason %q, want reason %q", tt.fullMethod, d.Allowed, d.Reason,
+				tt.wantReason)
+		}
+
+		if err := a.Check(tt.fullMethod); (err == nil) != (tt.wantReason == "") {
+			t.Errorf("%v check error = %v, want rejection %v", tt.fullMethod, err, tt.wantReason != "")
+		}
+	}
+}
+
+func TestAdmissionControllerRejectionDetails(t *testing.T) {
+
+	p := &probes{freeDisk: 100 << 20}
+
+	a := newTestAdmissionController(p, AdmissionPolicy{MinFreeDiskBytes: 1 << 30}, nil)
+
+	_, err := UnaryServerInterceptor(a)(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: scanFullMethod},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			t.Errorf("handler called for a rejected request")
+			return nil, nil
+		})
+
+	st := status.Convert(err)
+
+	if st.Code() != codes.ResourceExhausted {
+		t.Fatalf("rejection code = %v, want %v", st.Code(), codes.ResourceExhausted)
+	}
+
+	var errorInfo *errdetails.ErrorInfo
+	var retryInfo *errdetails.RetryInfo
+
+	for _, detail := range st.Details() {
+		switch detail := detail.(type) {
+		case *errdetails.ErrorInfo:
+			errorInfo = detail
+		case *errdetails.RetryInfo:
+			retryInfo = detail
+		}
+	}
+
+	if errorInfo == nil || retryInfo == nil {
+		t.Fatalf("rejection details = %v, want ErrorInfo and RetryInfo", st.Details())
+	}
+
+	wantMetadata := map[string]string{"method": scanFullMethod, "value": fmt.Sprint(100 << 20),
+		"threshold": fmt.Sprint(1 << 30)}
+
+	if errorInfo.GetDomain() != AdmissionErrorDomain || errorInfo.GetReason() != ReasonDiskSpace {
+		t.Errorf("ErrorInfo domain %v and reason %v, want %v and %v", errorInfo.GetDomain(), errorInfo.GetReason(),
+			AdmissionErrorDomain, ReasonDiskSpace)
+	}
+
+	for k, v := range wantMetadata {
+		if errorInfo.GetMetadata()[k] != v {
+			t.Errorf("ErrorInfo metadata %v = %q, want %q", k, errorInfo.GetMetadata()[k], v)
+		}
+	}
+
+	if retryAfter, err := ptypes.Duration(retryInfo.GetRetryDelay()); err != nil || retryAfter != 15*time.Second {
+		t.Errorf("RetryInfo delay = %v, want %v", retryAfter, 15*time.Second)
+	}
+}
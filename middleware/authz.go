@@ -0,0 +1,166 @@
+package middleware
+
+import (
+	"context"
+	"crypto/x509"
+	"path"
+	"strings"
+
+	"github.com/lucabrasi83/vscan-agent/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// defaultPolicyKey is the policy key applied to RPCs without a dedicated policy
+const defaultPolicyKey = "*"
+
+// Authorizer enforces a per-RPC allowlist of client identities on top of mutual TLS.
+// Policies are keyed by full method name (/agentpb.VscanAgentService/BuildScanConfig), short method name
+// (BuildScanConfig) or "*" for any other RPC. RPCs matching no policy are denied.
+// The gRPC health checking service is always allowed.
+// Allowlist entries are matched against the client certificate subject Common Name and Subject Alternative
+// Names (DNS, URI, email and IP) using path.Match patterns, e.g. "*.controller.vscan.internal".
+// "*" matches any sequence of characters but "/", including dots, so "*.vscan.internal" also matches nested
+// subdomains. As "*" does not match "/", URI SANs are matched one path segment per "*", e.g.
+// "spiffe://vscan.internal/ns/*/sa/controller". A "*" entry alone allows any client certificate.
+type Authorizer struct {
+	policies map[string][]string
+}
+
+// NewAuthorizer returns an Authorizer enforcing the given policies
+func NewAuthorizer(policies map[string][]string) *Authorizer {
+	return &Authorizer{policies: policies}
+}
+
+// Authorize verifies the client certificate of the request is allowed to call the RPC.
+// It returns a PermissionDenied status error otherwise.
+func (a *Authorizer) Authorize(ctx context.Context, fullMethod string) error {
+
+	// Health checks only require a client certificate signed by the CA
+	if isHealthCheck(fullMethod) {
+		return nil
+	}
+
+	cert := peerCertificate(ctx)
+
+	if cert == nil {
+		return status.Errorf(codes.PermissionDenied,
+			"request is rejected by agent %v: no client certificate presented", hostname)
+	}
+
+	allowed, ok := a.policy(fullMethod)
+
+	identities := CertificateIdentities(cert)
+
+	if ok {
+		for _, pattern := range allowed {
+			for _, id := range identities {
+				if matchIdentity(pattern, id) {
+					return nil
+				}
+			}
+		}
+	}
+
+	logging.VSCANLog("warning", "Request to %v rejected for client identities %v", fullMethod, identities)
+
+	return status.Errorf(codes.PermissionDenied,
+		"request is rejected by agent %v: client %v is not allowed to call %v", hostname,
+		cert.Subject.CommonName, fullMethod)
+}
+
+// matchIdentity returns whether the client identity matches the allowlist pattern
+func matchIdentity(pattern string, identity string) bool {
+
+	if pattern == "*" {
+		return true
+	}
+
+	match, _ := path.Match(pattern, identity)
+
+	return match
+}
+
+// policy returns the allowlist applying to the RPC
+func (a *Authorizer) policy(fullMethod string) ([]string, bool) {
+
+	if allowed, ok := a.policies[fullMethod]; ok {
+		return allowed, true
+	}
+
+	if allowed, ok := a.policies[fullMethod[strings.LastIndex(fullMethod, "/")+1:]]; ok {
+		return allowed, true
+	}
+
+	allowed, ok := a.policies[defaultPolicyKey]
+
+	return allowed, ok
+}
+
+// peerCertificate returns the verified client certificate of the request, or nil if none
+func peerCertificate(ctx context.Context) *x509.Certificate {
+
+	p, ok := peer.FromContext(ctx)
+
+	if !ok {
+		return nil
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+
+	if !ok {
+		return nil
+	}
+
+	if len(tlsInfo.State.VerifiedChains) > 0 && len(tlsInfo.State.VerifiedChains[0]) > 0 {
+		return tlsInfo.State.VerifiedChains[0][0]
+	}
+
+	return nil
+}
+
+// CertificateIdentities returns the subject Common Name and Subject Alternative Names of a certificate
+func CertificateIdentities(cert *x509.Certificate) []string {
+
+	var identities []string
+
+	if cert.Subject.CommonName != "" {
+		identities = append(identities, cert.Subject.CommonName)
+	}
+
+	identities = append(identities, cert.DNSNames...)
+	identities = append(identities, cert.EmailAddresses...)
+
+	for _, u := range cert.URIs {
+		identities = append(identities, u.String())
+	}
+
+	for _, ip := range cert.IPAddresses {
+		identities = append(identities, ip.String())
+	}
+
+	return identities
+}
+
+// UnaryAuthzInterceptor returns a new unary server interceptor that performs client identity authorization.
+func UnaryAuthzInterceptor(authorizer *Authorizer) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := authorizer.Authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuthzInterceptor returns a new stream server interceptor that performs client identity authorization.
+func StreamAuthzInterceptor(authorizer *Authorizer) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := authorizer.Authorize(stream.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"net/url"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// peerContext returns a request context carrying the verified client certificate
+func peerContext(cert *x509.Certificate) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)},
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{
+			VerifiedChains: [][]*x509.Certificate{{cert}},
+		}},
+	})
+}
+
+func clientCertificate(commonName string, dnsNames []string, uris ...string) *x509.Certificate {
+
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: commonName}, DNSNames: dnsNames}
+
+	for _, u := range uris {
+		parsed, _ := url.Parse(u)
+		cert.URIs = append(cert.URIs, parsed)
+	}
+
+	return cert
+}
+
+func TestAuthorizer(t *testing.T) {
+
+	const (
+		scanMethod  = "/agentpb.VscanAgentService/BuildScanConfig"
+		drainMethod = "/agentpb.VscanAgentService/Drain"
+		infoMethod  = "/agentpb.VscanAgentService/GetAgentInfo"
+	)
+
+	authorizer := NewAuthorizer(map[string][]string{
+		scanMethod: {"scheduler.vscan.internal", "*.controller.vscan.internal"},
+		"Drain":    {"spiffe://vscan.internal/ns/*/sa/operator"},
+		"*":        {"monitoring"},
+	})
+
+	controller := clientCertificate("controller-1", []string{"node-1.controller.vscan.internal"})
+	scheduler := clientCertificate("scheduler.vscan.internal", nil)
+	operator := clientCertificate("", nil, "spiffe://vscan.internal/ns/prod/sa/operator")
+	monitoring := clientCertificate("monitoring", nil)
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		fullMethod string
+		allowed    bool
+	}{
+		{name: "common name on full method policy", ctx: peerContext(scheduler), fullMethod: scanMethod,
+			allowed: true},
+		{name: "SAN glob on full method policy", ctx: peerContext(controller), fullMethod: scanMethod,
+			allowed: true},
+		{name: "glob matches nested subdomains", fullMethod: scanMethod, allowed: true,
+			ctx: peerContext(clientCertificate("a.b.controller.vscan.internal", nil))},
+		{name: "glob does not match parent domain", fullMethod: scanMethod,
+			ctx: peerContext(clientCertificate("controller.vscan.internal", nil))},
+		{name: "identity not in method policy", ctx: peerContext(monitoring), fullMethod: scanMethod},
+		{name: "URI SAN on short method policy", ctx: peerContext(operator), fullMethod: drainMethod,
+			allowed: true},
+		{name: "URI SAN with another path", fullMethod: drainMethod,
+			ctx: peerContext(clientCertificate("", nil, "spiffe://vscan.internal/ns/prod/sa/scheduler"))},
+		{name: "method policy overrides fallback", ctx: peerContext(monitoring), fullMethod: drainMethod},
+		{name: "fallback policy", ctx: peerContext(monitoring), fullMethod: infoMethod, allowed: true},
+		{name: "identity not in fallback policy", ctx: peerContext(controller), fullMethod: infoMethod},
+		{name: "health check without certificate", ctx: context.Background(),
+			fullMethod: "/grpc.health.v1.Health/Check", allowed: true},
+		{name: "no peer", ctx: context.Background(), fullMethod: infoMethod},
+		{name: "non-TLS peer", fullMethod: infoMethod,
+			ctx: peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{}})},
+		{name: "TLS peer without verified certificate", fullMethod: infoMethod,
+			ctx: peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{}})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			err := authorizer.Authorize(tt.ctx, tt.fullMethod)
+
+			if tt.allowed && err != nil {
+				t.Fatalf("request denied: %v", err)
+			}
+
+			if !tt.allowed && status.Code(err) != codes.PermissionDenied {
+				t.Fatalf("error = %v, want %v", err, codes.PermissionDenied)
+			}
+		})
+	}
+}
+
+func TestAuthorizerDefaultDeny(t *testing.T) {
+
+	authorizer := NewAuthorizer(map[string][]string{"BuildScanConfig": {"*"}})
+
+	ctx := peerContext(clientCertificate("any-client", nil, "spiffe://vscan.internal/ns/prod/sa/any"))
+
+	if err := authorizer.Authorize(ctx, "/agentpb.VscanAgentService/BuildScanConfig"); err != nil {
+		t.Errorf("\"*\" policy denied a client certificate: %v", err)
+	}
+
+	if err := authorizer.Authorize(ctx, "/agentpb.VscanAgentService/Drain"); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("RPC without policy error = %v, want %v", err, codes.PermissionDenied)
+	}
+}
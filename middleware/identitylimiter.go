@@ -0,0 +1,256 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Identity rate limiting rejection reasons reported in the ErrorInfo details
+const (
+	ReasonRateLimited       = "RATE_LIMITED"
+	ReasonScanQuotaExceeded = "SCAN_QUOTA_EXCEEDED"
+)
+
+// anonymousClientIdentity is the identity of the requests without client certificate identity
+const anonymousClientIdentity = "anonymous"
+
+// scanFullMethod is the RPC subject to the concurrent scans quota
+const scanFullMethod = "/agentpb.VscanAgentService/BuildScanConfig"
+
+// clientIdleTimeout is the time after which the state of a client identity without running scans nor request is
+// evicted, once its token bucket refilled, so identities seen once do not accumulate
+const clientIdleTimeout = 10 * time.Minute
+
+// IdentityQuota represents the request rate and concurrent scans allowed to a client identity.
+// A zero RequestsPerSecond or MaxConcurrentScans disables the corresponding limit.
+type IdentityQuota struct {
+	RequestsPerSecond  float64
+	Burst              int
+	MaxConcurrentScans int
+}
+
+// IdentityLimiter is a Limiter enforcing a token bucket request rate and a concurrent scans quota per client
+// certificate identity, so one caller cannot monopolize the agent.
+// A client gets the quota set for the first of its certificate identities (Common Name, then Subject Alternative
+// Names) found in the quotas, or the default quota keyed by its Common Name otherwise.
+type IdentityLimiter struct {
+	defaultQuota IdentityQuota
+	quotas       map[string]IdentityQuota
+
+	// exemptMethods holds the RPCs not rate limited, keyed by full method name or short method name
+	exemptMethods map[string]bool
+
+	// retryAfter is the retry delay hint returned when the concurrent scans quota is exceeded
+	retryAfter time.Duration
+
+	mu      sync.Mutex
+	clients map[string]*clientState
+
+	// lastEviction is the last time idle clients were evicted
+	lastEviction time.Time
+}
+
+// clientState holds the token bucket and running scans of a client identity
+type clientState struct {
+	limiter  *rate.Limiter
+	scans    int
+	lastSeen time.Time
+}
+
+// NewIdentityLimiter returns an IdentityLimiter enforcing the given quotas on the RPCs other than exemptMethods,
+// given by full method name or short method name
+func NewIdentityLimiter(defaultQuota IdentityQuota, quotas map[string]IdentityQuota, exemptMethods []string,
+	retryAfter time.Duration) *IdentityLimiter {
+
+	exempt := make(map[string]bool, len(exemptMethods))
+
+	for _, m := range exemptMethods {
+		exempt[m] = true
+	}
+
+	return &IdentityLimiter{
+		defaultQuota:  defaultQuota,
+		quotas:        quotas,
+		exemptMethods: exempt,
+		retryAfter:    retryAfter,
+		clients:       make(map[string]*clientState),
+		lastEviction:  time.Now(),
+	}
+}
+
+// Limit takes a token from the client bucket and, for scan requests, a slot of its concurrent scans quota released
+// once the scan completed. The request is rejected with the retry delay if the client exceeded its quota.
+// The token is given back if the request is rejected by another limiter composed with the IdentityLimiter.
+func (l *IdentityLimiter) Limit(ctx context.Context, fullMethod string, req interface{}) Decision {
+
+	if l.exemptMethods[fullMethod] || l.exemptMethods[shortMethodName(fullMethod)] {
+		return Allow()
+	}
+
+	identity, quota := l.identity(ctx)
+
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.evictIdleClients(now)
+
+	client := l.client(identity, quota)
+	client.lastSeen = now
+
+	var reservation *rate.Reservation
+
+	if client.limiter != nil {
+
+		reservation = client.limiter.ReserveN(now, 1)
+
+		if delay := reservation.DelayFrom(now); delay > 0 {
+
+			reservation.CancelAt(now)
+
+			d := Reject(ReasonRateLimited, fmt.Sprintf("client %v exceeded its rate of %v requests per second",
+				identity, quota.RequestsPerSecond), delay)
+			d.Metadata = map[string]string{"identity": identity}
+
+			return d
+		}
+	}
+
+	scan := fullMethod == scanFullMethod && quota.MaxConcurrentScans > 0
+
+	if scan && client.scans >= quota.MaxConcurrentScans {
+
+		if reservation != nil {
+			reservation.CancelAt(now)
+		}
+
+		d := Reject(ReasonScanQuotaExceeded, fmt.Sprintf("client %v reached its quota of %v concurrent scans",
+			identity, quota.MaxConcurrentScans), l.retryAfter)
+		d.Metadata = map[string]string{
+			"identity":  identity,
+			"value":     fmt.Sprint(client.scans),
+			"threshold": fmt.Sprint(quota.MaxConcurrentScans),
+		}
+
+		return d
+	}
+
+	if !scan && reservation == nil {
+		return Allow()
+	}
+
+	if scan {
+		client.scans++
+	}
+
+	var once sync.Once
+
+	// release gives back the concurrent scan slot and, if the request was rejected by another limiter, the token.
+	// The reservation is canceled as of its time since a reservation already acted upon cannot be canceled; the
+	// composed limiters cancel it right after evaluating the request.
+	release := func(cancelToken bool) {
+		once.Do(func() {
+			if cancelToken && reservation != nil {
+				reservation.CancelAt(now)
+			}
+
+			if scan {
+				l.mu.Lock()
+				client.scans--
+				l.mu.Unlock()
+			}
+		})
+	}
+
+	d := Allow()
+	d.Release = func() { release(false) }
+	d.Cancel = func() { release(true) }
+
+	return d
+}
+
+// identity returns the client identity of the request and its quota
+func (l *IdentityLimiter) identity(ctx context.Context) (string, IdentityQuota) {
+
+	cert := peerCertificate(ctx)
+
+	if cert == nil {
+		return anonymousClientIdentity, l.defaultQuota
+	}
+
+	identities := CertificateIdentities(cert)
+
+	for _, id := range identities {
+		if quota, ok := l.quotas[id]; ok {
+			return id, quota
+		}
+	}
+
+	if len(identities) == 0 {
+		return anonymousClientIdentity, l.defaultQuota
+	}
+
+	return identities[0], l.defaultQuota
+}
+
+// client returns the state of the client identity, created on its first request.
+// It must be called with the lock held.
+func (l *IdentityLimiter) client(identity string, quota IdentityQuota) *clientState {
+
+	client, ok := l.clients[identity]
+
+	if ok {
+		return client
+	}
+
+	client = &clientState{}
+
+	if quota.RequestsPerSecond > 0 {
+
+		burst := quota.Burst
+
+		if burst < 1 {
+			burst = int(math.Ceil(quota.RequestsPerSecond))
+		}
+
+		client.limiter = rate.NewLimiter(rate.Limit(quota.RequestsPerSecond), burst)
+	}
+
+	l.clients[identity] = client
+
+	return client
+}
+
+// evictIdleClients removes the state of the client identities idle for clientIdleTimeout, at most once per
+// clientIdleTimeout. A client is only evicted once its token bucket is full again so eviction does not grant it
+// extra tokens. It must be called with the lock held.
+func (l *IdentityLimiter) evictIdleClients(now time.Time) {
+
+	if now.Sub(l.lastEviction) < clientIdleTimeout {
+		return
+	}
+
+	l.lastEviction = now
+
+	for identity, client := range l.clients {
+
+		idle := now.Sub(client.lastSeen)
+
+		if client.scans > 0 || idle < clientIdleTimeout {
+			continue
+		}
+
+		if client.limiter != nil &&
+			idle.Seconds() < float64(client.limiter.Burst())/float64(client.limiter.Limit()) {
+			continue
+		}
+
+		delete(l.clients, identity)
+	}
+}
@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+var rejectAll = LimiterFunc(func(ctx context.Context, fullMethod string, req interface{}) Decision {
+	return Reject("REJECTED", "rejected by test limiter", time.Second)
+})
+
+func TestIdentityLimiterTokenReturnedOnLaterRejection(t *testing.T) {
+
+	l := NewIdentityLimiter(IdentityQuota{RequestsPerSecond: 0.01, Burst: 1, MaxConcurrentScans: 1}, nil, nil, time.Second)
+
+	ctx := context.Background()
+
+	for _, limiter := range []Limiter{Chain(l, rejectAll), All(l, rejectAll)} {
+		if d := limiter.Limit(ctx, scanFullMethod, nil); d.Allowed {
+			t.Fatalf("request allowed although rejected by a later limiter")
+		}
+	}
+
+	d := l.Limit(ctx, scanFullMethod, nil)
+
+	if !d.Allowed {
+		t.Fatalf("token or scan slot spent by requests rejected later in the chain: %v", d.Message)
+	}
+
+	d.Release()
+
+	// The token of a completed request stays spent while its scan slot is given back
+	if d := l.Limit(ctx, "/agentpb.VscanAgentService/GetScanJobs", nil); d.Reason != ReasonRateLimited {
+		t.Errorf("request after a completed one rejected with %q, want %q", d.Reason, ReasonRateLimited)
+	}
+
+	if scans := l.clients[anonymousClientIdentity].scans; scans != 0 {
+		t.Errorf("%v concurrent scans held after release, want 0", scans)
+	}
+}
+
+func TestIdentityLimiterEvictsIdleClients(t *testing.T) {
+
+	l := NewIdentityLimiter(IdentityQuota{RequestsPerSecond: 1, Burst: 1, MaxConcurrentScans: 1}, nil, nil, time.Second)
+
+	ctx := context.Background()
+
+	running := l.Limit(ctx, scanFullMethod, nil)
+
+	l.clients["idle"] = &clientState{lastSeen: time.Now().Add(-2 * clientIdleTimeout)}
+	l.lastEviction = time.Now().Add(-2 * clientIdleTimeout)
+	l.clients[anonymousClientIdentity].lastSeen = time.Now().Add(-2 * clientIdleTimeout)
+
+	l.evictIdleClients(time.Now())
+
+	if _, ok := l.clients["idle"]; ok {
+		t.Errorf("idle client not evicted")
+	}
+
+	if _, ok := l.clients[anonymousClientIdentity]; !ok {
+		t.Errorf("client with a running scan evicted")
+	}
+
+	running.Release()
+}
+
+func TestIdentityLimiterExemptMethods(t *testing.T) {
+
+	l := NewIdentityLimiter(IdentityQuota{RequestsPerSecond: 0.01, Burst: 1}, nil,
+		[]string{"GetAgentInfo", "/agentpb.VscanAgentService/Drain"}, time.Second)
+
+	ctx := context.Background()
+
+	// Exempt RPCs do not spend the client token
+	for i := 0; i < 3; i++ {
+		for _, m := range []string{"/agentpb.VscanAgentService/GetAgentInfo", "/agentpb.VscanAgentService/Drain"} {
+			if d := l.Limit(ctx, m, nil); !d.Allowed {
+				t.Fatalf("exempt RPC %v rejected: %v", m, d.Message)
+			}
+		}
+	}
+
+	if d := l.Limit(ctx, scanFullMethod, nil); !d.Allowed {
+		t.Fatalf("first rate limited request rejected: %v", d.Message)
+	}
+
+	if d := l.Limit(ctx, scanFullMethod, nil); d.Reason != ReasonRateLimited {
+		t.Errorf("request exceeding the rate rejected with %q, want %q", d.Reason, ReasonRateLimited)
+	}
+}
@@ -0,0 +1,122 @@
+// Package limitertest provides fake middleware.Limiter implementations to unit test the rate limiting and
+// admission control interceptors and the code composing limiters.
+package limitertest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/lucabrasi83/vscan-agent/middleware"
+)
+
+// Call records a request evaluated by a Fake limiter
+type Call struct {
+	FullMethod string
+	Req        interface{}
+}
+
+// Fake is a middleware.Limiter returning preset decisions and recording the requests it evaluated and the
+// resources released or canceled by the allowed requests
+type Fake struct {
+	mu sync.Mutex
+
+	// Decision is returned once the Sequence decisions are exhausted
+	Decision middleware.Decision
+
+	// Sequence holds the decisions returned in order for the first requests
+	Sequence []middleware.Decision
+
+	calls    []Call
+	allowed  int
+	released int
+	canceled int
+}
+
+// Allowing returns a Fake allowing every request
+func Allowing() *Fake {
+	return &Fake{Decision: middleware.Allow()}
+}
+
+// Rejecting returns a Fake rejecting every request with the given reason and retry delay
+func Rejecting(reason string, retryAfter time.Duration) *Fake {
+	return &Fake{Decision: middleware.Reject(reason, "rejected by fake limiter", retryAfter)}
+}
+
+// Limit records the request and returns the next preset decision
+func (f *Fake) Limit(ctx context.Context, fullMethod string, req interface{}) middleware.Decision {
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls = append(f.calls, Call{FullMethod: fullMethod, Req: req})
+
+	d := f.Decision
+
+	if len(f.Sequence) > 0 {
+		d, f.Sequence = f.Sequence[0], f.Sequence[1:]
+	}
+
+	if d.Allowed {
+
+		f.allowed++
+
+		release := d.Release
+
+		d.Release = func() {
+			f.mu.Lock()
+			f.released++
+			f.mu.Unlock()
+
+			if release != nil {
+				release()
+			}
+		}
+
+		cancel := d.Cancel
+
+		d.Cancel = func() {
+			f.mu.Lock()
+			f.canceled++
+			f.mu.Unlock()
+
+			if cancel != nil {
+				cancel()
+			}
+		}
+	}
+
+	return d
+}
+
+// Calls returns the requests evaluated so far
+func (f *Fake) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]Call(nil), f.calls...)
+}
+
+// Released returns the number of allowed requests which released their resources
+func (f *Fake) Released() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.released
+}
+
+// Canceled returns the number of allowed requests which were rejected by another limiter and canceled
+func (f *Fake) Canceled() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.canceled
+}
+
+// Held returns the number of allowed requests which did not release nor cancel their resources yet
+func (f *Fake) Held() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.allowed - f.released - f.canceled
+}
@@ -3,14 +3,12 @@ package middleware
 import (
 	"context"
 	"os"
-	"runtime"
-	"strconv"
+	"strings"
+	"time"
 
 	"github.com/lucabrasi83/vscan-agent/logging"
-	"github.com/shirou/gopsutil/load"
+	"github.com/lucabrasi83/vscan-agent/metrics"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 var (
@@ -18,10 +16,6 @@ var (
 	errHost  error
 )
 
-// maxLoadLimit represents the threshold at which we abort the Request due to high system load
-// By default value is set at 90% for 5 minutes Average Load
-const maxLoadLimit = 0.9
-
 func init() {
 
 	hostname, errHost = os.Hostname()
@@ -32,24 +26,212 @@ func init() {
 
 }
 
-// alwaysPassLimiter is an example limiter which impletemts Limiter interface.
-// It does not limit any request because Limit function always returns false.
-type AlwaysPassLimiter struct{}
+// Decision represents the outcome of a Limiter evaluating a request
+type Decision struct {
+	Allowed bool
+
+	// Reason is the machine readable rejection reason reported in the ErrorInfo details, e.g. RATE_LIMITED
+	Reason string
+
+	// Message explains the rejection to the client
+	Message string
+
+	// RetryAfter is the retry delay hint reported in the RetryInfo details
+	RetryAfter time.Duration
+
+	// Metadata is reported in the ErrorInfo details
+	Metadata map[string]string
+
+	// Release, if set, is called once an allowed request completed to give back the resources it held
+	Release func()
+
+	// Cancel, if set, is called instead of Release when the request allowed by the limiter is rejected by another
+	// limiter it is composed with, to also give back the resources kept by completed requests such as rate tokens
+	Cancel func()
+}
+
+// Allow returns a Decision allowing the request
+func Allow() Decision {
+	return Decision{Allowed: true}
+}
+
+// Reject returns a Decision rejecting the request
+func Reject(reason string, message string, retryAfter time.Duration) Decision {
+	return Decision{Reason: reason, Message: message, RetryAfter: retryAfter}
+}
+
+// release gives back the resources held by an allowed request
+func (d Decision) release() {
+	if d.Release != nil {
+		d.Release()
+	}
+}
 
-// Limiter defines the interface to perform request rate limiting.
-// If Limit function return true, the request will be rejected.
-// Otherwise, the request will pass.
+// cancel gives back the resources held by an allowed request rejected by another limiter
+func (d Decision) cancel() {
+	if d.Cancel != nil {
+		d.Cancel()
+		return
+	}
+	d.release()
+}
+
+// Limiter defines the interface to perform request rate limiting and admission control.
+// Limit evaluates the request to the RPC fullMethod. req is the request message of unary RPCs and is nil for
+// streaming RPCs, whose messages are only received by the handler.
 type Limiter interface {
-	Limit() bool
+	Limit(ctx context.Context, fullMethod string, req interface{}) Decision
+}
+
+// LimiterFunc is an adapter to use a function as a Limiter
+type LimiterFunc func(ctx context.Context, fullMethod string, req interface{}) Decision
+
+// Limit calls f(ctx, fullMethod, req)
+func (f LimiterFunc) Limit(ctx context.Context, fullMethod string, req interface{}) Decision {
+	return f(ctx, fullMethod, req)
+}
+
+// Chain returns a Limiter evaluating the limiters in order and rejecting the request on the first rejection.
+// Resources held by the limiters which allowed the request before the rejection are canceled.
+func Chain(limiters ...Limiter) Limiter {
+	return LimiterFunc(func(ctx context.Context, fullMethod string, req interface{}) Decision {
+
+		allowed := make([]Decision, 0, len(limiters))
+
+		for _, l := range limiters {
+
+			d := l.Limit(ctx, fullMethod, req)
+
+			if !d.Allowed {
+				cancelAll(allowed)
+				return d
+			}
+
+			allowed = append(allowed, d)
+		}
+
+		return composedAllow(allowed)
+	})
+}
+
+// All returns a Limiter evaluating every limiter and rejecting the request if any of them rejects it.
+// The rejection with the longest retry delay is returned so the client does not retry too early.
+func All(limiters ...Limiter) Limiter {
+	return LimiterFunc(func(ctx context.Context, fullMethod string, req interface{}) Decision {
+
+		var allowed []Decision
+		var rejection *Decision
+
+		for _, l := range limiters {
+
+			d := l.Limit(ctx, fullMethod, req)
+
+			if d.Allowed {
+				allowed = append(allowed, d)
+				continue
+			}
+
+			if rejection == nil || d.RetryAfter > rejection.RetryAfter {
+				rejection = &d
+			}
+		}
+
+		if rejection != nil {
+			cancelAll(allowed)
+			return *rejection
+		}
+
+		return composedAllow(allowed)
+	})
+}
+
+// Any returns a Limiter allowing the request as soon as one of the limiters, evaluated in order, allows it.
+// If all of them reject the request, the rejection with the shortest retry delay is returned.
+func Any(limiters ...Limiter) Limiter {
+	return LimiterFunc(func(ctx context.Context, fullMethod string, req interface{}) Decision {
+
+		var rejection *Decision
+
+		for _, l := range limiters {
+
+			d := l.Limit(ctx, fullMethod, req)
+
+			if d.Allowed {
+				return d
+			}
+
+			if rejection == nil || d.RetryAfter < rejection.RetryAfter {
+				rejection = &d
+			}
+		}
+
+		if rejection != nil {
+			return *rejection
+		}
+
+		return Allow()
+	})
+}
+
+// composedAllow returns a Decision allowing the request allowed by each of the decisions, releasing or canceling
+// all of them, so composed limiters can be composed again
+func composedAllow(decisions []Decision) Decision {
+	return Decision{
+		Allowed: true,
+		Release: func() { releaseAll(decisions) },
+		Cancel:  func() { cancelAll(decisions) },
+	}
+}
+
+// releaseAll releases the allowed decisions in reverse order
+func releaseAll(decisions []Decision) {
+	for i := len(decisions) - 1; i >= 0; i-- {
+		decisions[i].release()
+	}
+}
+
+// cancelAll cancels the allowed decisions in reverse order
+func cancelAll(decisions []Decision) {
+	for i := len(decisions) - 1; i >= 0; i-- {
+		decisions[i].cancel()
+	}
+}
+
+// limit evaluates the request and returns the ResourceExhausted status error of a rejection
+func limit(ctx context.Context, limiter Limiter, fullMethod string, req interface{}) (Decision, error) {
+
+	if isHealthCheck(fullMethod) {
+		return Allow(), nil
+	}
+
+	d := limiter.Limit(ctx, fullMethod, req)
+
+	if d.Allowed {
+		return d, nil
+	}
+
+	metrics.RateLimiterRejected(fullMethod, d.Reason)
+
+	metadata := map[string]string{"method": fullMethod}
+
+	for k, v := range d.Metadata {
+		metadata[k] = v
+	}
+
+	return d, resourceExhausted(d.Message, d.Reason, d.RetryAfter, metadata)
 }
 
 // UnaryServerInterceptor returns a new unary server interceptors that performs request rate limiting.
 func UnaryServerInterceptor(limiter Limiter) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		if limiter.Limit() {
-			return nil, status.Errorf(codes.ResourceExhausted,
-				"request is rejected by agent %v due to rate limiting policy", hostname)
+		d, err := limit(ctx, limiter, info.FullMethod, req)
+
+		if err != nil {
+			return nil, err
 		}
+
+		defer d.release()
+
 		return handler(ctx, req)
 	}
 }
@@ -57,35 +239,26 @@ func UnaryServerInterceptor(limiter Limiter) grpc.UnaryServerInterceptor {
 // StreamServerInterceptor returns a new stream server interceptor that performs rate limiting on the request.
 func StreamServerInterceptor(limiter Limiter) grpc.StreamServerInterceptor {
 	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-		if limiter.Limit() {
-			return status.Errorf(codes.ResourceExhausted,
-				"request is rejected by agent %v due to rate limiting policy", hostname)
+		d, err := limit(stream.Context(), limiter, info.FullMethod, nil)
+
+		if err != nil {
+			return err
 		}
+
+		defer d.release()
+
 		return handler(srv, stream)
 	}
 }
 
-func (*AlwaysPassLimiter) Limit() bool {
-
-	// Number of CPU cores available
-	numCPU := runtime.NumCPU()
-
-	// System Load averages for 1 minute, 5 minutes, 15 minutes
-	maxLoadAverages, _ := load.Avg()
-
-	// Current 5 minutes System Load Average
-	currentLoad := maxLoadAverages.Load5
-
-	// Maximum Load tolerated below must be below 0.9
-	maxLoad := currentLoad / float64(numCPU)
-
-	if maxLoad >= maxLoadLimit {
-		logging.VSCANLog("error",
-			"Request rejected due to high system load. Current 5 minutes Average System Load at %v",
-			strconv.FormatFloat(currentLoad, 'f', 2, 64),
-		)
+// isHealthCheck returns whether the RPC belongs to the gRPC health checking service.
+// Health checks report the agent load themselves and must not be rejected by the interceptors.
+func isHealthCheck(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, "/grpc.health.v1.Health/")
+}
 
-		return true
-	}
-	return false
+// shortMethodName returns the method name of the RPC full method name, e.g. Drain for
+// /agentpb.VscanAgentService/Drain
+func shortMethodName(fullMethod string) string {
+	return fullMethod[strings.LastIndex(fullMethod, "/")+1:]
 }
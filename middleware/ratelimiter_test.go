@@ -0,0 +1,254 @@
+package middleware_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/lucabrasi83/vscan-agent/middleware"
+	"github.com/lucabrasi83/vscan-agent/middleware/limitertest"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testMethod = "/agentpb.VscanAgentService/DeviceConnectivityTest"
+
+// fakeState is the expected state of a limitertest.Fake once the composed limiter evaluated a request
+type fakeState struct {
+	calls    int
+	held     int
+	canceled int
+}
+
+func TestComposedLimiters(t *testing.T) {
+
+	allow := func() *limitertest.Fake { return limitertest.Allowing() }
+	reject := func(retryAfter time.Duration) func() *limitertest.Fake {
+		return func() *limitertest.Fake { return limitertest.Rejecting("REJECTED", retryAfter) }
+	}
+
+	tests := []struct {
+		name    string
+		compose func(...middleware.Limiter) middleware.Limiter
+		fakes   []func() *limitertest.Fake
+
+		wantAllowed    bool
+		wantRetryAfter time.Duration
+		want           []fakeState
+	}{
+		{
+			name:        "chain allowed",
+			compose:     middleware.Chain,
+			fakes:       []func() *limitertest.Fake{allow, allow},
+			wantAllowed: true,
+			want:        []fakeState{{calls: 1, held: 1}, {calls: 1, held: 1}},
+		},
+		{
+			name:           "chain cancels the limiters allowing before the rejection",
+			compose:        middleware.Chain,
+			fakes:          []func() *limitertest.Fake{allow, allow, reject(time.Second), allow},
+			wantRetryAfter: time.Second,
+			want:           []fakeState{{calls: 1, canceled: 1}, {calls: 1, canceled: 1}, {calls: 1}, {}},
+		},
+		{
+			name:        "all allowed",
+			compose:     middleware.All,
+			fakes:       []func() *limitertest.Fake{allow, allow},
+			wantAllowed: true,
+			want:        []fakeState{{calls: 1, held: 1}, {calls: 1, held: 1}},
+		},
+		{
+			name:           "all cancels the allowing limiters and picks the longest retry delay",
+			compose:        middleware.All,
+			fakes:          []func() *limitertest.Fake{reject(time.Second), allow, reject(5 * time.Second)},
+			wantRetryAfter: 5 * time.Second,
+			want:           []fakeState{{calls: 1}, {calls: 1, canceled: 1}, {calls: 1}},
+		},
+		{
+			name:        "any allowed by the first allowing limiter",
+			compose:     middleware.Any,
+			fakes:       []func() *limitertest.Fake{reject(time.Second), allow, allow},
+			wantAllowed: true,
+			want:        []fakeState{{calls: 1}, {calls: 1, held: 1}, {}},
+		},
+		{
+			name:           "any picks the shortest retry delay",
+			compose:        middleware.Any,
+			fakes:          []func() *limitertest.Fake{reject(3 * time.Second), reject(time.Second)},
+			wantRetryAfter: time.Second,
+			want:           []fakeState{{calls: 1}, {calls: 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			fakes := make([]*limitertest.Fake, len(tt.fakes))
+			limiters := make([]middleware.Limiter, len(tt.fakes))
+
+			for i, newFake := range tt.fakes {
+				fakes[i] = newFake()
+				limiters[i] = fakes[i]
+			}
+
+			d := tt.compose(limiters...).Limit(context.Background(), testMethod, nil)
+
+			if d.Allowed != tt.wantAllowed {
+				t.Fatalf("allowed = %v, want %v", d.Allowed, tt.wantAllowed)
+			}
+
+			if !d.Allowed && d.RetryAfter != tt.wantRetryAfter {
+				t.Errorf("retry after = %v, want %v", d.RetryAfter, tt.wantRetryAfter)
+			}
+
+			for i, f := range fakes {
+
+				got := fakeState{calls: len(f.Calls()), held: f.Held(), canceled: f.Canceled()}
+
+				if got != tt.want[i] {
+					t.Errorf("limiter %v state = %+v, want %+v", i, got, tt.want[i])
+				}
+			}
+
+			if d.Release != nil {
+				d.Release()
+			}
+
+			for i, f := range fakes {
+				if f.Held() != 0 {
+					t.Errorf("limiter %v holds %v request(s) after release", i, f.Held())
+				}
+			}
+		})
+	}
+}
+
+func TestNestedLimitersCancelOnLaterRejection(t *testing.T) {
+
+	first, second := limitertest.Allowing(), limitertest.Allowing()
+
+	limiter := middleware.Chain(middleware.All(first, second), limitertest.Rejecting("REJECTED", time.Second))
+
+	if d := limiter.Limit(context.Background(), testMethod, nil); d.Allowed {
+		t.Fatalf("request allowed although rejected by the last limiter")
+	}
+
+	for i, f := range []*limitertest.Fake{first, second} {
+		if f.Canceled() != 1 || f.Held() != 0 {
+			t.Errorf("nested limiter %v canceled %v and holds %v request(s), want 1 and 0", i, f.Canceled(), f.Held())
+		}
+	}
+}
+
+// Only the health checks bypass the limiters. The exemption of other RPCs is set in the limiters configuration.
+func TestUnaryServerInterceptorExemptMethods(t *testing.T) {
+
+	tests := []struct {
+		fullMethod string
+		exempt     bool
+	}{
+		{fullMethod: "/agentpb.VscanAgentService/GetAgentInfo"},
+		{fullMethod: "/agentpb.VscanAgentService/Drain"},
+		{fullMethod: "/grpc.health.v1.Health/Check", exempt: true},
+		{fullMethod: "/grpc.health.v1.Health/Watch", exempt: true},
+		{fullMethod: testMethod},
+		{fullMethod: "/agentpb.VscanAgentService/SSHConnectivityTest"},
+	}
+
+	for _, tt := range tests {
+
+		limiter := limitertest.Rejecting("REJECTED", time.Second)
+
+		handled := false
+
+		_, err := middleware.UnaryServerInterceptor(limiter)(context.Background(), nil,
+			&grpc.UnaryServerInfo{FullMethod: tt.fullMethod},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				handled = true
+				return nil, nil
+			})
+
+		if evaluated := len(limiter.Calls()) > 0; evaluated == tt.exempt {
+			t.Errorf("%v evaluated by the limiter = %v, want %v", tt.fullMethod, evaluated, !tt.exempt)
+		}
+
+		if handled != tt.exempt || (err == nil) != tt.exempt {
+			t.Errorf("%v handled = %v with error %v, want handled %v", tt.fullMethod, handled, err, tt.exempt)
+		}
+	}
+}
+
+func TestUnaryServerInterceptorRejectionDetails(t *testing.T) {
+
+	rejection := middleware.Reject(middleware.ReasonRateLimited, "client exceeded its rate", 3*time.Second)
+	rejection.Metadata = map[string]string{"identity": "controller.vscan.test"}
+
+	limiter := &limitertest.Fake{Decision: rejection}
+
+	_, err := middleware.UnaryServerInterceptor(limiter)(context.Background(), nil,
+		&grpc.UnaryServerInfo{FullMethod: testMethod},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			t.Errorf("handler called for a rejected request")
+			return nil, nil
+		})
+
+	st := status.Convert(err)
+
+	if st.Code() != codes.ResourceExhausted {
+		t.Fatalf("rejection code = %v, want %v", st.Code(), codes.ResourceExhausted)
+	}
+
+	var errorInfo *errdetails.ErrorInfo
+	var retryInfo *errdetails.RetryInfo
+
+	for _, detail := range st.Details() {
+		switch detail := detail.(type) {
+		case *errdetails.ErrorInfo:
+			errorInfo = detail
+		case *errdetails.RetryInfo:
+			retryInfo = detail
+		}
+	}
+
+	if errorInfo == nil || retryInfo == nil {
+		t.Fatalf("rejection details = %v, want ErrorInfo and RetryInfo", st.Details())
+	}
+
+	if errorInfo.GetDomain() != middleware.AdmissionErrorDomain || errorInfo.GetReason() != middleware.ReasonRateLimited {
+		t.Errorf("ErrorInfo domain %v and reason %v, want %v and %v", errorInfo.GetDomain(), errorInfo.GetReason(),
+			middleware.AdmissionErrorDomain, middleware.ReasonRateLimited)
+	}
+
+	if md := errorInfo.GetMetadata(); md["method"] != testMethod || md["identity"] != "controller.vscan.test" {
+		t.Errorf("ErrorInfo metadata = %v, want the method and the limiter metadata", md)
+	}
+
+	if delay, err := ptypes.Duration(retryInfo.GetRetryDelay()); err != nil || delay != 3*time.Second {
+		t.Errorf("RetryInfo delay = %v (%v), want %v", delay, err, 3*time.Second)
+	}
+}
+
+func TestUnaryServerInterceptorReleasesAfterHandler(t *testing.T) {
+
+	limiter := limitertest.Allowing()
+
+	_, err := middleware.UnaryServerInterceptor(limiter)(context.Background(), nil,
+		&grpc.UnaryServerInfo{FullMethod: testMethod},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			if limiter.Held() != 1 {
+				t.Errorf("request holds %v limiter resource(s) while handled, want 1", limiter.Held())
+			}
+			return nil, nil
+		})
+
+	if err != nil {
+		t.Fatalf("allowed request failed: %v", err)
+	}
+
+	if limiter.Released() != 1 || limiter.Canceled() != 0 {
+		t.Errorf("released %v and canceled %v request(s), want 1 and 0", limiter.Released(), limiter.Canceled())
+	}
+}
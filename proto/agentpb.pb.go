@@ -3,17 +3,19 @@
 
 package agentpb
 
-import proto "github.com/gogo/protobuf/proto"
-import fmt "fmt"
-import math "math"
-
 import (
-	context "golang.org/x/net/context"
+	context "context"
+	encoding_binary "encoding/binary"
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
 	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	io "io"
+	math "math"
+	math_bits "math/bits"
 )
 
-import io "io"
-
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
 var _ = fmt.Errorf
@@ -23,24 +25,211 @@ var _ = math.Inf
 // is compatible with the proto package it is being compiled against.
 // A compilation error at this line likely means your copy of the
 // proto package needs to be updated.
-const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// DeviceVendor represents the vendor / platform family of the device(s) a set of credentials applies to.
+// VENDOR_UNSPECIFIED means the legacy credentials_device_vendor string is used instead.
+type DeviceVendor int32
+
+const (
+	DeviceVendor_VENDOR_UNSPECIFIED DeviceVendor = 0
+	DeviceVendor_CISCO              DeviceVendor = 1
+	DeviceVendor_JUNIPER            DeviceVendor = 2
+	DeviceVendor_ARISTA             DeviceVendor = 3
+	DeviceVendor_PALO_ALTO          DeviceVendor = 4
+	DeviceVendor_LINUX              DeviceVendor = 5
+)
+
+var DeviceVendor_name = map[int32]string{
+	0: "VENDOR_UNSPECIFIED",
+	1: "CISCO",
+	2: "JUNIPER",
+	3: "ARISTA",
+	4: "PALO_ALTO",
+	5: "LINUX",
+}
+
+var DeviceVendor_value = map[string]int32{
+	"VENDOR_UNSPECIFIED": 0,
+	"CISCO":              1,
+	"JUNIPER":            2,
+	"ARISTA":             3,
+	"PALO_ALTO":          4,
+	"LINUX":              5,
+}
+
+func (x DeviceVendor) String() string {
+	return proto.EnumName(DeviceVendor_name, int32(x))
+}
+
+func (DeviceVendor) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_0233734088c6ede9, []int{0}
+}
+
+// PrivilegeMethod represents how the VSCAN Agent must elevate its privileges once logged in to the device.
+// ENABLE applies to Cisco and Arista devices, SUDO and SU apply to Linux hosts.
+type PrivilegeMethod int32
+
+const (
+	PrivilegeMethod_PRIVILEGE_NONE PrivilegeMethod = 0
+	PrivilegeMethod_ENABLE         PrivilegeMethod = 1
+	PrivilegeMethod_SUDO           PrivilegeMethod = 2
+	PrivilegeMethod_SU             PrivilegeMethod = 3
+)
+
+var PrivilegeMethod_name = map[int32]string{
+	0: "PRIVILEGE_NONE",
+	1: "ENABLE",
+	2: "SUDO",
+	3: "SU",
+}
+
+var PrivilegeMethod_value = map[string]int32{
+	"PRIVILEGE_NONE": 0,
+	"ENABLE":         1,
+	"SUDO":           2,
+	"SU":             3,
+}
+
+func (x PrivilegeMethod) String() string {
+	return proto.EnumName(PrivilegeMethod_name, int32(x))
+}
+
+func (PrivilegeMethod) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_0233734088c6ede9, []int{1}
+}
+
+// SSHFailureReason classifies the reason why an SSH connectivity test failed
+type SSHFailureReason int32
+
+const (
+	SSHFailureReason_SSH_FAILURE_NONE                      SSHFailureReason = 0
+	SSHFailureReason_SSH_FAILURE_DNS                       SSHFailureReason = 1
+	SSHFailureReason_SSH_FAILURE_CONNECTION_REFUSED        SSHFailureReason = 2
+	SSHFailureReason_SSH_FAILURE_TIMEOUT                   SSHFailureReason = 3
+	SSHFailureReason_SSH_FAILURE_AUTH_REJECTED             SSHFailureReason = 4
+	SSHFailureReason_SSH_FAILURE_HOST_KEY_MISMATCH         SSHFailureReason = 5
+	SSHFailureReason_SSH_FAILURE_OTHER                     SSHFailureReason = 6
+	SSHFailureReason_SSH_FAILURE_HANDSHAKE                 SSHFailureReason = 7
+	SSHFailureReason_SSH_FAILURE_NO_SUPPORTED_AUTH_METHODS SSHFailureReason = 8
+)
+
+var SSHFailureReason_name = map[int32]string{
+	0: "SSH_FAILURE_NONE",
+	1: "SSH_FAILURE_DNS",
+	2: "SSH_FAILURE_CONNECTION_REFUSED",
+	3: "SSH_FAILURE_TIMEOUT",
+	4: "SSH_FAILURE_AUTH_REJECTED",
+	5: "SSH_FAILURE_HOST_KEY_MISMATCH",
+	6: "SSH_FAILURE_OTHER",
+	7: "SSH_FAILURE_HANDSHAKE",
+	8: "SSH_FAILURE_NO_SUPPORTED_AUTH_METHODS",
+}
+
+var SSHFailureReason_value = map[string]int32{
+	"SSH_FAILURE_NONE":                      0,
+	"SSH_FAILURE_DNS":                       1,
+	"SSH_FAILURE_CONNECTION_REFUSED":        2,
+	"SSH_FAILURE_TIMEOUT":                   3,
+	"SSH_FAILURE_AUTH_REJECTED":             4,
+	"SSH_FAILURE_HOST_KEY_MISMATCH":         5,
+	"SSH_FAILURE_OTHER":                     6,
+	"SSH_FAILURE_HANDSHAKE":                 7,
+	"SSH_FAILURE_NO_SUPPORTED_AUTH_METHODS": 8,
+}
+
+func (x SSHFailureReason) String() string {
+	return proto.EnumName(SSHFailureReason_name, int32(x))
+}
+
+func (SSHFailureReason) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_0233734088c6ede9, []int{2}
+}
+
+// KeyboardInteractiveAnswer maps an SSH keyboard-interactive prompt to the answer the VSCAN Agent must reply with
+// prompt_pattern is a case insensitive regular expression matched against the prompt sent by the SSH server
+type KeyboardInteractiveAnswer struct {
+	PromptPattern string `protobuf:"bytes,1,opt,name=prompt_pattern,json=promptPattern,proto3" json:"prompt_pattern,omitempty"`
+	Answer        string `protobuf:"bytes,2,opt,name=answer,proto3" json:"answer,omitempty"`
+}
+
+func (m *KeyboardInteractiveAnswer) Reset()         { *m = KeyboardInteractiveAnswer{} }
+func (m *KeyboardInteractiveAnswer) String() string { return proto.CompactTextString(m) }
+func (*KeyboardInteractiveAnswer) ProtoMessage()    {}
+func (*KeyboardInteractiveAnswer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0233734088c6ede9, []int{0}
+}
+func (m *KeyboardInteractiveAnswer) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KeyboardInteractiveAnswer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KeyboardInteractiveAnswer.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *KeyboardInteractiveAnswer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeyboardInteractiveAnswer.Merge(m, src)
+}
+func (m *KeyboardInteractiveAnswer) XXX_Size() int {
+	return m.Size()
+}
+func (m *KeyboardInteractiveAnswer) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeyboardInteractiveAnswer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KeyboardInteractiveAnswer proto.InternalMessageInfo
+
+func (m *KeyboardInteractiveAnswer) GetPromptPattern() string {
+	if m != nil {
+		return m.PromptPattern
+	}
+	return ""
+}
+
+func (m *KeyboardInteractiveAnswer) GetAnswer() string {
+	if m != nil {
+		return m.Answer
+	}
+	return ""
+}
 
 // SSHGateway message represents an SSH Gateway settings to be used in order to scan devices
 // located on a private network.
 // This is an optional message
+// gateway_private_key_passphrase is required when gateway_private_key is an encrypted PEM private key
+// gateway_host_key_fingerprints pins the accepted gateway host key(s) in SHA256 or legacy MD5 fingerprint format
+// gateway_certificate is an optional OpenSSH certificate (authorized_keys format) signing gateway_private_key
+// gateway_keyboard_interactive_answers are used to reply to keyboard-interactive prompts (e.g. static OTP).
+// Password prompts without a matching answer are replied with gateway_password.
+// Servers requiring several authentication methods (e.g. publickey then password) are supported by providing
+// the credentials of each method.
+// gateway_proxy_url is an optional socks5:// or http:// (HTTP CONNECT) proxy URL, with optional user:password
+// credentials, used to reach the gateway. It overrides the VSCAN Agent default SSH proxy.
 type SSHGateway struct {
-	GatewayName       string `protobuf:"bytes,1,opt,name=gateway_name,json=gatewayName,proto3" json:"gateway_name,omitempty"`
-	GatewayIp         string `protobuf:"bytes,2,opt,name=gateway_ip,json=gatewayIp,proto3" json:"gateway_ip,omitempty"`
-	GatewayUsername   string `protobuf:"bytes,3,opt,name=gateway_username,json=gatewayUsername,proto3" json:"gateway_username,omitempty"`
-	GatewayPassword   string `protobuf:"bytes,4,opt,name=gateway_password,json=gatewayPassword,proto3" json:"gateway_password,omitempty"`
-	GatewayPrivateKey string `protobuf:"bytes,5,opt,name=gateway_private_key,json=gatewayPrivateKey,proto3" json:"gateway_private_key,omitempty"`
+	GatewayName                       string                       `protobuf:"bytes,1,opt,name=gateway_name,json=gatewayName,proto3" json:"gateway_name,omitempty"`
+	GatewayIp                         string                       `protobuf:"bytes,2,opt,name=gateway_ip,json=gatewayIp,proto3" json:"gateway_ip,omitempty"`
+	GatewayUsername                   string                       `protobuf:"bytes,3,opt,name=gateway_username,json=gatewayUsername,proto3" json:"gateway_username,omitempty"`
+	GatewayPassword                   string                       `protobuf:"bytes,4,opt,name=gateway_password,json=gatewayPassword,proto3" json:"gateway_password,omitempty"`
+	GatewayPrivateKey                 string                       `protobuf:"bytes,5,opt,name=gateway_private_key,json=gatewayPrivateKey,proto3" json:"gateway_private_key,omitempty"`
+	GatewayPrivateKeyPassphrase       string                       `protobuf:"bytes,6,opt,name=gateway_private_key_passphrase,json=gatewayPrivateKeyPassphrase,proto3" json:"gateway_private_key_passphrase,omitempty"`
+	GatewayHostKeyFingerprints        []string                     `protobuf:"bytes,7,rep,name=gateway_host_key_fingerprints,json=gatewayHostKeyFingerprints,proto3" json:"gateway_host_key_fingerprints,omitempty"`
+	GatewayCertificate                string                       `protobuf:"bytes,8,opt,name=gateway_certificate,json=gatewayCertificate,proto3" json:"gateway_certificate,omitempty"`
+	GatewayKeyboardInteractiveAnswers []*KeyboardInteractiveAnswer `protobuf:"bytes,9,rep,name=gateway_keyboard_interactive_answers,json=gatewayKeyboardInteractiveAnswers,proto3" json:"gateway_keyboard_interactive_answers,omitempty"`
+	GatewayProxyUrl                   string                       `protobuf:"bytes,10,opt,name=gateway_proxy_url,json=gatewayProxyUrl,proto3" json:"gateway_proxy_url,omitempty"`
 }
 
 func (m *SSHGateway) Reset()         { *m = SSHGateway{} }
 func (m *SSHGateway) String() string { return proto.CompactTextString(m) }
 func (*SSHGateway) ProtoMessage()    {}
 func (*SSHGateway) Descriptor() ([]byte, []int) {
-	return fileDescriptor_agentpb_a363b3d4a74a9f5b, []int{0}
+	return fileDescriptor_0233734088c6ede9, []int{1}
 }
 func (m *SSHGateway) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -50,15 +239,15 @@ func (m *SSHGateway) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return xxx_messageInfo_SSHGateway.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
 			return nil, err
 		}
 		return b[:n], nil
 	}
 }
-func (dst *SSHGateway) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SSHGateway.Merge(dst, src)
+func (m *SSHGateway) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SSHGateway.Merge(m, src)
 }
 func (m *SSHGateway) XXX_Size() int {
 	return m.Size()
@@ -104,21 +293,64 @@ func (m *SSHGateway) GetGatewayPrivateKey() string {
 	return ""
 }
 
+func (m *SSHGateway) GetGatewayPrivateKeyPassphrase() string {
+	if m != nil {
+		return m.GatewayPrivateKeyPassphrase
+	}
+	return ""
+}
+
+func (m *SSHGateway) GetGatewayHostKeyFingerprints() []string {
+	if m != nil {
+		return m.GatewayHostKeyFingerprints
+	}
+	return nil
+}
+
+func (m *SSHGateway) GetGatewayCertificate() string {
+	if m != nil {
+		return m.GatewayCertificate
+	}
+	return ""
+}
+
+func (m *SSHGateway) GetGatewayKeyboardInteractiveAnswers() []*KeyboardInteractiveAnswer {
+	if m != nil {
+		return m.GatewayKeyboardInteractiveAnswers
+	}
+	return nil
+}
+
+func (m *SSHGateway) GetGatewayProxyUrl() string {
+	if m != nil {
+		return m.GatewayProxyUrl
+	}
+	return ""
+}
+
 // UserDeviceCredentials represents the device credentials the VSCAN Agent must use to access the device.
+// ios_enable_password is kept for backward compatibility and is superseded by privilege_password.
+// private_key_passphrase is required when private_key is an encrypted PEM private key.
+// certificate is an optional OpenSSH certificate (authorized_keys format) signing private_key.
 type UserDeviceCredentials struct {
-	CredentialsName         string `protobuf:"bytes,1,opt,name=credentials_name,json=credentialsName,proto3" json:"credentials_name,omitempty"`
-	CredentialsDeviceVendor string `protobuf:"bytes,2,opt,name=credentials_device_vendor,json=credentialsDeviceVendor,proto3" json:"credentials_device_vendor,omitempty"`
-	Username                string `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
-	Password                string `protobuf:"bytes,4,opt,name=password,proto3" json:"password,omitempty"`
-	IosEnablePassword       string `protobuf:"bytes,5,opt,name=ios_enable_password,json=iosEnablePassword,proto3" json:"ios_enable_password,omitempty"`
-	PrivateKey              string `protobuf:"bytes,6,opt,name=private_key,json=privateKey,proto3" json:"private_key,omitempty"`
+	CredentialsName         string          `protobuf:"bytes,1,opt,name=credentials_name,json=credentialsName,proto3" json:"credentials_name,omitempty"`
+	CredentialsDeviceVendor string          `protobuf:"bytes,2,opt,name=credentials_device_vendor,json=credentialsDeviceVendor,proto3" json:"credentials_device_vendor,omitempty"`
+	Username                string          `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	Password                string          `protobuf:"bytes,4,opt,name=password,proto3" json:"password,omitempty"`
+	IosEnablePassword       string          `protobuf:"bytes,5,opt,name=ios_enable_password,json=iosEnablePassword,proto3" json:"ios_enable_password,omitempty"`
+	PrivateKey              string          `protobuf:"bytes,6,opt,name=private_key,json=privateKey,proto3" json:"private_key,omitempty"`
+	DeviceVendor            DeviceVendor    `protobuf:"varint,7,opt,name=device_vendor,json=deviceVendor,proto3,enum=agentpb.DeviceVendor" json:"device_vendor,omitempty"`
+	PrivilegeMethod         PrivilegeMethod `protobuf:"varint,8,opt,name=privilege_method,json=privilegeMethod,proto3,enum=agentpb.PrivilegeMethod" json:"privilege_method,omitempty"`
+	PrivilegePassword       string          `protobuf:"bytes,9,opt,name=privilege_password,json=privilegePassword,proto3" json:"privilege_password,omitempty"`
+	PrivateKeyPassphrase    string          `protobuf:"bytes,10,opt,name=private_key_passphrase,json=privateKeyPassphrase,proto3" json:"private_key_passphrase,omitempty"`
+	Certificate             string          `protobuf:"bytes,11,opt,name=certificate,proto3" json:"certificate,omitempty"`
 }
 
 func (m *UserDeviceCredentials) Reset()         { *m = UserDeviceCredentials{} }
 func (m *UserDeviceCredentials) String() string { return proto.CompactTextString(m) }
 func (*UserDeviceCredentials) ProtoMessage()    {}
 func (*UserDeviceCredentials) Descriptor() ([]byte, []int) {
-	return fileDescriptor_agentpb_a363b3d4a74a9f5b, []int{1}
+	return fileDescriptor_0233734088c6ede9, []int{2}
 }
 func (m *UserDeviceCredentials) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -128,15 +360,15 @@ func (m *UserDeviceCredentials) XXX_Marshal(b []byte, deterministic bool) ([]byt
 		return xxx_messageInfo_UserDeviceCredentials.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
 			return nil, err
 		}
 		return b[:n], nil
 	}
 }
-func (dst *UserDeviceCredentials) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UserDeviceCredentials.Merge(dst, src)
+func (m *UserDeviceCredentials) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UserDeviceCredentials.Merge(m, src)
 }
 func (m *UserDeviceCredentials) XXX_Size() int {
 	return m.Size()
@@ -189,6 +421,41 @@ func (m *UserDeviceCredentials) GetPrivateKey() string {
 	return ""
 }
 
+func (m *UserDeviceCredentials) GetDeviceVendor() DeviceVendor {
+	if m != nil {
+		return m.DeviceVendor
+	}
+	return DeviceVendor_VENDOR_UNSPECIFIED
+}
+
+func (m *UserDeviceCredentials) GetPrivilegeMethod() PrivilegeMethod {
+	if m != nil {
+		return m.PrivilegeMethod
+	}
+	return PrivilegeMethod_PRIVILEGE_NONE
+}
+
+func (m *UserDeviceCredentials) GetPrivilegePassword() string {
+	if m != nil {
+		return m.PrivilegePassword
+	}
+	return ""
+}
+
+func (m *UserDeviceCredentials) GetPrivateKeyPassphrase() string {
+	if m != nil {
+		return m.PrivateKeyPassphrase
+	}
+	return ""
+}
+
+func (m *UserDeviceCredentials) GetCertificate() string {
+	if m != nil {
+		return m.Certificate
+	}
+	return ""
+}
+
 // Device represents the device name / ip address pair a scan is requested for.
 type Device struct {
 	DeviceName string `protobuf:"bytes,1,opt,name=device_name,json=deviceName,proto3" json:"device_name,omitempty"`
@@ -199,7 +466,7 @@ func (m *Device) Reset()         { *m = Device{} }
 func (m *Device) String() string { return proto.CompactTextString(m) }
 func (*Device) ProtoMessage()    {}
 func (*Device) Descriptor() ([]byte, []int) {
-	return fileDescriptor_agentpb_a363b3d4a74a9f5b, []int{2}
+	return fileDescriptor_0233734088c6ede9, []int{3}
 }
 func (m *Device) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -209,15 +476,15 @@ func (m *Device) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return xxx_messageInfo_Device.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
 			return nil, err
 		}
 		return b[:n], nil
 	}
 }
-func (dst *Device) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Device.Merge(dst, src)
+func (m *Device) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Device.Merge(m, src)
 }
 func (m *Device) XXX_Size() int {
 	return m.Size()
@@ -262,7 +529,7 @@ func (m *ScanRequest) Reset()         { *m = ScanRequest{} }
 func (m *ScanRequest) String() string { return proto.CompactTextString(m) }
 func (*ScanRequest) ProtoMessage()    {}
 func (*ScanRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_agentpb_a363b3d4a74a9f5b, []int{3}
+	return fileDescriptor_0233734088c6ede9, []int{4}
 }
 func (m *ScanRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -272,15 +539,15 @@ func (m *ScanRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return xxx_messageInfo_ScanRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
 			return nil, err
 		}
 		return b[:n], nil
 	}
 }
-func (dst *ScanRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ScanRequest.Merge(dst, src)
+func (m *ScanRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScanRequest.Merge(m, src)
 }
 func (m *ScanRequest) XXX_Size() int {
 	return m.Size()
@@ -345,7 +612,7 @@ func (m *ScanResultsResponse) Reset()         { *m = ScanResultsResponse{} }
 func (m *ScanResultsResponse) String() string { return proto.CompactTextString(m) }
 func (*ScanResultsResponse) ProtoMessage()    {}
 func (*ScanResultsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_agentpb_a363b3d4a74a9f5b, []int{4}
+	return fileDescriptor_0233734088c6ede9, []int{5}
 }
 func (m *ScanResultsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -355,15 +622,15 @@ func (m *ScanResultsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return xxx_messageInfo_ScanResultsResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
 			return nil, err
 		}
 		return b[:n], nil
 	}
 }
-func (dst *ScanResultsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ScanResultsResponse.Merge(dst, src)
+func (m *ScanResultsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScanResultsResponse.Merge(m, src)
 }
 func (m *ScanResultsResponse) XXX_Size() int {
 	return m.Size()
@@ -418,7 +685,7 @@ func (m *ScanLogFileResponseWB) Reset()         { *m = ScanLogFileResponseWB{} }
 func (m *ScanLogFileResponseWB) String() string { return proto.CompactTextString(m) }
 func (*ScanLogFileResponseWB) ProtoMessage()    {}
 func (*ScanLogFileResponseWB) Descriptor() ([]byte, []int) {
-	return fileDescriptor_agentpb_a363b3d4a74a9f5b, []int{5}
+	return fileDescriptor_0233734088c6ede9, []int{6}
 }
 func (m *ScanLogFileResponseWB) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -428,15 +695,15 @@ func (m *ScanLogFileResponseWB) XXX_Marshal(b []byte, deterministic bool) ([]byt
 		return xxx_messageInfo_ScanLogFileResponseWB.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
 			return nil, err
 		}
 		return b[:n], nil
 	}
 }
-func (dst *ScanLogFileResponseWB) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ScanLogFileResponseWB.Merge(dst, src)
+func (m *ScanLogFileResponseWB) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScanLogFileResponseWB.Merge(m, src)
 }
 func (m *ScanLogFileResponseWB) XXX_Size() int {
 	return m.Size()
@@ -463,7 +730,7 @@ func (m *ScanLogFileResponsePS) Reset()         { *m = ScanLogFileResponsePS{} }
 func (m *ScanLogFileResponsePS) String() string { return proto.CompactTextString(m) }
 func (*ScanLogFileResponsePS) ProtoMessage()    {}
 func (*ScanLogFileResponsePS) Descriptor() ([]byte, []int) {
-	return fileDescriptor_agentpb_a363b3d4a74a9f5b, []int{6}
+	return fileDescriptor_0233734088c6ede9, []int{7}
 }
 func (m *ScanLogFileResponsePS) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -473,15 +740,15 @@ func (m *ScanLogFileResponsePS) XXX_Marshal(b []byte, deterministic bool) ([]byt
 		return xxx_messageInfo_ScanLogFileResponsePS.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
 			return nil, err
 		}
 		return b[:n], nil
 	}
 }
-func (dst *ScanLogFileResponsePS) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ScanLogFileResponsePS.Merge(dst, src)
+func (m *ScanLogFileResponsePS) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScanLogFileResponsePS.Merge(m, src)
 }
 func (m *ScanLogFileResponsePS) XXX_Size() int {
 	return m.Size()
@@ -508,7 +775,7 @@ func (m *SSHGatewayTestRequest) Reset()         { *m = SSHGatewayTestRequest{} }
 func (m *SSHGatewayTestRequest) String() string { return proto.CompactTextString(m) }
 func (*SSHGatewayTestRequest) ProtoMessage()    {}
 func (*SSHGatewayTestRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_agentpb_a363b3d4a74a9f5b, []int{7}
+	return fileDescriptor_0233734088c6ede9, []int{8}
 }
 func (m *SSHGatewayTestRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -518,15 +785,15 @@ func (m *SSHGatewayTestRequest) XXX_Marshal(b []byte, deterministic bool) ([]byt
 		return xxx_messageInfo_SSHGatewayTestRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
 			return nil, err
 		}
 		return b[:n], nil
 	}
 }
-func (dst *SSHGatewayTestRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SSHGatewayTestRequest.Merge(dst, src)
+func (m *SSHGatewayTestRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SSHGatewayTestRequest.Merge(m, src)
 }
 func (m *SSHGatewayTestRequest) XXX_Size() int {
 	return m.Size()
@@ -544,17 +811,115 @@ func (m *SSHGatewayTestRequest) GetSshGateway() *SSHGateway {
 	return nil
 }
 
+// SSHNegotiatedAlgorithms represents the algorithms negotiated during the SSH key exchange
+// MAC algorithms are reported as "implicit" when an AEAD cipher is negotiated
+type SSHNegotiatedAlgorithms struct {
+	KexAlgorithm         string `protobuf:"bytes,1,opt,name=kex_algorithm,json=kexAlgorithm,proto3" json:"kex_algorithm,omitempty"`
+	HostKeyAlgorithm     string `protobuf:"bytes,2,opt,name=host_key_algorithm,json=hostKeyAlgorithm,proto3" json:"host_key_algorithm,omitempty"`
+	CipherClientToServer string `protobuf:"bytes,3,opt,name=cipher_client_to_server,json=cipherClientToServer,proto3" json:"cipher_client_to_server,omitempty"`
+	CipherServerToClient string `protobuf:"bytes,4,opt,name=cipher_server_to_client,json=cipherServerToClient,proto3" json:"cipher_server_to_client,omitempty"`
+	MacClientToServer    string `protobuf:"bytes,5,opt,name=mac_client_to_server,json=macClientToServer,proto3" json:"mac_client_to_server,omitempty"`
+	MacServerToClient    string `protobuf:"bytes,6,opt,name=mac_server_to_client,json=macServerToClient,proto3" json:"mac_server_to_client,omitempty"`
+}
+
+func (m *SSHNegotiatedAlgorithms) Reset()         { *m = SSHNegotiatedAlgorithms{} }
+func (m *SSHNegotiatedAlgorithms) String() string { return proto.CompactTextString(m) }
+func (*SSHNegotiatedAlgorithms) ProtoMessage()    {}
+func (*SSHNegotiatedAlgorithms) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0233734088c6ede9, []int{9}
+}
+func (m *SSHNegotiatedAlgorithms) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SSHNegotiatedAlgorithms) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SSHNegotiatedAlgorithms.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SSHNegotiatedAlgorithms) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SSHNegotiatedAlgorithms.Merge(m, src)
+}
+func (m *SSHNegotiatedAlgorithms) XXX_Size() int {
+	return m.Size()
+}
+func (m *SSHNegotiatedAlgorithms) XXX_DiscardUnknown() {
+	xxx_messageInfo_SSHNegotiatedAlgorithms.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SSHNegotiatedAlgorithms proto.InternalMessageInfo
+
+func (m *SSHNegotiatedAlgorithms) GetKexAlgorithm() string {
+	if m != nil {
+		return m.KexAlgorithm
+	}
+	return ""
+}
+
+func (m *SSHNegotiatedAlgorithms) GetHostKeyAlgorithm() string {
+	if m != nil {
+		return m.HostKeyAlgorithm
+	}
+	return ""
+}
+
+func (m *SSHNegotiatedAlgorithms) GetCipherClientToServer() string {
+	if m != nil {
+		return m.CipherClientToServer
+	}
+	return ""
+}
+
+func (m *SSHNegotiatedAlgorithms) GetCipherServerToClient() string {
+	if m != nil {
+		return m.CipherServerToClient
+	}
+	return ""
+}
+
+func (m *SSHNegotiatedAlgorithms) GetMacClientToServer() string {
+	if m != nil {
+		return m.MacClientToServer
+	}
+	return ""
+}
+
+func (m *SSHNegotiatedAlgorithms) GetMacServerToClient() string {
+	if m != nil {
+		return m.MacServerToClient
+	}
+	return ""
+}
+
 // SSHGatewayTestResponse represents a response to test connectivity to an openSSH host
+// ssh_host_key_fingerprint is the SHA256 fingerprint of the host key observed during the test
+// ssh_host_key_matched indicates whether it matched a pinned fingerprint or the agent known_hosts file
+// ssh_failure_reason classifies the failure when ssh_can_connect is false
+// tcp_connect_ms, handshake_ms and auth_ms break down the time spent in each connection phase
 type SSHGatewayTestResponse struct {
-	SshTestResult string `protobuf:"bytes,1,opt,name=ssh_test_result,json=sshTestResult,proto3" json:"ssh_test_result,omitempty"`
-	SshCanConnect bool   `protobuf:"varint,2,opt,name=ssh_can_connect,json=sshCanConnect,proto3" json:"ssh_can_connect,omitempty"`
+	SshTestResult           string                   `protobuf:"bytes,1,opt,name=ssh_test_result,json=sshTestResult,proto3" json:"ssh_test_result,omitempty"`
+	SshCanConnect           bool                     `protobuf:"varint,2,opt,name=ssh_can_connect,json=sshCanConnect,proto3" json:"ssh_can_connect,omitempty"`
+	SshHostKeyFingerprint   string                   `protobuf:"bytes,3,opt,name=ssh_host_key_fingerprint,json=sshHostKeyFingerprint,proto3" json:"ssh_host_key_fingerprint,omitempty"`
+	SshHostKeyMatched       bool                     `protobuf:"varint,4,opt,name=ssh_host_key_matched,json=sshHostKeyMatched,proto3" json:"ssh_host_key_matched,omitempty"`
+	SshFailureReason        SSHFailureReason         `protobuf:"varint,5,opt,name=ssh_failure_reason,json=sshFailureReason,proto3,enum=agentpb.SSHFailureReason" json:"ssh_failure_reason,omitempty"`
+	SshServerVersion        string                   `protobuf:"bytes,6,opt,name=ssh_server_version,json=sshServerVersion,proto3" json:"ssh_server_version,omitempty"`
+	SshNegotiatedAlgorithms *SSHNegotiatedAlgorithms `protobuf:"bytes,7,opt,name=ssh_negotiated_algorithms,json=sshNegotiatedAlgorithms,proto3" json:"ssh_negotiated_algorithms,omitempty"`
+	TcpConnectMs            int64                    `protobuf:"varint,8,opt,name=tcp_connect_ms,json=tcpConnectMs,proto3" json:"tcp_connect_ms,omitempty"`
+	HandshakeMs             int64                    `protobuf:"varint,9,opt,name=handshake_ms,json=handshakeMs,proto3" json:"handshake_ms,omitempty"`
+	AuthMs                  int64                    `protobuf:"varint,10,opt,name=auth_ms,json=authMs,proto3" json:"auth_ms,omitempty"`
 }
 
 func (m *SSHGatewayTestResponse) Reset()         { *m = SSHGatewayTestResponse{} }
 func (m *SSHGatewayTestResponse) String() string { return proto.CompactTextString(m) }
 func (*SSHGatewayTestResponse) ProtoMessage()    {}
 func (*SSHGatewayTestResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_agentpb_a363b3d4a74a9f5b, []int{8}
+	return fileDescriptor_0233734088c6ede9, []int{10}
 }
 func (m *SSHGatewayTestResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -564,15 +929,15 @@ func (m *SSHGatewayTestResponse) XXX_Marshal(b []byte, deterministic bool) ([]by
 		return xxx_messageInfo_SSHGatewayTestResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
 			return nil, err
 		}
 		return b[:n], nil
 	}
 }
-func (dst *SSHGatewayTestResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SSHGatewayTestResponse.Merge(dst, src)
+func (m *SSHGatewayTestResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SSHGatewayTestResponse.Merge(m, src)
 }
 func (m *SSHGatewayTestResponse) XXX_Size() int {
 	return m.Size()
@@ -597,736 +962,11099 @@ func (m *SSHGatewayTestResponse) GetSshCanConnect() bool {
 	return false
 }
 
-func init() {
-	proto.RegisterType((*SSHGateway)(nil), "agentpb.SSHGateway")
-	proto.RegisterType((*UserDeviceCredentials)(nil), "agentpb.UserDeviceCredentials")
-	proto.RegisterType((*Device)(nil), "agentpb.Device")
-	proto.RegisterType((*ScanRequest)(nil), "agentpb.ScanRequest")
-	proto.RegisterType((*ScanResultsResponse)(nil), "agentpb.ScanResultsResponse")
-	proto.RegisterType((*ScanLogFileResponseWB)(nil), "agentpb.ScanLogFileResponseWB")
-	proto.RegisterType((*ScanLogFileResponsePS)(nil), "agentpb.ScanLogFileResponsePS")
-	proto.RegisterType((*SSHGatewayTestRequest)(nil), "agentpb.SSHGatewayTestRequest")
-	proto.RegisterType((*SSHGatewayTestResponse)(nil), "agentpb.SSHGatewayTestResponse")
-}
-
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// VscanAgentServiceClient is the client API for VscanAgentService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type VscanAgentServiceClient interface {
-	BuildScanConfig(ctx context.Context, in *ScanRequest, opts ...grpc.CallOption) (VscanAgentService_BuildScanConfigClient, error)
-	SSHConnectivityTest(ctx context.Context, in *SSHGatewayTestRequest, opts ...grpc.CallOption) (*SSHGatewayTestResponse, error)
+func (m *SSHGatewayTestResponse) GetSshHostKeyFingerprint() string {
+	if m != nil {
+		return m.SshHostKeyFingerprint
+	}
+	return ""
 }
 
-type vscanAgentServiceClient struct {
-	cc *grpc.ClientConn
+func (m *SSHGatewayTestResponse) GetSshHostKeyMatched() bool {
+	if m != nil {
+		return m.SshHostKeyMatched
+	}
+	return false
 }
 
-func NewVscanAgentServiceClient(cc *grpc.ClientConn) VscanAgentServiceClient {
-	return &vscanAgentServiceClient{cc}
+func (m *SSHGatewayTestResponse) GetSshFailureReason() SSHFailureReason {
+	if m != nil {
+		return m.SshFailureReason
+	}
+	return SSHFailureReason_SSH_FAILURE_NONE
 }
 
-func (c *vscanAgentServiceClient) BuildScanConfig(ctx context.Context, in *ScanRequest, opts ...grpc.CallOption) (VscanAgentService_BuildScanConfigClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_VscanAgentService_serviceDesc.Streams[0], "/agentpb.VscanAgentService/BuildScanConfig", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &vscanAgentServiceBuildScanConfigClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+func (m *SSHGatewayTestResponse) GetSshServerVersion() string {
+	if m != nil {
+		return m.SshServerVersion
 	}
-	return x, nil
+	return ""
 }
 
-type VscanAgentService_BuildScanConfigClient interface {
-	Recv() (*ScanResultsResponse, error)
-	grpc.ClientStream
+func (m *SSHGatewayTestResponse) GetSshNegotiatedAlgorithms() *SSHNegotiatedAlgorithms {
+	if m != nil {
+		return m.SshNegotiatedAlgorithms
+	}
+	return nil
 }
 
-type vscanAgentServiceBuildScanConfigClient struct {
-	grpc.ClientStream
+func (m *SSHGatewayTestResponse) GetTcpConnectMs() int64 {
+	if m != nil {
+		return m.TcpConnectMs
+	}
+	return 0
 }
 
-func (x *vscanAgentServiceBuildScanConfigClient) Recv() (*ScanResultsResponse, error) {
-	m := new(ScanResultsResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *SSHGatewayTestResponse) GetHandshakeMs() int64 {
+	if m != nil {
+		return m.HandshakeMs
 	}
-	return m, nil
+	return 0
 }
 
-func (c *vscanAgentServiceClient) SSHConnectivityTest(ctx context.Context, in *SSHGatewayTestRequest, opts ...grpc.CallOption) (*SSHGatewayTestResponse, error) {
-	out := new(SSHGatewayTestResponse)
-	err := c.cc.Invoke(ctx, "/agentpb.VscanAgentService/SSHConnectivityTest", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *SSHGatewayTestResponse) GetAuthMs() int64 {
+	if m != nil {
+		return m.AuthMs
 	}
-	return out, nil
+	return 0
 }
 
-// VscanAgentServiceServer is the server API for VscanAgentService service.
-type VscanAgentServiceServer interface {
-	BuildScanConfig(*ScanRequest, VscanAgentService_BuildScanConfigServer) error
-	SSHConnectivityTest(context.Context, *SSHGatewayTestRequest) (*SSHGatewayTestResponse, error)
+// DeviceTestRequest represents a request to test SSH connectivity to device(s) through the optional SSH gateway
+// using the device credentials.
+// If check_enable_mode is set, the VSCAN Agent also verifies it can enter privileged mode with the
+// ENABLE privilege method and privilege password of the credentials.
+// timeout_seconds applies to each device test and defaults to 30 seconds.
+type DeviceTestRequest struct {
+	Devices               []*Device              `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+	SshGateway            *SSHGateway            `protobuf:"bytes,2,opt,name=ssh_gateway,json=sshGateway,proto3" json:"ssh_gateway,omitempty"`
+	UserDeviceCredentials *UserDeviceCredentials `protobuf:"bytes,3,opt,name=user_device_credentials,json=userDeviceCredentials,proto3" json:"user_device_credentials,omitempty"`
+	CheckEnableMode       bool                   `protobuf:"varint,4,opt,name=check_enable_mode,json=checkEnableMode,proto3" json:"check_enable_mode,omitempty"`
+	TimeoutSeconds        int64                  `protobuf:"varint,5,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
 }
 
-func RegisterVscanAgentServiceServer(s *grpc.Server, srv VscanAgentServiceServer) {
-	s.RegisterService(&_VscanAgentService_serviceDesc, srv)
+func (m *DeviceTestRequest) Reset()         { *m = DeviceTestRequest{} }
+func (m *DeviceTestRequest) String() string { return proto.CompactTextString(m) }
+func (*DeviceTestRequest) ProtoMessage()    {}
+func (*DeviceTestRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0233734088c6ede9, []int{11}
 }
-
-func _VscanAgentService_BuildScanConfig_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(ScanRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
-	}
-	return srv.(VscanAgentServiceServer).BuildScanConfig(m, &vscanAgentServiceBuildScanConfigServer{stream})
+func (m *DeviceTestRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-type VscanAgentService_BuildScanConfigServer interface {
-	Send(*ScanResultsResponse) error
-	grpc.ServerStream
+func (m *DeviceTestRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DeviceTestRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
 }
-
-type vscanAgentServiceBuildScanConfigServer struct {
-	grpc.ServerStream
+func (m *DeviceTestRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeviceTestRequest.Merge(m, src)
+}
+func (m *DeviceTestRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *DeviceTestRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeviceTestRequest.DiscardUnknown(m)
 }
 
-func (x *vscanAgentServiceBuildScanConfigServer) Send(m *ScanResultsResponse) error {
-	return x.ServerStream.SendMsg(m)
+var xxx_messageInfo_DeviceTestRequest proto.InternalMessageInfo
+
+func (m *DeviceTestRequest) GetDevices() []*Device {
+	if m != nil {
+		return m.Devices
+	}
+	return nil
 }
 
-func _VscanAgentService_SSHConnectivityTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SSHGatewayTestRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *DeviceTestRequest) GetSshGateway() *SSHGateway {
+	if m != nil {
+		return m.SshGateway
 	}
-	if interceptor == nil {
-		return srv.(VscanAgentServiceServer).SSHConnectivityTest(ctx, in)
+	return nil
+}
+
+func (m *DeviceTestRequest) GetUserDeviceCredentials() *UserDeviceCredentials {
+	if m != nil {
+		return m.UserDeviceCredentials
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/agentpb.VscanAgentService/SSHConnectivityTest",
+	return nil
+}
+
+func (m *DeviceTestRequest) GetCheckEnableMode() bool {
+	if m != nil {
+		return m.CheckEnableMode
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VscanAgentServiceServer).SSHConnectivityTest(ctx, req.(*SSHGatewayTestRequest))
+	return false
+}
+
+func (m *DeviceTestRequest) GetTimeoutSeconds() int64 {
+	if m != nil {
+		return m.TimeoutSeconds
 	}
-	return interceptor(ctx, in, info, handler)
+	return 0
 }
 
-var _VscanAgentService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "agentpb.VscanAgentService",
-	HandlerType: (*VscanAgentServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "SSHConnectivityTest",
-			Handler:    _VscanAgentService_SSHConnectivityTest_Handler,
-		},
-	},
-	Streams: []grpc.StreamDesc{
-		{
-			StreamName:    "BuildScanConfig",
-			Handler:       _VscanAgentService_BuildScanConfig_Handler,
-			ServerStreams: true,
-		},
-	},
-	Metadata: "proto/agentpb.proto",
+// DeviceTestResult represents the SSH connectivity test result of a single device
+// latency_ms is the time taken to establish and authenticate the SSH session to the device
+type DeviceTestResult struct {
+	DeviceName        string `protobuf:"bytes,1,opt,name=device_name,json=deviceName,proto3" json:"device_name,omitempty"`
+	IpAddress         string `protobuf:"bytes,2,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	SshCanConnect     bool   `protobuf:"varint,3,opt,name=ssh_can_connect,json=sshCanConnect,proto3" json:"ssh_can_connect,omitempty"`
+	SshTestResult     string `protobuf:"bytes,4,opt,name=ssh_test_result,json=sshTestResult,proto3" json:"ssh_test_result,omitempty"`
+	SshServerBanner   string `protobuf:"bytes,5,opt,name=ssh_server_banner,json=sshServerBanner,proto3" json:"ssh_server_banner,omitempty"`
+	LatencyMs         int64  `protobuf:"varint,6,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+	EnableModeChecked bool   `protobuf:"varint,7,opt,name=enable_mode_checked,json=enableModeChecked,proto3" json:"enable_mode_checked,omitempty"`
+	EnableModeOk      bool   `protobuf:"varint,8,opt,name=enable_mode_ok,json=enableModeOk,proto3" json:"enable_mode_ok,omitempty"`
 }
 
-func (m *SSHGateway) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *DeviceTestResult) Reset()         { *m = DeviceTestResult{} }
+func (m *DeviceTestResult) String() string { return proto.CompactTextString(m) }
+func (*DeviceTestResult) ProtoMessage()    {}
+func (*DeviceTestResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0233734088c6ede9, []int{12}
+}
+func (m *DeviceTestResult) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DeviceTestResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DeviceTestResult.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
+}
+func (m *DeviceTestResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeviceTestResult.Merge(m, src)
+}
+func (m *DeviceTestResult) XXX_Size() int {
+	return m.Size()
+}
+func (m *DeviceTestResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeviceTestResult.DiscardUnknown(m)
 }
 
-func (m *SSHGateway) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.GatewayName) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.GatewayName)))
-		i += copy(dAtA[i:], m.GatewayName)
-	}
-	if len(m.GatewayIp) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.GatewayIp)))
-		i += copy(dAtA[i:], m.GatewayIp)
-	}
-	if len(m.GatewayUsername) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.GatewayUsername)))
-		i += copy(dAtA[i:], m.GatewayUsername)
-	}
-	if len(m.GatewayPassword) > 0 {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.GatewayPassword)))
-		i += copy(dAtA[i:], m.GatewayPassword)
-	}
-	if len(m.GatewayPrivateKey) > 0 {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.GatewayPrivateKey)))
-		i += copy(dAtA[i:], m.GatewayPrivateKey)
+var xxx_messageInfo_DeviceTestResult proto.InternalMessageInfo
+
+func (m *DeviceTestResult) GetDeviceName() string {
+	if m != nil {
+		return m.DeviceName
 	}
-	return i, nil
+	return ""
 }
 
-func (m *UserDeviceCredentials) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *DeviceTestResult) GetIpAddress() string {
+	if m != nil {
+		return m.IpAddress
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *UserDeviceCredentials) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.CredentialsName) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.CredentialsName)))
-		i += copy(dAtA[i:], m.CredentialsName)
-	}
-	if len(m.CredentialsDeviceVendor) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.CredentialsDeviceVendor)))
-		i += copy(dAtA[i:], m.CredentialsDeviceVendor)
-	}
-	if len(m.Username) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.Username)))
-		i += copy(dAtA[i:], m.Username)
-	}
-	if len(m.Password) > 0 {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.Password)))
-		i += copy(dAtA[i:], m.Password)
-	}
-	if len(m.IosEnablePassword) > 0 {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.IosEnablePassword)))
-		i += copy(dAtA[i:], m.IosEnablePassword)
-	}
-	if len(m.PrivateKey) > 0 {
-		dAtA[i] = 0x32
-		i++
-		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.PrivateKey)))
-		i += copy(dAtA[i:], m.PrivateKey)
+func (m *DeviceTestResult) GetSshCanConnect() bool {
+	if m != nil {
+		return m.SshCanConnect
 	}
-	return i, nil
+	return false
 }
 
-func (m *Device) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *DeviceTestResult) GetSshTestResult() string {
+	if m != nil {
+		return m.SshTestResult
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *Device) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.DeviceName) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.DeviceName)))
-		i += copy(dAtA[i:], m.DeviceName)
-	}
-	if len(m.IpAddress) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.IpAddress)))
-		i += copy(dAtA[i:], m.IpAddress)
+func (m *DeviceTestResult) GetSshServerBanner() string {
+	if m != nil {
+		return m.SshServerBanner
 	}
-	return i, nil
+	return ""
 }
 
-func (m *ScanRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *DeviceTestResult) GetLatencyMs() int64 {
+	if m != nil {
+		return m.LatencyMs
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *ScanRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.JobId) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.JobId)))
-		i += copy(dAtA[i:], m.JobId)
-	}
-	if len(m.Devices) > 0 {
-		for _, msg := range m.Devices {
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintAgentpb(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+func (m *DeviceTestResult) GetEnableModeChecked() bool {
+	if m != nil {
+		return m.EnableModeChecked
 	}
-	if m.SshGateway != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintAgentpb(dAtA, i, uint64(m.SshGateway.Size()))
-		n1, err := m.SshGateway.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n1
+	return false
+}
+
+func (m *DeviceTestResult) GetEnableModeOk() bool {
+	if m != nil {
+		return m.EnableModeOk
 	}
-	if m.UserDeviceCredentials != nil {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintAgentpb(dAtA, i, uint64(m.UserDeviceCredentials.Size()))
-		n2, err := m.UserDeviceCredentials.MarshalTo(dAtA[i:])
+	return false
+}
+
+// DeviceTestResponse represents the SSH connectivity test results of the requested device(s)
+type DeviceTestResponse struct {
+	DeviceTestResults []*DeviceTestResult `protobuf:"bytes,1,rep,name=device_test_results,json=deviceTestResults,proto3" json:"device_test_results,omitempty"`
+}
+
+func (m *DeviceTestResponse) Reset()         { *m = DeviceTestResponse{} }
+func (m *DeviceTestResponse) String() string { return proto.CompactTextString(m) }
+func (*DeviceTestResponse) ProtoMessage()    {}
+func (*DeviceTestResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0233734088c6ede9, []int{13}
+}
+func (m *DeviceTestResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DeviceTestResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DeviceTestResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i += n2
-	}
-	if len(m.OvalSourceUrl) > 0 {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.OvalSourceUrl)))
-		i += copy(dAtA[i:], m.OvalSourceUrl)
-	}
-	if m.ScanTimeoutSeconds != 0 {
-		dAtA[i] = 0x30
-		i++
-		i = encodeVarintAgentpb(dAtA, i, uint64(m.ScanTimeoutSeconds))
+		return b[:n], nil
 	}
-	return i, nil
+}
+func (m *DeviceTestResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeviceTestResponse.Merge(m, src)
+}
+func (m *DeviceTestResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *DeviceTestResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeviceTestResponse.DiscardUnknown(m)
 }
 
-func (m *ScanResultsResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_DeviceTestResponse proto.InternalMessageInfo
+
+func (m *DeviceTestResponse) GetDeviceTestResults() []*DeviceTestResult {
+	if m != nil {
+		return m.DeviceTestResults
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *ScanResultsResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.ScanResultsJson) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.ScanResultsJson)))
-		i += copy(dAtA[i:], m.ScanResultsJson)
-	}
-	if len(m.VscanAgentName) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.VscanAgentName)))
-		i += copy(dAtA[i:], m.VscanAgentName)
-	}
-	if len(m.DeviceName) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.DeviceName)))
-		i += copy(dAtA[i:], m.DeviceName)
-	}
-	if m.ScanLogsWebsocket != nil {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintAgentpb(dAtA, i, uint64(m.ScanLogsWebsocket.Size()))
-		n3, err := m.ScanLogsWebsocket.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n3
-	}
-	if m.ScanLogsPersist != nil {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintAgentpb(dAtA, i, uint64(m.ScanLogsPersist.Size()))
-		n4, err := m.ScanLogsPersist.MarshalTo(dAtA[i:])
+// DiscoveryRequest represents a request to fingerprint the vendor, platform and OS version of device(s)
+// The device(s) are reached through the SSH gateway when one is specified.
+// If the credentials device vendor is not specified, the VSCAN Agent probes the device with the commands of each
+// supported vendor.
+// timeout_seconds applies to each device and defaults to 30 seconds.
+type DiscoveryRequest struct {
+	Devices               []*Device              `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+	SshGateway            *SSHGateway            `protobuf:"bytes,2,opt,name=ssh_gateway,json=sshGateway,proto3" json:"ssh_gateway,omitempty"`
+	UserDeviceCredentials *UserDeviceCredentials `protobuf:"bytes,3,opt,name=user_device_credentials,json=userDeviceCredentials,proto3" json:"user_device_credentials,omitempty"`
+	TimeoutSeconds        int64                  `protobuf:"varint,4,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+}
+
+func (m *DiscoveryRequest) Reset()         { *m = DiscoveryRequest{} }
+func (m *DiscoveryRequest) String() string { return proto.CompactTextString(m) }
+func (*DiscoveryRequest) ProtoMessage()    {}
+func (*DiscoveryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0233734088c6ede9, []int{14}
+}
+func (m *DiscoveryRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DiscoveryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DiscoveryRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i += n4
+		return b[:n], nil
 	}
-	return i, nil
+}
+func (m *DiscoveryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiscoveryRequest.Merge(m, src)
+}
+func (m *DiscoveryRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *DiscoveryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiscoveryRequest.DiscardUnknown(m)
 }
 
-func (m *ScanLogFileResponseWB) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_DiscoveryRequest proto.InternalMessageInfo
+
+func (m *DiscoveryRequest) GetDevices() []*Device {
+	if m != nil {
+		return m.Devices
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *ScanLogFileResponseWB) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.ScanLogs) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.ScanLogs)))
-		i += copy(dAtA[i:], m.ScanLogs)
+func (m *DiscoveryRequest) GetSshGateway() *SSHGateway {
+	if m != nil {
+		return m.SshGateway
 	}
-	return i, nil
+	return nil
 }
 
-func (m *ScanLogFileResponsePS) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *DiscoveryRequest) GetUserDeviceCredentials() *UserDeviceCredentials {
+	if m != nil {
+		return m.UserDeviceCredentials
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *ScanLogFileResponsePS) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.ScanLogs) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.ScanLogs)))
-		i += copy(dAtA[i:], m.ScanLogs)
+func (m *DiscoveryRequest) GetTimeoutSeconds() int64 {
+	if m != nil {
+		return m.TimeoutSeconds
 	}
-	return i, nil
+	return 0
 }
 
-func (m *SSHGatewayTestRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+// DeviceInventory represents the inventory record of a single discovered device
+// discovery_error is set when the device could not be reached or fingerprinted
+type DeviceInventory struct {
+	DeviceName     string       `protobuf:"bytes,1,opt,name=device_name,json=deviceName,proto3" json:"device_name,omitempty"`
+	IpAddress      string       `protobuf:"bytes,2,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	Discovered     bool         `protobuf:"varint,3,opt,name=discovered,proto3" json:"discovered,omitempty"`
+	DiscoveryError string       `protobuf:"bytes,4,opt,name=discovery_error,json=discoveryError,proto3" json:"discovery_error,omitempty"`
+	Vendor         DeviceVendor `protobuf:"varint,5,opt,name=vendor,proto3,enum=agentpb.DeviceVendor" json:"vendor,omitempty"`
+	Platform       string       `protobuf:"bytes,6,opt,name=platform,proto3" json:"platform,omitempty"`
+	OsFamily       string       `protobuf:"bytes,7,opt,name=os_family,json=osFamily,proto3" json:"os_family,omitempty"`
+	OsVersion      string       `protobuf:"bytes,8,opt,name=os_version,json=osVersion,proto3" json:"os_version,omitempty"`
+	Hostname       string       `protobuf:"bytes,9,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	SerialNumber   string       `protobuf:"bytes,10,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
 }
 
-func (m *SSHGatewayTestRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.SshGateway != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintAgentpb(dAtA, i, uint64(m.SshGateway.Size()))
-		n5, err := m.SshGateway.MarshalTo(dAtA[i:])
+func (m *DeviceInventory) Reset()         { *m = DeviceInventory{} }
+func (m *DeviceInventory) String() string { return proto.CompactTextString(m) }
+func (*DeviceInventory) ProtoMessage()    {}
+func (*DeviceInventory) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0233734088c6ede9, []int{15}
+}
+func (m *DeviceInventory) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DeviceInventory) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DeviceInventory.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i += n5
+		return b[:n], nil
 	}
-	return i, nil
+}
+func (m *DeviceInventory) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeviceInventory.Merge(m, src)
+}
+func (m *DeviceInventory) XXX_Size() int {
+	return m.Size()
+}
+func (m *DeviceInventory) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeviceInventory.DiscardUnknown(m)
 }
 
-func (m *SSHGatewayTestResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_DeviceInventory proto.InternalMessageInfo
+
+func (m *DeviceInventory) GetDeviceName() string {
+	if m != nil {
+		return m.DeviceName
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *SSHGatewayTestResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.SshTestResult) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.SshTestResult)))
-		i += copy(dAtA[i:], m.SshTestResult)
-	}
-	if m.SshCanConnect {
-		dAtA[i] = 0x10
-		i++
-		if m.SshCanConnect {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i++
+func (m *DeviceInventory) GetIpAddress() string {
+	if m != nil {
+		return m.IpAddress
 	}
-	return i, nil
+	return ""
 }
 
-func encodeVarintAgentpb(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *DeviceInventory) GetDiscovered() bool {
+	if m != nil {
+		return m.Discovered
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
+	return false
 }
-func (m *SSHGateway) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.GatewayName)
-	if l > 0 {
-		n += 1 + l + sovAgentpb(uint64(l))
-	}
-	l = len(m.GatewayIp)
-	if l > 0 {
-		n += 1 + l + sovAgentpb(uint64(l))
-	}
-	l = len(m.GatewayUsername)
-	if l > 0 {
-		n += 1 + l + sovAgentpb(uint64(l))
-	}
-	l = len(m.GatewayPassword)
-	if l > 0 {
-		n += 1 + l + sovAgentpb(uint64(l))
-	}
-	l = len(m.GatewayPrivateKey)
-	if l > 0 {
-		n += 1 + l + sovAgentpb(uint64(l))
+
+func (m *DeviceInventory) GetDiscoveryError() string {
+	if m != nil {
+		return m.DiscoveryError
 	}
-	return n
+	return ""
 }
 
-func (m *UserDeviceCredentials) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.CredentialsName)
-	if l > 0 {
-		n += 1 + l + sovAgentpb(uint64(l))
+func (m *DeviceInventory) GetVendor() DeviceVendor {
+	if m != nil {
+		return m.Vendor
 	}
-	l = len(m.CredentialsDeviceVendor)
-	if l > 0 {
-		n += 1 + l + sovAgentpb(uint64(l))
+	return DeviceVendor_VENDOR_UNSPECIFIED
+}
+
+func (m *DeviceInventory) GetPlatform() string {
+	if m != nil {
+		return m.Platform
 	}
-	l = len(m.Username)
-	if l > 0 {
-		n += 1 + l + sovAgentpb(uint64(l))
+	return ""
+}
+
+func (m *DeviceInventory) GetOsFamily() string {
+	if m != nil {
+		return m.OsFamily
 	}
-	l = len(m.Password)
-	if l > 0 {
-		n += 1 + l + sovAgentpb(uint64(l))
+	return ""
+}
+
+func (m *DeviceInventory) GetOsVersion() string {
+	if m != nil {
+		return m.OsVersion
 	}
-	l = len(m.IosEnablePassword)
-	if l > 0 {
-		n += 1 + l + sovAgentpb(uint64(l))
+	return ""
+}
+
+func (m *DeviceInventory) GetHostname() string {
+	if m != nil {
+		return m.Hostname
 	}
-	l = len(m.PrivateKey)
-	if l > 0 {
-		n += 1 + l + sovAgentpb(uint64(l))
+	return ""
+}
+
+func (m *DeviceInventory) GetSerialNumber() string {
+	if m != nil {
+		return m.SerialNumber
 	}
-	return n
+	return ""
 }
 
-func (m *Device) Size() (n int) {
-	if m == nil {
-		return 0
+// DiscoveryResponse represents the inventory records of the requested device(s)
+type DiscoveryResponse struct {
+	DeviceInventories []*DeviceInventory `protobuf:"bytes,1,rep,name=device_inventories,json=deviceInventories,proto3" json:"device_inventories,omitempty"`
+}
+
+func (m *DiscoveryResponse) Reset()         { *m = DiscoveryResponse{} }
+func (m *DiscoveryResponse) String() string { return proto.CompactTextString(m) }
+func (*DiscoveryResponse) ProtoMessage()    {}
+func (*DiscoveryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0233734088c6ede9, []int{16}
+}
+func (m *DiscoveryResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DiscoveryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DiscoveryResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	var l int
-	_ = l
-	l = len(m.DeviceName)
-	if l > 0 {
-		n += 1 + l + sovAgentpb(uint64(l))
+}
+func (m *DiscoveryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiscoveryResponse.Merge(m, src)
+}
+func (m *DiscoveryResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *DiscoveryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiscoveryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DiscoveryResponse proto.InternalMessageInfo
+
+func (m *DiscoveryResponse) GetDeviceInventories() []*DeviceInventory {
+	if m != nil {
+		return m.DeviceInventories
 	}
-	l = len(m.IpAddress)
-	if l > 0 {
-		n += 1 + l + sovAgentpb(uint64(l))
+	return nil
+}
+
+// BulkSSHTestRequest represents a request to test SSH connectivity to many openSSH hosts at once
+// concurrency is the maximum number of targets tested in parallel. It defaults to 10 and cannot exceed 100.
+// timeout_seconds applies to each target and defaults to 30 seconds.
+type BulkSSHTestRequest struct {
+	SshTargets     []*SSHGateway `protobuf:"bytes,1,rep,name=ssh_targets,json=sshTargets,proto3" json:"ssh_targets,omitempty"`
+	Concurrency    int32         `protobuf:"varint,2,opt,name=concurrency,proto3" json:"concurrency,omitempty"`
+	TimeoutSeconds int64         `protobuf:"varint,3,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+}
+
+func (m *BulkSSHTestRequest) Reset()         { *m = BulkSSHTestRequest{} }
+func (m *BulkSSHTestRequest) String() string { return proto.CompactTextString(m) }
+func (*BulkSSHTestRequest) ProtoMessage()    {}
+func (*BulkSSHTestRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0233734088c6ede9, []int{17}
+}
+func (m *BulkSSHTestRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BulkSSHTestRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BulkSSHTestRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return n
+}
+func (m *BulkSSHTestRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BulkSSHTestRequest.Merge(m, src)
+}
+func (m *BulkSSHTestRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *BulkSSHTestRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BulkSSHTestRequest.DiscardUnknown(m)
 }
 
-func (m *ScanRequest) Size() (n int) {
-	if m == nil {
-		return 0
+var xxx_messageInfo_BulkSSHTestRequest proto.InternalMessageInfo
+
+func (m *BulkSSHTestRequest) GetSshTargets() []*SSHGateway {
+	if m != nil {
+		return m.SshTargets
 	}
-	var l int
-	_ = l
-	l = len(m.JobId)
-	if l > 0 {
-		n += 1 + l + sovAgentpb(uint64(l))
+	return nil
+}
+
+func (m *BulkSSHTestRequest) GetConcurrency() int32 {
+	if m != nil {
+		return m.Concurrency
 	}
-	if len(m.Devices) > 0 {
-		for _, e := range m.Devices {
-			l = e.Size()
-			n += 1 + l + sovAgentpb(uint64(l))
+	return 0
+}
+
+func (m *BulkSSHTestRequest) GetTimeoutSeconds() int64 {
+	if m != nil {
+		return m.TimeoutSeconds
+	}
+	return 0
+}
+
+// BulkSSHTestResult represents the SSH connectivity test result of a single target
+// target_index is the position of the target in the BulkSSHTestRequest ssh_targets
+type BulkSSHTestResult struct {
+	TargetIndex     int32                   `protobuf:"varint,1,opt,name=target_index,json=targetIndex,proto3" json:"target_index,omitempty"`
+	GatewayName     string                  `protobuf:"bytes,2,opt,name=gateway_name,json=gatewayName,proto3" json:"gateway_name,omitempty"`
+	GatewayIp       string                  `protobuf:"bytes,3,opt,name=gateway_ip,json=gatewayIp,proto3" json:"gateway_ip,omitempty"`
+	SshTestResponse *SSHGatewayTestResponse `protobuf:"bytes,4,opt,name=ssh_test_response,json=sshTestResponse,proto3" json:"ssh_test_response,omitempty"`
+	FailureReason   SSHFailureReason        `protobuf:"varint,5,opt,name=failure_reason,json=failureReason,proto3,enum=agentpb.SSHFailureReason" json:"failure_reason,omitempty"`
+	DurationMs      int64                   `protobuf:"varint,6,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+}
+
+func (m *BulkSSHTestResult) Reset()         { *m = BulkSSHTestResult{} }
+func (m *BulkSSHTestResult) String() string { return proto.CompactTextString(m) }
+func (*BulkSSHTestResult) ProtoMessage()    {}
+func (*BulkSSHTestResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0233734088c6ede9, []int{18}
+}
+func (m *BulkSSHTestResult) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BulkSSHTestResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BulkSSHTestResult.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.SshGateway != nil {
-		l = m.SshGateway.Size()
-		n += 1 + l + sovAgentpb(uint64(l))
+}
+func (m *BulkSSHTestResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BulkSSHTestResult.Merge(m, src)
+}
+func (m *BulkSSHTestResult) XXX_Size() int {
+	return m.Size()
+}
+func (m *BulkSSHTestResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_BulkSSHTestResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BulkSSHTestResult proto.InternalMessageInfo
+
+func (m *BulkSSHTestResult) GetTargetIndex() int32 {
+	if m != nil {
+		return m.TargetIndex
 	}
-	if m.UserDeviceCredentials != nil {
-		l = m.UserDeviceCredentials.Size()
-		n += 1 + l + sovAgentpb(uint64(l))
+	return 0
+}
+
+func (m *BulkSSHTestResult) GetGatewayName() string {
+	if m != nil {
+		return m.GatewayName
 	}
-	l = len(m.OvalSourceUrl)
-	if l > 0 {
-		n += 1 + l + sovAgentpb(uint64(l))
+	return ""
+}
+
+func (m *BulkSSHTestResult) GetGatewayIp() string {
+	if m != nil {
+		return m.GatewayIp
 	}
-	if m.ScanTimeoutSeconds != 0 {
-		n += 1 + sovAgentpb(uint64(m.ScanTimeoutSeconds))
+	return ""
+}
+
+func (m *BulkSSHTestResult) GetSshTestResponse() *SSHGatewayTestResponse {
+	if m != nil {
+		return m.SshTestResponse
 	}
-	return n
+	return nil
 }
 
-func (m *ScanResultsResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *BulkSSHTestResult) GetFailureReason() SSHFailureReason {
+	if m != nil {
+		return m.FailureReason
 	}
-	var l int
-	_ = l
-	l = len(m.ScanResultsJson)
-	if l > 0 {
-		n += 1 + l + sovAgentpb(uint64(l))
+	return SSHFailureReason_SSH_FAILURE_NONE
+}
+
+func (m *BulkSSHTestResult) GetDurationMs() int64 {
+	if m != nil {
+		return m.DurationMs
 	}
-	l = len(m.VscanAgentName)
-	if l > 0 {
-		n += 1 + l + sovAgentpb(uint64(l))
+	return 0
+}
+
+// BulkSSHTestSummary represents the counts of SSH connectivity test results by failure class
+type BulkSSHTestSummary struct {
+	Total                  int32 `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Succeeded              int32 `protobuf:"varint,2,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	Failed                 int32 `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	DnsFailures            int32 `protobuf:"varint,4,opt,name=dns_failures,json=dnsFailures,proto3" json:"dns_failures,omitempty"`
+	ConnectionRefused      int32 `protobuf:"varint,5,opt,name=connection_refused,json=connectionRefused,proto3" json:"connection_refused,omitempty"`
+	Timeouts               int32 `protobuf:"varint,6,opt,name=timeouts,proto3" json:"timeouts,omitempty"`
+	AuthRejected           int32 `protobuf:"varint,7,opt,name=auth_rejected,json=authRejected,proto3" json:"auth_rejected,omitempty"`
+	HostKeyMismatches      int32 `protobuf:"varint,8,opt,name=host_key_mismatches,json=hostKeyMismatches,proto3" json:"host_key_mismatches,omitempty"`
+	OtherFailures          int32 `protobuf:"varint,9,opt,name=other_failures,json=otherFailures,proto3" json:"other_failures,omitempty"`
+	DurationMs             int64 `protobuf:"varint,10,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	HandshakeFailures      int32 `protobuf:"varint,11,opt,name=handshake_failures,json=handshakeFailures,proto3" json:"handshake_failures,omitempty"`
+	NoSupportedAuthMethods int32 `protobuf:"varint,12,opt,name=no_supported_auth_methods,json=noSupportedAuthMethods,proto3" json:"no_supported_auth_methods,omitempty"`
+}
+
+func (m *BulkSSHTestSummary) Reset()         { *m = BulkSSHTestSummary{} }
+func (m *BulkSSHTestSummary) String() string { return proto.CompactTextString(m) }
+func (*BulkSSHTestSummary) ProtoMessage()    {}
+func (*BulkSSHTestSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0233734088c6ede9, []int{19}
+}
+func (m *BulkSSHTestSummary) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BulkSSHTestSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BulkSSHTestSummary.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	l = len(m.DeviceName)
-	if l > 0 {
-		n += 1 + l + sovAgentpb(uint64(l))
+}
+func (m *BulkSSHTestSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BulkSSHTestSummary.Merge(m, src)
+}
+func (m *BulkSSHTestSummary) XXX_Size() int {
+	return m.Size()
+}
+func (m *BulkSSHTestSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_BulkSSHTestSummary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BulkSSHTestSummary proto.InternalMessageInfo
+
+func (m *BulkSSHTestSummary) GetTotal() int32 {
+	if m != nil {
+		return m.Total
 	}
-	if m.ScanLogsWebsocket != nil {
-		l = m.ScanLogsWebsocket.Size()
-		n += 1 + l + sovAgentpb(uint64(l))
+	return 0
+}
+
+func (m *BulkSSHTestSummary) GetSucceeded() int32 {
+	if m != nil {
+		return m.Succeeded
 	}
-	if m.ScanLogsPersist != nil {
-		l = m.ScanLogsPersist.Size()
-		n += 1 + l + sovAgentpb(uint64(l))
+	return 0
+}
+
+func (m *BulkSSHTestSummary) GetFailed() int32 {
+	if m != nil {
+		return m.Failed
 	}
-	return n
+	return 0
 }
 
-func (m *ScanLogFileResponseWB) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *BulkSSHTestSummary) GetDnsFailures() int32 {
+	if m != nil {
+		return m.DnsFailures
 	}
-	var l int
-	_ = l
-	l = len(m.ScanLogs)
-	if l > 0 {
-		n += 1 + l + sovAgentpb(uint64(l))
+	return 0
+}
+
+func (m *BulkSSHTestSummary) GetConnectionRefused() int32 {
+	if m != nil {
+		return m.ConnectionRefused
 	}
-	return n
+	return 0
 }
 
-func (m *ScanLogFileResponsePS) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *BulkSSHTestSummary) GetTimeouts() int32 {
+	if m != nil {
+		return m.Timeouts
 	}
-	var l int
-	_ = l
-	l = len(m.ScanLogs)
-	if l > 0 {
-		n += 1 + l + sovAgentpb(uint64(l))
+	return 0
+}
+
+func (m *BulkSSHTestSummary) GetAuthRejected() int32 {
+	if m != nil {
+		return m.AuthRejected
 	}
-	return n
+	return 0
 }
 
-func (m *SSHGatewayTestRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *BulkSSHTestSummary) GetHostKeyMismatches() int32 {
+	if m != nil {
+		return m.HostKeyMismatches
 	}
-	var l int
-	_ = l
-	if m.SshGateway != nil {
-		l = m.SshGateway.Size()
-		n += 1 + l + sovAgentpb(uint64(l))
+	return 0
+}
+
+func (m *BulkSSHTestSummary) GetOtherFailures() int32 {
+	if m != nil {
+		return m.OtherFailures
 	}
-	return n
+	return 0
 }
 
-func (m *SSHGatewayTestResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *BulkSSHTestSummary) GetDurationMs() int64 {
+	if m != nil {
+		return m.DurationMs
 	}
-	var l int
-	_ = l
-	l = len(m.SshTestResult)
-	if l > 0 {
-		n += 1 + l + sovAgentpb(uint64(l))
+	return 0
+}
+
+func (m *BulkSSHTestSummary) GetHandshakeFailures() int32 {
+	if m != nil {
+		return m.HandshakeFailures
 	}
-	if m.SshCanConnect {
-		n += 2
+	return 0
+}
+
+func (m *BulkSSHTestSummary) GetNoSupportedAuthMethods() int32 {
+	if m != nil {
+		return m.NoSupportedAuthMethods
+	}
+	return 0
+}
+
+// BulkSSHTestResponse represents a stream of per target results as they complete, followed by a single summary
+type BulkSSHTestResponse struct {
+	// Types that are valid to be assigned to Result:
+	//	*BulkSSHTestResponse_TargetResult
+	//	*BulkSSHTestResponse_Summary
+	Result isBulkSSHTestResponse_Result `protobuf_oneof:"result"`
+}
+
+func (m *BulkSSHTestResponse) Reset()         { *m = BulkSSHTestResponse{} }
+func (m *BulkSSHTestResponse) String() string { return proto.CompactTextString(m) }
+func (*BulkSSHTestResponse) ProtoMessage()    {}
+func (*BulkSSHTestResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0233734088c6ede9, []int{20}
+}
+func (m *BulkSSHTestResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BulkSSHTestResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BulkSSHTestResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BulkSSHTestResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BulkSSHTestResponse.Merge(m, src)
+}
+func (m *BulkSSHTestResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *BulkSSHTestResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BulkSSHTestResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BulkSSHTestResponse proto.InternalMessageInfo
+
+type isBulkSSHTestResponse_Result interface {
+	isBulkSSHTestResponse_Result()
+	MarshalTo([]byte) (int, error)
+	Size() int
+}
+
+type BulkSSHTestResponse_TargetResult struct {
+	TargetResult *BulkSSHTestResult `protobuf:"bytes,1,opt,name=target_result,json=targetResult,proto3,oneof" json:"target_result,omitempty"`
+}
+type BulkSSHTestResponse_Summary struct {
+	Summary *BulkSSHTestSummary `protobuf:"bytes,2,opt,name=summary,proto3,oneof" json:"summary,omitempty"`
+}
+
+func (*BulkSSHTestResponse_TargetResult) isBulkSSHTestResponse_Result() {}
+func (*BulkSSHTestResponse_Summary) isBulkSSHTestResponse_Result()      {}
+
+func (m *BulkSSHTestResponse) GetResult() isBulkSSHTestResponse_Result {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *BulkSSHTestResponse) GetTargetResult() *BulkSSHTestResult {
+	if x, ok := m.GetResult().(*BulkSSHTestResponse_TargetResult); ok {
+		return x.TargetResult
+	}
+	return nil
+}
+
+func (m *BulkSSHTestResponse) GetSummary() *BulkSSHTestSummary {
+	if x, ok := m.GetResult().(*BulkSSHTestResponse_Summary); ok {
+		return x.Summary
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*BulkSSHTestResponse) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*BulkSSHTestResponse_TargetResult)(nil),
+		(*BulkSSHTestResponse_Summary)(nil),
+	}
+}
+
+// AgentInfoRequest represents a request for the VSCAN Agent build, platform and capabilities details
+type AgentInfoRequest struct {
+}
+
+func (m *AgentInfoRequest) Reset()         { *m = AgentInfoRequest{} }
+func (m *AgentInfoRequest) String() string { return proto.CompactTextString(m) }
+func (*AgentInfoRequest) ProtoMessage()    {}
+func (*AgentInfoRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0233734088c6ede9, []int{21}
+}
+func (m *AgentInfoRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AgentInfoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AgentInfoRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AgentInfoRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AgentInfoRequest.Merge(m, src)
+}
+func (m *AgentInfoRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *AgentInfoRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AgentInfoRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AgentInfoRequest proto.InternalMessageInfo
+
+// AgentBuildInfo represents the VSCAN Agent release details
+type AgentBuildInfo struct {
+	Version   string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Commit    string `protobuf:"bytes,2,opt,name=commit,proto3" json:"commit,omitempty"`
+	BuiltAt   string `protobuf:"bytes,3,opt,name=built_at,json=builtAt,proto3" json:"built_at,omitempty"`
+	BuiltOn   string `protobuf:"bytes,4,opt,name=built_on,json=builtOn,proto3" json:"built_on,omitempty"`
+	GoVersion string `protobuf:"bytes,5,opt,name=go_version,json=goVersion,proto3" json:"go_version,omitempty"`
+}
+
+func (m *AgentBuildInfo) Reset()         { *m = AgentBuildInfo{} }
+func (m *AgentBuildInfo) String() string { return proto.CompactTextString(m) }
+func (*AgentBuildInfo) ProtoMessage()    {}
+func (*AgentBuildInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0233734088c6ede9, []int{22}
+}
+func (m *AgentBuildInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AgentBuildInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AgentBuildInfo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AgentBuildInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AgentBuildInfo.Merge(m, src)
+}
+func (m *AgentBuildInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *AgentBuildInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_AgentBuildInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AgentBuildInfo proto.InternalMessageInfo
+
+func (m *AgentBuildInfo) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *AgentBuildInfo) GetCommit() string {
+	if m != nil {
+		return m.Commit
+	}
+	return ""
+}
+
+func (m *AgentBuildInfo) GetBuiltAt() string {
+	if m != nil {
+		return m.BuiltAt
+	}
+	return ""
+}
+
+func (m *AgentBuildInfo) GetBuiltOn() string {
+	if m != nil {
+		return m.BuiltOn
+	}
+	return ""
+}
+
+func (m *AgentBuildInfo) GetGoVersion() string {
+	if m != nil {
+		return m.GoVersion
+	}
+	return ""
+}
+
+// AgentPlatformInfo represents the VSCAN Agent host, operating system and hardware details
+type AgentPlatformInfo struct {
+	Hostname        string `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Os              string `protobuf:"bytes,2,opt,name=os,proto3" json:"os,omitempty"`
+	Platform        string `protobuf:"bytes,3,opt,name=platform,proto3" json:"platform,omitempty"`
+	PlatformVersion string `protobuf:"bytes,4,opt,name=platform_version,json=platformVersion,proto3" json:"platform_version,omitempty"`
+	KernelVersion   string `protobuf:"bytes,5,opt,name=kernel_version,json=kernelVersion,proto3" json:"kernel_version,omitempty"`
+	Architecture    string `protobuf:"bytes,6,opt,name=architecture,proto3" json:"architecture,omitempty"`
+	CpuModel        string `protobuf:"bytes,7,opt,name=cpu_model,json=cpuModel,proto3" json:"cpu_model,omitempty"`
+	CpuCount        int32  `protobuf:"varint,8,opt,name=cpu_count,json=cpuCount,proto3" json:"cpu_count,omitempty"`
+}
+
+func (m *AgentPlatformInfo) Reset()         { *m = AgentPlatformInfo{} }
+func (m *AgentPlatformInfo) String() string { return proto.CompactTextString(m) }
+func (*AgentPlatformInfo) ProtoMessage()    {}
+func (*AgentPlatformInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0233734088c6ede9, []int{23}
+}
+func (m *AgentPlatformInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AgentPlatformInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AgentPlatformInfo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AgentPlatformInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AgentPlatformInfo.Merge(m, src)
+}
+func (m *AgentPlatformInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *AgentPlatformInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_AgentPlatformInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AgentPlatformInfo proto.InternalMessageInfo
+
+func (m *AgentPlatformInfo) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+func (m *AgentPlatformInfo) GetOs() string {
+	if m != nil {
+		return m.Os
+	}
+	return ""
+}
+
+func (m *AgentPlatformInfo) GetPlatform() string {
+	if m != nil {
+		return m.Platform
+	}
+	return ""
+}
+
+func (m *AgentPlatformInfo) GetPlatformVersion() string {
+	if m != nil {
+		return m.PlatformVersion
+	}
+	return ""
+}
+
+func (m *AgentPlatformInfo) GetKernelVersion() string {
+	if m != nil {
+		return m.KernelVersion
+	}
+	return ""
+}
+
+func (m *AgentPlatformInfo) GetArchitecture() string {
+	if m != nil {
+		return m.Architecture
+	}
+	return ""
+}
+
+func (m *AgentPlatformInfo) GetCpuModel() string {
+	if m != nil {
+		return m.CpuModel
+	}
+	return ""
+}
+
+func (m *AgentPlatformInfo) GetCpuCount() int32 {
+	if m != nil {
+		return m.CpuCount
+	}
+	return 0
+}
+
+// AgentResourceUsage represents the live VSCAN Agent host resource usage
+// disk usage is reported for the partition holding the scan jobs directory
+type AgentResourceUsage struct {
+	MemoryTotalBytes     uint64  `protobuf:"varint,1,opt,name=memory_total_bytes,json=memoryTotalBytes,proto3" json:"memory_total_bytes,omitempty"`
+	MemoryAvailableBytes uint64  `protobuf:"varint,2,opt,name=memory_available_bytes,json=memoryAvailableBytes,proto3" json:"memory_available_bytes,omitempty"`
+	MemoryUsedPercent    float64 `protobuf:"fixed64,3,opt,name=memory_used_percent,json=memoryUsedPercent,proto3" json:"memory_used_percent,omitempty"`
+	DiskTotalBytes       uint64  `protobuf:"varint,4,opt,name=disk_total_bytes,json=diskTotalBytes,proto3" json:"disk_total_bytes,omitempty"`
+	DiskFreeBytes        uint64  `protobuf:"varint,5,opt,name=disk_free_bytes,json=diskFreeBytes,proto3" json:"disk_free_bytes,omitempty"`
+	DiskUsedPercent      float64 `protobuf:"fixed64,6,opt,name=disk_used_percent,json=diskUsedPercent,proto3" json:"disk_used_percent,omitempty"`
+	LoadAverage_1        float64 `protobuf:"fixed64,7,opt,name=load_average_1,json=loadAverage1,proto3" json:"load_average_1,omitempty"`
+	LoadAverage_5        float64 `protobuf:"fixed64,8,opt,name=load_average_5,json=loadAverage5,proto3" json:"load_average_5,omitempty"`
+	LoadAverage_15       float64 `protobuf:"fixed64,9,opt,name=load_average_15,json=loadAverage15,proto3" json:"load_average_15,omitempty"`
+}
+
+func (m *AgentResourceUsage) Reset()         { *m = AgentResourceUsage{} }
+func (m *AgentResourceUsage) String() string { return proto.CompactTextString(m) }
+func (*AgentResourceUsage) ProtoMessage()    {}
+func (*AgentResourceUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0233734088c6ede9, []int{24}
+}
+func (m *AgentResourceUsage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AgentResourceUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AgentResourceUsage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AgentResourceUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AgentResourceUsage.Merge(m, src)
+}
+func (m *AgentResourceUsage) XXX_Size() int {
+	return m.Size()
+}
+func (m *AgentResourceUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_AgentResourceUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AgentResourceUsage proto.InternalMessageInfo
+
+func (m *AgentResourceUsage) GetMemoryTotalBytes() uint64 {
+	if m != nil {
+		return m.MemoryTotalBytes
+	}
+	return 0
+}
+
+func (m *AgentResourceUsage) GetMemoryAvailableBytes() uint64 {
+	if m != nil {
+		return m.MemoryAvailableBytes
+	}
+	return 0
+}
+
+func (m *AgentResourceUsage) GetMemoryUsedPercent() float64 {
+	if m != nil {
+		return m.MemoryUsedPercent
+	}
+	return 0
+}
+
+func (m *AgentResourceUsage) GetDiskTotalBytes() uint64 {
+	if m != nil {
+		return m.DiskTotalBytes
+	}
+	return 0
+}
+
+func (m *AgentResourceUsage) GetDiskFreeBytes() uint64 {
+	if m != nil {
+		return m.DiskFreeBytes
+	}
+	return 0
+}
+
+func (m *AgentResourceUsage) GetDiskUsedPercent() float64 {
+	if m != nil {
+		return m.DiskUsedPercent
+	}
+	return 0
+}
+
+func (m *AgentResourceUsage) GetLoadAverage_1() float64 {
+	if m != nil {
+		return m.LoadAverage_1
+	}
+	return 0
+}
+
+func (m *AgentResourceUsage) GetLoadAverage_5() float64 {
+	if m != nil {
+		return m.LoadAverage_5
+	}
+	return 0
+}
+
+func (m *AgentResourceUsage) GetLoadAverage_15() float64 {
+	if m != nil {
+		return m.LoadAverage_15
+	}
+	return 0
+}
+
+// ScannerBackend represents a scanner supported by the VSCAN Agent
+// available is false when the scanner prerequisites (runtime, binaries, license) are missing
+// license_expires_unix is the license expiry time in seconds since the Unix epoch, or 0 if unknown
+type ScannerBackend struct {
+	Name                string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version             string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Available           bool   `protobuf:"varint,3,opt,name=available,proto3" json:"available,omitempty"`
+	LicenseExpiresUnix  int64  `protobuf:"varint,4,opt,name=license_expires_unix,json=licenseExpiresUnix,proto3" json:"license_expires_unix,omitempty"`
+	LicenseDaysToExpiry int32  `protobuf:"varint,5,opt,name=license_days_to_expiry,json=licenseDaysToExpiry,proto3" json:"license_days_to_expiry,omitempty"`
+}
+
+func (m *ScannerBackend) Reset()         { *m = ScannerBackend{} }
+func (m *ScannerBackend) String() string { return proto.CompactTextString(m) }
+func (*ScannerBackend) ProtoMessage()    {}
+func (*ScannerBackend) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0233734088c6ede9, []int{25}
+}
+func (m *ScannerBackend) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ScannerBackend) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ScannerBackend.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ScannerBackend) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScannerBackend.Merge(m, src)
+}
+func (m *ScannerBackend) XXX_Size() int {
+	return m.Size()
+}
+func (m *ScannerBackend) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScannerBackend.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScannerBackend proto.InternalMessageInfo
+
+func (m *ScannerBackend) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ScannerBackend) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *ScannerBackend) GetAvailable() bool {
+	if m != nil {
+		return m.Available
+	}
+	return false
+}
+
+func (m *ScannerBackend) GetLicenseExpiresUnix() int64 {
+	if m != nil {
+		return m.LicenseExpiresUnix
+	}
+	return 0
+}
+
+func (m *ScannerBackend) GetLicenseDaysToExpiry() int32 {
+	if m != nil {
+		return m.LicenseDaysToExpiry
+	}
+	return 0
+}
+
+// RunningJob represents a scan job currently running on the VSCAN Agent
+type RunningJob struct {
+	JobId       string `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Devices     int32  `protobuf:"varint,2,opt,name=devices,proto3" json:"devices,omitempty"`
+	StartedUnix int64  `protobuf:"varint,3,opt,name=started_unix,json=startedUnix,proto3" json:"started_unix,omitempty"`
+}
+
+func (m *RunningJob) Reset()         { *m = RunningJob{} }
+func (m *RunningJob) String() string { return proto.CompactTextString(m) }
+func (*RunningJob) ProtoMessage()    {}
+func (*RunningJob) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0233734088c6ede9, []int{26}
+}
+func (m *RunningJob) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RunningJob) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RunningJob.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RunningJob) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunningJob.Merge(m, src)
+}
+func (m *RunningJob) XXX_Size() int {
+	return m.Size()
+}
+func (m *RunningJob) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunningJob.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunningJob proto.InternalMessageInfo
+
+func (m *RunningJob) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+func (m *RunningJob) GetDevices() int32 {
+	if m != nil {
+		return m.Devices
+	}
+	return 0
+}
+
+func (m *RunningJob) GetStartedUnix() int64 {
+	if m != nil {
+		return m.StartedUnix
+	}
+	return 0
+}
+
+// AgentJobCounts represents the scan jobs handled by the VSCAN Agent since it started
+type AgentJobCounts struct {
+	Running     int32         `protobuf:"varint,1,opt,name=running,proto3" json:"running,omitempty"`
+	Completed   int64         `protobuf:"varint,2,opt,name=completed,proto3" json:"completed,omitempty"`
+	Failed      int64         `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	RunningJobs []*RunningJob `protobuf:"bytes,4,rep,name=running_jobs,json=runningJobs,proto3" json:"running_jobs,omitempty"`
+}
+
+func (m *AgentJobCounts) Reset()         { *m = AgentJobCounts{} }
+func (m *AgentJobCounts) String() string { return proto.CompactTextString(m) }
+func (*AgentJobCounts) ProtoMessage()    {}
+func (*AgentJobCounts) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0233734088c6ede9, []int{27}
+}
+func (m *AgentJobCounts) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AgentJobCounts) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AgentJobCounts.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AgentJobCounts) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AgentJobCounts.Merge(m, src)
+}
+func (m *AgentJobCounts) XXX_Size() int {
+	return m.Size()
+}
+func (m *AgentJobCounts) XXX_DiscardUnknown() {
+	xxx_messageInfo_AgentJobCounts.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AgentJobCounts proto.InternalMessageInfo
+
+func (m *AgentJobCounts) GetRunning() int32 {
+	if m != nil {
+		return m.Running
+	}
+	return 0
+}
+
+func (m *AgentJobCounts) GetCompleted() int64 {
+	if m != nil {
+		return m.Completed
+	}
+	return 0
+}
+
+func (m *AgentJobCounts) GetFailed() int64 {
+	if m != nil {
+		return m.Failed
+	}
+	return 0
+}
+
+func (m *AgentJobCounts) GetRunningJobs() []*RunningJob {
+	if m != nil {
+		return m.RunningJobs
+	}
+	return nil
+}
+
+// AgentInfoResponse represents the VSCAN Agent details used by the controller to pick agents
+type AgentInfoResponse struct {
+	Build                         *AgentBuildInfo     `protobuf:"bytes,1,opt,name=build,proto3" json:"build,omitempty"`
+	Platform                      *AgentPlatformInfo  `protobuf:"bytes,2,opt,name=platform,proto3" json:"platform,omitempty"`
+	Resources                     *AgentResourceUsage `protobuf:"bytes,3,opt,name=resources,proto3" json:"resources,omitempty"`
+	ScannerBackends               []*ScannerBackend   `protobuf:"bytes,4,rep,name=scanner_backends,json=scannerBackends,proto3" json:"scanner_backends,omitempty"`
+	Jobs                          *AgentJobCounts     `protobuf:"bytes,5,opt,name=jobs,proto3" json:"jobs,omitempty"`
+	ServerCertificateDaysToExpiry int32               `protobuf:"varint,6,opt,name=server_certificate_days_to_expiry,json=serverCertificateDaysToExpiry,proto3" json:"server_certificate_days_to_expiry,omitempty"`
+	UptimeSeconds                 int64               `protobuf:"varint,7,opt,name=uptime_seconds,json=uptimeSeconds,proto3" json:"uptime_seconds,omitempty"`
+	Draining                      bool                `protobuf:"varint,8,opt,name=draining,proto3" json:"draining,omitempty"`
+}
+
+func (m *AgentInfoResponse) Reset()         { *m = AgentInfoResponse{} }
+func (m *AgentInfoResponse) String() string { return proto.CompactTextString(m) }
+func (*AgentInfoResponse) ProtoMessage()    {}
+func (*AgentInfoResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0233734088c6ede9, []int{28}
+}
+func (m *AgentInfoResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AgentInfoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AgentInfoResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AgentInfoResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AgentInfoResponse.Merge(m, src)
+}
+func (m *AgentInfoResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *AgentInfoResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AgentInfoResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AgentInfoResponse proto.InternalMessageInfo
+
+func (m *AgentInfoResponse) GetBuild() *AgentBuildInfo {
+	if m != nil {
+		return m.Build
+	}
+	return nil
+}
+
+func (m *AgentInfoResponse) GetPlatform() *AgentPlatformInfo {
+	if m != nil {
+		return m.Platform
+	}
+	return nil
+}
+
+func (m *AgentInfoResponse) GetResources() *AgentResourceUsage {
+	if m != nil {
+		return m.Resources
+	}
+	return nil
+}
+
+func (m *AgentInfoResponse) GetScannerBackends() []*ScannerBackend {
+	if m != nil {
+		return m.ScannerBackends
+	}
+	return nil
+}
+
+func (m *AgentInfoResponse) GetJobs() *AgentJobCounts {
+	if m != nil {
+		return m.Jobs
+	}
+	return nil
+}
+
+func (m *AgentInfoResponse) GetServerCertificateDaysToExpiry() int32 {
+	if m != nil {
+		return m.ServerCertificateDaysToExpiry
+	}
+	return 0
+}
+
+func (m *AgentInfoResponse) GetUptimeSeconds() int64 {
+	if m != nil {
+		return m.UptimeSeconds
+	}
+	return 0
+}
+
+func (m *AgentInfoResponse) GetDraining() bool {
+	if m != nil {
+		return m.Draining
+	}
+	return false
+}
+
+// DrainRequest represents a request to stop accepting new scan jobs ahead of an agent shutdown or upgrade
+// Running scan jobs are allowed to finish for grace_period_seconds, which defaults to the agent setting, before
+// they are cancelled.
+type DrainRequest struct {
+	GracePeriodSeconds int64 `protobuf:"varint,1,opt,name=grace_period_seconds,json=gracePeriodSeconds,proto3" json:"grace_period_seconds,omitempty"`
+}
+
+func (m *DrainRequest) Reset()         { *m = DrainRequest{} }
+func (m *DrainRequest) String() string { return proto.CompactTextString(m) }
+func (*DrainRequest) ProtoMessage()    {}
+func (*DrainRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0233734088c6ede9, []int{29}
+}
+func (m *DrainRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DrainRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DrainRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DrainRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DrainRequest.Merge(m, src)
+}
+func (m *DrainRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *DrainRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DrainRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DrainRequest proto.InternalMessageInfo
+
+func (m *DrainRequest) GetGracePeriodSeconds() int64 {
+	if m != nil {
+		return m.GracePeriodSeconds
+	}
+	return 0
+}
+
+// DrainResponse represents the VSCAN Agent drain status
+// deadline_unix is the time, in seconds since the Unix epoch, at which the remaining scan jobs are cancelled
+type DrainResponse struct {
+	Draining     bool          `protobuf:"varint,1,opt,name=draining,proto3" json:"draining,omitempty"`
+	DeadlineUnix int64         `protobuf:"varint,2,opt,name=deadline_unix,json=deadlineUnix,proto3" json:"deadline_unix,omitempty"`
+	RunningJobs  []*RunningJob `protobuf:"bytes,3,rep,name=running_jobs,json=runningJobs,proto3" json:"running_jobs,omitempty"`
+}
+
+func (m *DrainResponse) Reset()         { *m = DrainResponse{} }
+func (m *DrainResponse) String() string { return proto.CompactTextString(m) }
+func (*DrainResponse) ProtoMessage()    {}
+func (*DrainResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0233734088c6ede9, []int{30}
+}
+func (m *DrainResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DrainResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DrainResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DrainResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DrainResponse.Merge(m, src)
+}
+func (m *DrainResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *DrainResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DrainResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DrainResponse proto.InternalMessageInfo
+
+func (m *DrainResponse) GetDraining() bool {
+	if m != nil {
+		return m.Draining
+	}
+	return false
+}
+
+func (m *DrainResponse) GetDeadlineUnix() int64 {
+	if m != nil {
+		return m.DeadlineUnix
+	}
+	return 0
+}
+
+func (m *DrainResponse) GetRunningJobs() []*RunningJob {
+	if m != nil {
+		return m.RunningJobs
+	}
+	return nil
+}
+
+// AgentRegistration represents the registration of a VSCAN Agent with the controller at startup
+// advertise_addr is the host:port the controller uses to reach the agent, empty in reverse-connect only mode
+type AgentRegistration struct {
+	AgentName     string             `protobuf:"bytes,1,opt,name=agent_name,json=agentName,proto3" json:"agent_name,omitempty"`
+	AdvertiseAddr string             `protobuf:"bytes,2,opt,name=advertise_addr,json=advertiseAddr,proto3" json:"advertise_addr,omitempty"`
+	AgentInfo     *AgentInfoResponse `protobuf:"bytes,3,opt,name=agent_info,json=agentInfo,proto3" json:"agent_info,omitempty"`
+}
+
+func (m *AgentRegistration) Reset()         { *m = AgentRegistration{} }
+func (m *AgentRegistration) String() string { return proto.CompactTextString(m) }
+func (*AgentRegistration) ProtoMessage()    {}
+func (*AgentRegistration) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0233734088c6ede9, []int{31}
+}
+func (m *AgentRegistration) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AgentRegistration) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AgentRegistration.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AgentRegistration) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AgentRegistration.Merge(m, src)
+}
+func (m *AgentRegistration) XXX_Size() int {
+	return m.Size()
+}
+func (m *AgentRegistration) XXX_DiscardUnknown() {
+	xxx_messageInfo_AgentRegistration.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AgentRegistration proto.InternalMessageInfo
+
+func (m *AgentRegistration) GetAgentName() string {
+	if m != nil {
+		return m.AgentName
+	}
+	return ""
+}
+
+func (m *AgentRegistration) GetAdvertiseAddr() string {
+	if m != nil {
+		return m.AdvertiseAddr
+	}
+	return ""
+}
+
+func (m *AgentRegistration) GetAgentInfo() *AgentInfoResponse {
+	if m != nil {
+		return m.AgentInfo
+	}
+	return nil
+}
+
+// AgentRegistrationResponse represents the controller acknowledgement of an agent registration
+// heartbeat_interval_seconds overrides the agent heartbeat interval when set
+type AgentRegistrationResponse struct {
+	AgentId                  string `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	HeartbeatIntervalSeconds int64  `protobuf:"varint,2,opt,name=heartbeat_interval_seconds,json=heartbeatIntervalSeconds,proto3" json:"heartbeat_interval_seconds,omitempty"`
+}
+
+func (m *AgentRegistrationResponse) Reset()         { *m = AgentRegistrationResponse{} }
+func (m *AgentRegistrationResponse) String() string { return proto.CompactTextString(m) }
+func (*AgentRegistrationResponse) ProtoMessage()    {}
+func (*AgentRegistrationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0233734088c6ede9, []int{32}
+}
+func (m *AgentRegistrationResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AgentRegistrationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AgentRegistrationResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AgentRegistrationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AgentRegistrationResponse.Merge(m, src)
+}
+func (m *AgentRegistrationResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *AgentRegistrationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AgentRegistrationResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AgentRegistrationResponse proto.InternalMessageInfo
+
+func (m *AgentRegistrationResponse) GetAgentId() string {
+	if m != nil {
+		return m.AgentId
+	}
+	return ""
+}
+
+func (m *AgentRegistrationResponse) GetHeartbeatIntervalSeconds() int64 {
+	if m != nil {
+		return m.HeartbeatIntervalSeconds
+	}
+	return 0
+}
+
+// AgentHeartbeat represents the periodic VSCAN Agent status report to the controller
+// queue_depth is the number of scan jobs accepted and not completed yet. The agent runs scan jobs as they are
+// received, so it matches the number of running jobs.
+type AgentHeartbeat struct {
+	AgentId     string              `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	AgentName   string              `protobuf:"bytes,2,opt,name=agent_name,json=agentName,proto3" json:"agent_name,omitempty"`
+	Resources   *AgentResourceUsage `protobuf:"bytes,3,opt,name=resources,proto3" json:"resources,omitempty"`
+	QueueDepth  int32               `protobuf:"varint,4,opt,name=queue_depth,json=queueDepth,proto3" json:"queue_depth,omitempty"`
+	RunningJobs []*RunningJob       `protobuf:"bytes,5,rep,name=running_jobs,json=runningJobs,proto3" json:"running_jobs,omitempty"`
+	Draining    bool                `protobuf:"varint,6,opt,name=draining,proto3" json:"draining,omitempty"`
+	Serving     bool                `protobuf:"varint,7,opt,name=serving,proto3" json:"serving,omitempty"`
+}
+
+func (m *AgentHeartbeat) Reset()         { *m = AgentHeartbeat{} }
+func (m *AgentHeartbeat) String() string { return proto.CompactTextString(m) }
+func (*AgentHeartbeat) ProtoMessage()    {}
+func (*AgentHeartbeat) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0233734088c6ede9, []int{33}
+}
+func (m *AgentHeartbeat) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AgentHeartbeat) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AgentHeartbeat.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AgentHeartbeat) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AgentHeartbeat.Merge(m, src)
+}
+func (m *AgentHeartbeat) XXX_Size() int {
+	return m.Size()
+}
+func (m *AgentHeartbeat) XXX_DiscardUnknown() {
+	xxx_messageInfo_AgentHeartbeat.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AgentHeartbeat proto.InternalMessageInfo
+
+func (m *AgentHeartbeat) GetAgentId() string {
+	if m != nil {
+		return m.AgentId
+	}
+	return ""
+}
+
+func (m *AgentHeartbeat) GetAgentName() string {
+	if m != nil {
+		return m.AgentName
+	}
+	return ""
+}
+
+func (m *AgentHeartbeat) GetResources() *AgentResourceUsage {
+	if m != nil {
+		return m.Resources
+	}
+	return nil
+}
+
+func (m *AgentHeartbeat) GetQueueDepth() int32 {
+	if m != nil {
+		return m.QueueDepth
+	}
+	return 0
+}
+
+func (m *AgentHeartbeat) GetRunningJobs() []*RunningJob {
+	if m != nil {
+		return m.RunningJobs
+	}
+	return nil
+}
+
+func (m *AgentHeartbeat) GetDraining() bool {
+	if m != nil {
+		return m.Draining
+	}
+	return false
+}
+
+func (m *AgentHeartbeat) GetServing() bool {
+	if m != nil {
+		return m.Serving
+	}
+	return false
+}
+
+// AgentHeartbeatResponse represents the controller acknowledgement of a heartbeat
+type AgentHeartbeatResponse struct {
+}
+
+func (m *AgentHeartbeatResponse) Reset()         { *m = AgentHeartbeatResponse{} }
+func (m *AgentHeartbeatResponse) String() string { return proto.CompactTextString(m) }
+func (*AgentHeartbeatResponse) ProtoMessage()    {}
+func (*AgentHeartbeatResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0233734088c6ede9, []int{34}
+}
+func (m *AgentHeartbeatResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AgentHeartbeatResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AgentHeartbeatResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AgentHeartbeatResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AgentHeartbeatResponse.Merge(m, src)
+}
+func (m *AgentHeartbeatResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *AgentHeartbeatResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AgentHeartbeatResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AgentHeartbeatResponse proto.InternalMessageInfo
+
+// AgentDeregistration represents the removal of a VSCAN Agent from the controller on graceful shutdown
+type AgentDeregistration struct {
+	AgentId   string `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	AgentName string `protobuf:"bytes,2,opt,name=agent_name,json=agentName,proto3" json:"agent_name,omitempty"`
+}
+
+func (m *AgentDeregistration) Reset()         { *m = AgentDeregistration{} }
+func (m *AgentDeregistration) String() string { return proto.CompactTextString(m) }
+func (*AgentDeregistration) ProtoMessage()    {}
+func (*AgentDeregistration) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0233734088c6ede9, []int{35}
+}
+func (m *AgentDeregistration) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AgentDeregistration) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AgentDeregistration.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AgentDeregistration) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AgentDeregistration.Merge(m, src)
+}
+func (m *AgentDeregistration) XXX_Size() int {
+	return m.Size()
+}
+func (m *AgentDeregistration) XXX_DiscardUnknown() {
+	xxx_messageInfo_AgentDeregistration.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AgentDeregistration proto.InternalMessageInfo
+
+func (m *AgentDeregistration) GetAgentId() string {
+	if m != nil {
+		return m.AgentId
+	}
+	return ""
+}
+
+func (m *AgentDeregistration) GetAgentName() string {
+	if m != nil {
+		return m.AgentName
+	}
+	return ""
+}
+
+// AgentDeregistrationResponse represents the controller acknowledgement of an agent deregistration
+type AgentDeregistrationResponse struct {
+}
+
+func (m *AgentDeregistrationResponse) Reset()         { *m = AgentDeregistrationResponse{} }
+func (m *AgentDeregistrationResponse) String() string { return proto.CompactTextString(m) }
+func (*AgentDeregistrationResponse) ProtoMessage()    {}
+func (*AgentDeregistrationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0233734088c6ede9, []int{36}
+}
+func (m *AgentDeregistrationResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AgentDeregistrationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AgentDeregistrationResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AgentDeregistrationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AgentDeregistrationResponse.Merge(m, src)
+}
+func (m *AgentDeregistrationResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *AgentDeregistrationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AgentDeregistrationResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AgentDeregistrationResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterEnum("agentpb.DeviceVendor", DeviceVendor_name, DeviceVendor_value)
+	proto.RegisterEnum("agentpb.PrivilegeMethod", PrivilegeMethod_name, PrivilegeMethod_value)
+	proto.RegisterEnum("agentpb.SSHFailureReason", SSHFailureReason_name, SSHFailureReason_value)
+	proto.RegisterType((*KeyboardInteractiveAnswer)(nil), "agentpb.KeyboardInteractiveAnswer")
+	proto.RegisterType((*SSHGateway)(nil), "agentpb.SSHGateway")
+	proto.RegisterType((*UserDeviceCredentials)(nil), "agentpb.UserDeviceCredentials")
+	proto.RegisterType((*Device)(nil), "agentpb.Device")
+	proto.RegisterType((*ScanRequest)(nil), "agentpb.ScanRequest")
+	proto.RegisterType((*ScanResultsResponse)(nil), "agentpb.ScanResultsResponse")
+	proto.RegisterType((*ScanLogFileResponseWB)(nil), "agentpb.ScanLogFileResponseWB")
+	proto.RegisterType((*ScanLogFileResponsePS)(nil), "agentpb.ScanLogFileResponsePS")
+	proto.RegisterType((*SSHGatewayTestRequest)(nil), "agentpb.SSHGatewayTestRequest")
+	proto.RegisterType((*SSHNegotiatedAlgorithms)(nil), "agentpb.SSHNegotiatedAlgorithms")
+	proto.RegisterType((*SSHGatewayTestResponse)(nil), "agentpb.SSHGatewayTestResponse")
+	proto.RegisterType((*DeviceTestRequest)(nil), "agentpb.DeviceTestRequest")
+	proto.RegisterType((*DeviceTestResult)(nil), "agentpb.DeviceTestResult")
+	proto.RegisterType((*DeviceTestResponse)(nil), "agentpb.DeviceTestResponse")
+	proto.RegisterType((*DiscoveryRequest)(nil), "agentpb.DiscoveryRequest")
+	proto.RegisterType((*DeviceInventory)(nil), "agentpb.DeviceInventory")
+	proto.RegisterType((*DiscoveryResponse)(nil), "agentpb.DiscoveryResponse")
+	proto.RegisterType((*BulkSSHTestRequest)(nil), "agentpb.BulkSSHTestRequest")
+	proto.RegisterType((*BulkSSHTestResult)(nil), "agentpb.BulkSSHTestResult")
+	proto.RegisterType((*BulkSSHTestSummary)(nil), "agentpb.BulkSSHTestSummary")
+	proto.RegisterType((*BulkSSHTestResponse)(nil), "agentpb.BulkSSHTestResponse")
+	proto.RegisterType((*AgentInfoRequest)(nil), "agentpb.AgentInfoRequest")
+	proto.RegisterType((*AgentBuildInfo)(nil), "agentpb.AgentBuildInfo")
+	proto.RegisterType((*AgentPlatformInfo)(nil), "agentpb.AgentPlatformInfo")
+	proto.RegisterType((*AgentResourceUsage)(nil), "agentpb.AgentResourceUsage")
+	proto.RegisterType((*ScannerBackend)(nil), "agentpb.ScannerBackend")
+	proto.RegisterType((*RunningJob)(nil), "agentpb.RunningJob")
+	proto.RegisterType((*AgentJobCounts)(nil), "agentpb.AgentJobCounts")
+	proto.RegisterType((*AgentInfoResponse)(nil), "agentpb.AgentInfoResponse")
+	proto.RegisterType((*DrainRequest)(nil), "agentpb.DrainRequest")
+	proto.RegisterType((*DrainResponse)(nil), "agentpb.DrainResponse")
+	proto.RegisterType((*AgentRegistration)(nil), "agentpb.AgentRegistration")
+	proto.RegisterType((*AgentRegistrationResponse)(nil), "agentpb.AgentRegistrationResponse")
+	proto.RegisterType((*AgentHeartbeat)(nil), "agentpb.AgentHeartbeat")
+	proto.RegisterType((*AgentHeartbeatResponse)(nil), "agentpb.AgentHeartbeatResponse")
+	proto.RegisterType((*AgentDeregistration)(nil), "agentpb.AgentDeregistration")
+	proto.RegisterType((*AgentDeregistrationResponse)(nil), "agentpb.AgentDeregistrationResponse")
+}
+
+func init() { proto.RegisterFile("proto/agentpb.proto", fileDescriptor_0233734088c6ede9) }
+
+var fileDescriptor_0233734088c6ede9 = []byte{
+	// 3458 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x5a, 0xcb, 0x73, 0x22, 0x49,
+	0x7a, 0x17, 0x20, 0x24, 0xf8, 0x40, 0x02, 0x52, 0x2f, 0xa4, 0x9e, 0xd6, 0x74, 0x97, 0x67, 0xc6,
+	0x3d, 0xed, 0x9d, 0xc7, 0x6a, 0xa7, 0x77, 0x3d, 0x1b, 0x3e, 0x2c, 0x02, 0xba, 0xa1, 0x5b, 0x02,
+	0x5c, 0x40, 0xcf, 0x78, 0x63, 0x23, 0xca, 0x45, 0x55, 0x0a, 0xaa, 0x55, 0x54, 0xb2, 0x95, 0x85,
+	0xa6, 0xf9, 0x0f, 0xec, 0x70, 0x38, 0xec, 0xc3, 0x3a, 0xfc, 0x3a, 0xf9, 0xb6, 0x27, 0x5f, 0x1c,
+	0x61, 0x5f, 0x7c, 0xdb, 0x8b, 0x8f, 0x7b, 0x71, 0x84, 0x8f, 0x8e, 0x9e, 0x7f, 0xc1, 0x7f, 0x80,
+	0x23, 0x5f, 0xf5, 0x12, 0xea, 0x99, 0x75, 0x9f, 0x7c, 0x23, 0xbf, 0x57, 0x66, 0x7e, 0xf9, 0xfb,
+	0x1e, 0x99, 0x05, 0xec, 0x2d, 0x7c, 0x12, 0x90, 0xcf, 0xcc, 0x29, 0xf6, 0x82, 0xc5, 0xe4, 0x53,
+	0x3e, 0x42, 0xdb, 0x72, 0xa8, 0xfd, 0x1c, 0x8e, 0x5f, 0xe0, 0xd5, 0x84, 0x98, 0xbe, 0xdd, 0xf5,
+	0x02, 0xec, 0x9b, 0x56, 0xe0, 0xdc, 0xe0, 0x86, 0x47, 0xbf, 0xc1, 0x3e, 0xfa, 0x10, 0x76, 0x17,
+	0x3e, 0x99, 0x2f, 0x02, 0x63, 0x61, 0x06, 0x01, 0xf6, 0xbd, 0x7a, 0xe6, 0x41, 0xe6, 0x51, 0x51,
+	0xdf, 0x11, 0xd4, 0x81, 0x20, 0xa2, 0x43, 0xd8, 0x32, 0xb9, 0x42, 0x3d, 0xcb, 0xd9, 0x72, 0xa4,
+	0xfd, 0xf3, 0x26, 0xc0, 0x70, 0xd8, 0x79, 0x66, 0x06, 0xf8, 0x1b, 0x73, 0x85, 0x1e, 0x42, 0x79,
+	0x2a, 0x7e, 0x1a, 0x9e, 0x39, 0xc7, 0xd2, 0x56, 0x49, 0xd2, 0x7a, 0xe6, 0x1c, 0xa3, 0xfb, 0x00,
+	0x4a, 0xc4, 0x59, 0x48, 0x6b, 0x45, 0x49, 0xe9, 0x2e, 0xd0, 0xc7, 0x50, 0x55, 0xec, 0x25, 0xc5,
+	0x3e, 0xb7, 0x92, 0xe3, 0x42, 0x15, 0x49, 0x1f, 0x4b, 0x72, 0x5c, 0x74, 0x61, 0x52, 0xfa, 0x0d,
+	0xf1, 0xed, 0xfa, 0x66, 0x42, 0x74, 0x20, 0xc9, 0xe8, 0x53, 0xd8, 0x0b, 0x45, 0x7d, 0xe7, 0xc6,
+	0x0c, 0xb0, 0x71, 0x8d, 0x57, 0xf5, 0x3c, 0x97, 0xae, 0x29, 0x69, 0xc1, 0x79, 0x81, 0x57, 0xa8,
+	0x09, 0xa7, 0x6b, 0xe4, 0xf9, 0x34, 0x8b, 0x99, 0x6f, 0x52, 0x5c, 0xdf, 0xe2, 0xaa, 0xf7, 0x6e,
+	0xa9, 0x0e, 0x42, 0x11, 0xd4, 0x80, 0xfb, 0xca, 0xc8, 0x8c, 0xd0, 0x80, 0x5b, 0xb8, 0x72, 0xbc,
+	0x29, 0xf6, 0x17, 0xbe, 0xe3, 0x05, 0xb4, 0xbe, 0xfd, 0x20, 0xf7, 0xa8, 0xa8, 0x9f, 0x48, 0xa1,
+	0x0e, 0xa1, 0xc1, 0x0b, 0xbc, 0x7a, 0x1a, 0x93, 0x40, 0x9f, 0x45, 0xeb, 0xb6, 0xb0, 0x1f, 0x38,
+	0x57, 0x8e, 0x65, 0x06, 0xb8, 0x5e, 0xe0, 0x93, 0x23, 0xc9, 0x6a, 0x46, 0x1c, 0x44, 0xe1, 0x03,
+	0xa5, 0x70, 0x2d, 0xcf, 0xdc, 0x70, 0xa2, 0x43, 0x37, 0xc4, 0xb1, 0xd1, 0x7a, 0xf1, 0x41, 0xee,
+	0x51, 0xe9, 0x4c, 0xfb, 0x54, 0x41, 0xe6, 0x4e, 0x80, 0xe8, 0x0f, 0xa5, 0xbd, 0x3b, 0x25, 0x28,
+	0x7a, 0x0c, 0xb5, 0xc8, 0x5b, 0xe4, 0xf5, 0xca, 0x58, 0xfa, 0x6e, 0x1d, 0x92, 0x27, 0xc1, 0xe8,
+	0x63, 0xdf, 0xd5, 0xfe, 0x6a, 0x13, 0x0e, 0xd8, 0x09, 0xb6, 0xf0, 0x8d, 0x63, 0xe1, 0xa6, 0x8f,
+	0x6d, 0xec, 0x05, 0x8e, 0xe9, 0x52, 0x76, 0x9c, 0x56, 0x34, 0x8c, 0xe3, 0xa7, 0x12, 0xa3, 0x73,
+	0x0c, 0xfd, 0x14, 0x8e, 0xe3, 0xa2, 0x36, 0xb7, 0x65, 0xdc, 0x60, 0xcf, 0x26, 0x0a, 0xa0, 0x47,
+	0x31, 0x01, 0x31, 0xd7, 0x4b, 0xce, 0x46, 0x27, 0x50, 0x48, 0x01, 0x2b, 0x1c, 0x33, 0x5e, 0x0a,
+	0x49, 0x85, 0x45, 0x0c, 0x42, 0x0e, 0xa1, 0x06, 0xf6, 0xcc, 0x89, 0x8b, 0x23, 0xc0, 0x49, 0x08,
+	0x39, 0x84, 0xb6, 0x39, 0x27, 0x84, 0xdc, 0xfb, 0x50, 0x8a, 0x43, 0x4d, 0xe0, 0x05, 0x16, 0x11,
+	0xc6, 0x7e, 0x0a, 0x3b, 0xc9, 0x85, 0x6f, 0x3f, 0xc8, 0x3c, 0xda, 0x3d, 0x3b, 0x08, 0xcf, 0x24,
+	0xbe, 0x6c, 0xbd, 0x6c, 0xc7, 0x37, 0xd1, 0x84, 0x2a, 0xb3, 0xe4, 0xb8, 0x78, 0x8a, 0x8d, 0x39,
+	0x0e, 0x66, 0xc4, 0xe6, 0xa0, 0xd8, 0x3d, 0xab, 0x87, 0xea, 0x03, 0x25, 0x70, 0xc9, 0xf9, 0x7a,
+	0x65, 0x91, 0x24, 0xa0, 0x4f, 0x00, 0x45, 0x46, 0xc2, 0x0d, 0x15, 0xc5, 0x86, 0x42, 0x4e, 0xb8,
+	0xa1, 0x2f, 0xe0, 0xf0, 0x8e, 0x58, 0x10, 0x47, 0xbd, 0xbf, 0x58, 0x17, 0x04, 0x0f, 0xa0, 0x14,
+	0x47, 0x6e, 0x49, 0x24, 0x84, 0x18, 0x49, 0xeb, 0xc0, 0x96, 0xd8, 0x29, 0x73, 0x99, 0xf4, 0x48,
+	0xec, 0xf0, 0x41, 0x90, 0x54, 0xee, 0x70, 0x16, 0x86, 0x69, 0xdb, 0x3e, 0xa6, 0x54, 0xe5, 0x0e,
+	0x67, 0xd1, 0x10, 0x04, 0xed, 0xdf, 0xb2, 0x50, 0x1a, 0x5a, 0xa6, 0xa7, 0xe3, 0x5f, 0x2e, 0x31,
+	0x0d, 0xd0, 0x01, 0x6c, 0xbd, 0x22, 0x13, 0xc3, 0xb1, 0xa5, 0xa9, 0xfc, 0x2b, 0x32, 0xe9, 0xda,
+	0xe8, 0x63, 0xd8, 0x16, 0x36, 0x99, 0x09, 0x16, 0x06, 0x95, 0x94, 0xcb, 0x75, 0xc5, 0x47, 0x5f,
+	0x40, 0x89, 0xd2, 0x99, 0x21, 0x41, 0xcc, 0xf1, 0x52, 0x3a, 0xdb, 0x0b, 0xc5, 0xa3, 0xcc, 0xa7,
+	0x03, 0xa5, 0x33, 0xf9, 0x1b, 0xbd, 0x84, 0x23, 0x06, 0x29, 0x85, 0xcb, 0x18, 0x12, 0x39, 0xaa,
+	0x4a, 0x67, 0xa7, 0xa1, 0x85, 0xb5, 0xa1, 0xa0, 0x1f, 0x2c, 0xd7, 0x46, 0xc8, 0x47, 0x50, 0x21,
+	0x37, 0xa6, 0x6b, 0x50, 0xb2, 0xf4, 0x2d, 0xcc, 0xa3, 0x4c, 0xc0, 0x6f, 0x87, 0x91, 0x87, 0x9c,
+	0x3a, 0xf6, 0x5d, 0xf4, 0x39, 0xec, 0x53, 0xcb, 0xf4, 0x8c, 0xc0, 0x99, 0x63, 0xb2, 0x0c, 0x0c,
+	0x8a, 0x2d, 0xe2, 0xd9, 0x94, 0x63, 0x30, 0xa7, 0x23, 0xc6, 0x1b, 0x09, 0xd6, 0x50, 0x70, 0xb4,
+	0x5f, 0x67, 0x61, 0x4f, 0x78, 0x8e, 0x2e, 0xdd, 0x80, 0xea, 0x98, 0x2e, 0x88, 0x47, 0x31, 0x8b,
+	0x6c, 0x6e, 0xc9, 0x17, 0x74, 0xe3, 0x15, 0x25, 0xa2, 0x40, 0x94, 0xf5, 0x0a, 0x8d, 0xe4, 0x9f,
+	0x53, 0xe2, 0xa1, 0x47, 0x50, 0xbd, 0xe1, 0xc2, 0x7c, 0x6f, 0xe2, 0x08, 0xc5, 0x11, 0xed, 0x72,
+	0x7a, 0x83, 0x91, 0xf9, 0x31, 0xa6, 0xce, 0x39, 0x77, 0xeb, 0x9c, 0x7b, 0xb0, 0xc7, 0x2d, 0xb9,
+	0x64, 0x4a, 0x8d, 0x6f, 0xf0, 0x84, 0x12, 0xeb, 0x1a, 0x07, 0xb7, 0x9c, 0xc7, 0x56, 0x7c, 0x41,
+	0xa6, 0x4f, 0x1d, 0x17, 0xab, 0x15, 0x7f, 0x75, 0xae, 0xf3, 0x15, 0x5f, 0x90, 0x29, 0xfd, 0x4a,
+	0x29, 0xa2, 0xe7, 0x50, 0x8b, 0xec, 0x2d, 0xb0, 0x4f, 0x1d, 0x1a, 0xd4, 0xf3, 0xdf, 0x6d, 0x6d,
+	0x30, 0xd4, 0x2b, 0xca, 0xda, 0x40, 0xa8, 0x69, 0x5f, 0xc0, 0xc1, 0xda, 0x79, 0xd1, 0x3d, 0x28,
+	0x86, 0x93, 0x48, 0x1f, 0x15, 0x94, 0xf2, 0x1d, 0x5a, 0x83, 0xe1, 0xdb, 0xb5, 0x2e, 0xe1, 0x20,
+	0x82, 0xd8, 0x08, 0xd3, 0x40, 0x21, 0x3b, 0x85, 0xcb, 0xcc, 0xf7, 0xc2, 0xa5, 0xf6, 0xef, 0x59,
+	0x38, 0x1a, 0x0e, 0x3b, 0x3d, 0x3c, 0x25, 0x81, 0x63, 0x06, 0xd8, 0x6e, 0xb8, 0x53, 0xe2, 0x3b,
+	0xc1, 0x6c, 0x4e, 0xd1, 0xef, 0xc1, 0xce, 0x35, 0x7e, 0x6d, 0x98, 0x8a, 0x22, 0x43, 0xa6, 0x7c,
+	0x8d, 0x5f, 0x87, 0x52, 0xe8, 0x07, 0x80, 0xc2, 0x4a, 0x16, 0x49, 0x8a, 0x43, 0xae, 0xce, 0x44,
+	0xfd, 0x8a, 0xa4, 0x9f, 0xc0, 0x91, 0xe5, 0x2c, 0x66, 0xd8, 0x37, 0x2c, 0xd7, 0x61, 0x90, 0x08,
+	0x88, 0x41, 0xb1, 0x7f, 0x83, 0x7d, 0x79, 0xe4, 0xfb, 0x82, 0xdd, 0xe4, 0xdc, 0x11, 0x19, 0x72,
+	0x5e, 0x4c, 0x4d, 0x08, 0x33, 0x35, 0x61, 0xa0, 0xbe, 0x19, 0x57, 0x13, 0xe2, 0x23, 0x22, 0xd4,
+	0xd1, 0x67, 0xb0, 0x3f, 0x37, 0xad, 0xdb, 0x53, 0xc9, 0x04, 0x3d, 0x37, 0xad, 0xd4, 0x3c, 0x52,
+	0xe1, 0xd6, 0x24, 0x5b, 0xa1, 0x42, 0x72, 0x06, 0xed, 0xcf, 0x37, 0xe1, 0x30, 0x7d, 0x1c, 0x32,
+	0x4e, 0x3e, 0x82, 0x0a, 0x3b, 0x8f, 0x00, 0xd3, 0x40, 0xc6, 0x8a, 0x6a, 0xa3, 0x28, 0x9d, 0x49,
+	0xc9, 0xa5, 0x1b, 0x28, 0x39, 0x76, 0xe0, 0x16, 0xf1, 0x3c, 0x6c, 0x05, 0xdc, 0x7b, 0x05, 0x2e,
+	0xd7, 0x34, 0xbd, 0xa6, 0x20, 0xa2, 0x9f, 0x40, 0x9d, 0xc9, 0xad, 0x6b, 0x1b, 0xa4, 0xef, 0x0e,
+	0x28, 0x9d, 0xdd, 0xee, 0x18, 0xd8, 0xa6, 0x12, 0x8a, 0x73, 0x33, 0xb0, 0x66, 0x58, 0x54, 0xb3,
+	0x82, 0x5e, 0x8b, 0x94, 0x2e, 0x05, 0x03, 0x3d, 0x03, 0xc4, 0x14, 0xae, 0x4c, 0xc7, 0x5d, 0xfa,
+	0xd8, 0xf0, 0xb1, 0xc9, 0x42, 0x3c, 0xcf, 0x6b, 0xc9, 0x71, 0x1c, 0x50, 0x4f, 0x85, 0x84, 0xce,
+	0x05, 0xf4, 0x2a, 0xa5, 0xb3, 0x04, 0x05, 0xfd, 0x40, 0x18, 0x92, 0xee, 0xbc, 0x61, 0xd1, 0x42,
+	0x3c, 0xe9, 0x4c, 0x26, 0x2d, 0x9c, 0xf9, 0x52, 0xd0, 0xd1, 0x2f, 0xe0, 0x98, 0x49, 0x7b, 0x21,
+	0x14, 0x23, 0x3c, 0x51, 0x5e, 0x08, 0x4b, 0x67, 0x0f, 0xe2, 0xb3, 0xaf, 0xc3, 0xac, 0x7e, 0x44,
+	0xe9, 0x6c, 0x2d, 0x98, 0x3f, 0x80, 0xdd, 0xc0, 0x5a, 0x28, 0x17, 0x1b, 0x73, 0xca, 0x8b, 0x63,
+	0x4e, 0x2f, 0x07, 0xd6, 0x42, 0xba, 0xf8, 0x92, 0xb2, 0x66, 0x75, 0x66, 0x7a, 0x36, 0x9d, 0x99,
+	0xd7, 0x98, 0xc9, 0x14, 0xb9, 0x4c, 0x29, 0xa4, 0x5d, 0x52, 0x74, 0x04, 0xdb, 0xe6, 0x32, 0x98,
+	0x31, 0x2e, 0x70, 0xee, 0x16, 0x1b, 0x5e, 0x52, 0xed, 0x9f, 0xb2, 0x50, 0x13, 0x09, 0x3a, 0x1e,
+	0x96, 0xb1, 0xca, 0x92, 0xf9, 0xdd, 0x2a, 0x4b, 0xf6, 0x9d, 0x2b, 0x4b, 0xee, 0x5d, 0x2a, 0xcb,
+	0x63, 0xa8, 0x59, 0x33, 0x6c, 0x5d, 0xab, 0xf6, 0x66, 0x4e, 0x6c, 0x2c, 0x31, 0x53, 0xe1, 0x0c,
+	0xd1, 0xdc, 0x5c, 0x12, 0x1b, 0xa3, 0xdf, 0x87, 0x4a, 0xba, 0xb0, 0xe4, 0xb9, 0x6f, 0x76, 0x83,
+	0x64, 0x51, 0xf9, 0x4d, 0x16, 0xaa, 0x71, 0x1f, 0xf1, 0x08, 0x78, 0xc7, 0x1a, 0xbf, 0x2e, 0x82,
+	0x72, 0xeb, 0x22, 0x68, 0x4d, 0x44, 0x6e, 0xae, 0x8b, 0x48, 0x56, 0xe1, 0x22, 0xd8, 0x4e, 0x4c,
+	0xcf, 0x0b, 0x73, 0x46, 0x25, 0x44, 0xed, 0x39, 0x27, 0xb3, 0xa5, 0xb9, 0x66, 0x80, 0x3d, 0x6b,
+	0xc5, 0x00, 0x21, 0xaa, 0x69, 0x51, 0x52, 0x2e, 0x29, 0xeb, 0x10, 0x63, 0xee, 0x33, 0xb8, 0xdf,
+	0xb0, 0xcd, 0xd1, 0x5c, 0xd0, 0x6b, 0x38, 0xf4, 0x60, 0x53, 0x30, 0x18, 0x4a, 0xe3, 0xf2, 0xe4,
+	0x9a, 0xa3, 0xb4, 0xa0, 0x97, 0x23, 0xd1, 0xfe, 0xb5, 0x66, 0x00, 0x4a, 0x38, 0x51, 0x24, 0x9c,
+	0x2e, 0xec, 0x49, 0x37, 0xc6, 0x76, 0xa8, 0x50, 0x77, 0x9c, 0x42, 0x5d, 0xb4, 0x5d, 0xbd, 0x66,
+	0xa7, 0x28, 0x54, 0xfb, 0x9f, 0x0c, 0x54, 0x5b, 0x0e, 0xb5, 0xc8, 0x0d, 0xf6, 0x57, 0xff, 0xef,
+	0x91, 0xbc, 0x06, 0x9d, 0x9b, 0x6b, 0xd1, 0xf9, 0x26, 0x0b, 0x15, 0xa1, 0xde, 0xf5, 0x6e, 0xb0,
+	0x17, 0x10, 0x7f, 0xf5, 0xce, 0xe0, 0x3c, 0x05, 0xb0, 0xa5, 0x27, 0xb1, 0x2d, 0x71, 0x19, 0xa3,
+	0xb0, 0xc5, 0xa9, 0xd1, 0xca, 0xc0, 0xbe, 0x4f, 0x7c, 0x09, 0xca, 0xdd, 0x90, 0xdc, 0x66, 0x54,
+	0xf4, 0x09, 0x6c, 0xc9, 0x4b, 0x41, 0xfe, 0x6d, 0x97, 0x02, 0x29, 0xc4, 0xef, 0x2d, 0xae, 0x19,
+	0x5c, 0x11, 0x7f, 0x2e, 0x33, 0x6e, 0x38, 0x66, 0x0d, 0x06, 0xa1, 0xc6, 0x95, 0x39, 0x77, 0xdc,
+	0x15, 0xc7, 0x62, 0x51, 0x2f, 0x10, 0xfa, 0x94, 0x8f, 0xd9, 0x7e, 0x08, 0x0d, 0x93, 0xb5, 0xb8,
+	0x56, 0x16, 0x09, 0x55, 0x59, 0xfa, 0x04, 0x0a, 0xac, 0x92, 0x70, 0x67, 0x88, 0x7b, 0x41, 0x38,
+	0x66, 0x0d, 0x03, 0xc5, 0xbe, 0x63, 0xba, 0x86, 0xb7, 0x9c, 0x4f, 0xb0, 0x2f, 0x6f, 0x01, 0x65,
+	0x41, 0xec, 0x71, 0x9a, 0xf6, 0x0b, 0xa8, 0xc5, 0xa0, 0x25, 0xb1, 0xfb, 0x0c, 0x90, 0xf4, 0xb2,
+	0x23, 0x3d, 0xef, 0x84, 0x30, 0xab, 0xa7, 0x36, 0x1a, 0x9e, 0x8d, 0x42, 0x6e, 0x37, 0x52, 0xd1,
+	0xfe, 0x26, 0x03, 0xe8, 0x7c, 0xe9, 0x5e, 0x0f, 0x87, 0x9d, 0x35, 0xcd, 0x51, 0x60, 0xfa, 0x53,
+	0x1c, 0xc6, 0xc4, 0x9d, 0x80, 0x1c, 0x09, 0x31, 0x7e, 0x51, 0x21, 0x9e, 0xb5, 0xf4, 0x7d, 0x16,
+	0xce, 0xfc, 0x6c, 0xf3, 0x7a, 0x9c, 0xb4, 0x0e, 0x5a, 0xb9, 0xb5, 0xd0, 0xfa, 0x75, 0x16, 0x6a,
+	0x89, 0x75, 0xf1, 0x4c, 0xf3, 0x10, 0xca, 0x62, 0x49, 0x86, 0xe3, 0xd9, 0xf8, 0x35, 0x47, 0x57,
+	0x5e, 0x2f, 0x09, 0x5a, 0x97, 0x91, 0x6e, 0x3d, 0x9f, 0x64, 0xbf, 0xeb, 0xf9, 0x24, 0x97, 0x7e,
+	0x3e, 0x79, 0x21, 0xd2, 0x99, 0x4a, 0x0a, 0xdc, 0xe1, 0xb2, 0x6f, 0x7e, 0x7f, 0x8d, 0x07, 0xe2,
+	0x39, 0x85, 0xe7, 0xbb, 0x38, 0x01, 0xfd, 0x0c, 0x76, 0x7f, 0xd7, 0xbe, 0x60, 0xe7, 0x2a, 0x3e,
+	0xe4, 0x01, 0xb5, 0xf4, 0xcd, 0xc0, 0x21, 0x5e, 0x94, 0x32, 0x41, 0x91, 0x2e, 0xa9, 0xf6, 0x9f,
+	0xb9, 0xc4, 0x11, 0x0e, 0x97, 0xf3, 0xb9, 0xe9, 0xaf, 0xd0, 0x3e, 0xe4, 0x03, 0x12, 0x98, 0xae,
+	0x74, 0x92, 0x18, 0xa0, 0xf7, 0xa0, 0x48, 0x97, 0x96, 0x85, 0xb1, 0x8d, 0x6d, 0x79, 0x40, 0x11,
+	0x81, 0x3d, 0x51, 0xb1, 0xc9, 0x65, 0xe0, 0xe5, 0x75, 0x39, 0x62, 0x4e, 0xb5, 0x3d, 0xaa, 0x3a,
+	0x1c, 0x91, 0x0e, 0xf2, 0x7a, 0xc9, 0xf6, 0xa8, 0x5c, 0x3a, 0x65, 0x37, 0x61, 0x59, 0x4c, 0xd8,
+	0x42, 0x7d, 0x7c, 0xb5, 0xa4, 0x58, 0x5c, 0xed, 0xf3, 0x7a, 0x2d, 0xe2, 0xe8, 0x82, 0xc1, 0xc2,
+	0x42, 0x9e, 0xb8, 0xd8, 0x52, 0x5e, 0x0f, 0xc7, 0x2c, 0x2c, 0x78, 0xc7, 0xe0, 0xe3, 0x57, 0xd8,
+	0x0a, 0x64, 0xfa, 0xcf, 0xeb, 0x65, 0x46, 0xd4, 0x25, 0x8d, 0x55, 0x8a, 0xa8, 0x43, 0x73, 0xa8,
+	0x68, 0xd2, 0x44, 0x93, 0x92, 0xd7, 0x6b, 0xb2, 0x91, 0xbe, 0x0c, 0x19, 0xec, 0x91, 0x8e, 0x04,
+	0xac, 0x23, 0x0e, 0x37, 0x51, 0xe4, 0xa2, 0x3b, 0x9c, 0x1a, 0x6e, 0x23, 0xe5, 0x6d, 0x48, 0x7b,
+	0x9b, 0xed, 0x33, 0xea, 0x78, 0x42, 0x5b, 0x25, 0x39, 0xad, 0xe2, 0x84, 0xf6, 0xbe, 0x84, 0x63,
+	0x8f, 0x18, 0x74, 0xb9, 0x58, 0x10, 0x9f, 0xb7, 0x68, 0xbc, 0x15, 0xe2, 0x8f, 0x07, 0xb4, 0x5e,
+	0xe6, 0x5a, 0x87, 0x1e, 0x19, 0x2a, 0x7e, 0x83, 0xb5, 0x46, 0x82, 0xab, 0xfd, 0x63, 0x06, 0xf6,
+	0x92, 0x21, 0x20, 0x20, 0xd5, 0x80, 0x1d, 0x19, 0x04, 0xb1, 0x36, 0xb9, 0x74, 0x76, 0x12, 0x22,
+	0xea, 0x56, 0xdc, 0x74, 0x36, 0x74, 0x19, 0x37, 0x32, 0x8e, 0x7e, 0x02, 0xdb, 0x54, 0xc0, 0x44,
+	0xd6, 0x9a, 0x7b, 0xeb, 0x94, 0x25, 0x92, 0x3a, 0x1b, 0xba, 0x92, 0x3e, 0x2f, 0xc0, 0x96, 0x98,
+	0x54, 0x43, 0x50, 0xe5, 0xb7, 0xd1, 0xae, 0x77, 0x45, 0x64, 0xd6, 0xd0, 0xfe, 0x36, 0x03, 0xbb,
+	0x9c, 0x78, 0xbe, 0x74, 0x5c, 0x9b, 0x71, 0x50, 0x1d, 0xb6, 0x55, 0x6a, 0x14, 0xa5, 0x40, 0x0d,
+	0x19, 0xd6, 0x2c, 0x32, 0x9f, 0x3b, 0x81, 0x7a, 0x0e, 0x15, 0x23, 0x74, 0x0c, 0x85, 0xc9, 0xd2,
+	0x71, 0x03, 0xc3, 0x54, 0x7d, 0xfa, 0x36, 0x1f, 0x37, 0x62, 0x2c, 0xe2, 0xd5, 0x37, 0x63, 0xac,
+	0xbe, 0xc7, 0x63, 0x9a, 0x84, 0x59, 0x38, 0x2f, 0x63, 0x9a, 0xc8, 0x2c, 0xac, 0xfd, 0x45, 0x16,
+	0x6a, 0x7c, 0x65, 0x03, 0x99, 0xd3, 0xf9, 0xe2, 0xe2, 0xb9, 0x39, 0x93, 0xca, 0xcd, 0xbb, 0x90,
+	0x25, 0xaa, 0x3c, 0x65, 0x09, 0x4d, 0xd4, 0x87, 0x5c, 0xaa, 0x3e, 0x7c, 0x0c, 0x55, 0xf5, 0x3b,
+	0x5c, 0x82, 0x7c, 0x45, 0x55, 0x74, 0x55, 0x0e, 0x3e, 0x84, 0xdd, 0x6b, 0xec, 0x7b, 0xd8, 0x4d,
+	0xad, 0x75, 0x47, 0x50, 0x95, 0x98, 0x06, 0x65, 0xd3, 0xb7, 0x66, 0x4e, 0x80, 0xad, 0x60, 0xe9,
+	0xab, 0xa7, 0xd2, 0x04, 0x8d, 0x55, 0x25, 0x6b, 0xb1, 0xe4, 0x8d, 0x8f, 0xab, 0xaa, 0x92, 0xb5,
+	0x58, 0xb2, 0x9e, 0xc7, 0x55, 0x4c, 0x8b, 0x2c, 0xbd, 0x40, 0x06, 0x05, 0x63, 0x36, 0xd9, 0x58,
+	0xfb, 0x87, 0x1c, 0x20, 0xee, 0x0d, 0x1d, 0x8b, 0x77, 0x90, 0x31, 0x35, 0xa7, 0x98, 0x5d, 0x3f,
+	0xe6, 0x78, 0x4e, 0xfc, 0x95, 0xc1, 0x73, 0x85, 0x31, 0x59, 0x05, 0x58, 0x5c, 0xa8, 0x37, 0xf5,
+	0xaa, 0xe0, 0x8c, 0x18, 0xe3, 0x9c, 0xd1, 0xd9, 0x5b, 0x96, 0x94, 0x36, 0x6f, 0x4c, 0xc7, 0xe5,
+	0x4d, 0x98, 0xd0, 0xc8, 0x72, 0x8d, 0x7d, 0xc1, 0x6d, 0x28, 0xa6, 0xd0, 0xfa, 0x14, 0xf6, 0xa4,
+	0x16, 0x4b, 0x03, 0xec, 0x21, 0xc1, 0xc2, 0xf2, 0x42, 0x96, 0xd1, 0x6b, 0x82, 0x35, 0xa6, 0xd8,
+	0x1e, 0x08, 0x06, 0x7b, 0x11, 0xb1, 0x1d, 0x7a, 0x9d, 0x58, 0xd1, 0x26, 0xb7, 0xcf, 0xea, 0xfd,
+	0x75, 0x6c, 0x3d, 0x1f, 0xf1, 0xc6, 0xe0, 0xda, 0xb8, 0xf2, 0xb1, 0x5a, 0x48, 0x9e, 0x0b, 0xee,
+	0x30, 0xf2, 0x53, 0x1f, 0xcb, 0x15, 0x3c, 0x86, 0x1a, 0x97, 0x4b, 0xcc, 0xbf, 0xc5, 0xe7, 0xe7,
+	0x06, 0xe2, 0xb3, 0x7f, 0x00, 0xbb, 0x2e, 0x31, 0x6d, 0xc3, 0xbc, 0xc1, 0xbe, 0x39, 0xc5, 0xc6,
+	0x0f, 0xb9, 0x9f, 0x33, 0x7a, 0x99, 0x51, 0x1b, 0x82, 0xf8, 0xc3, 0x5b, 0x52, 0x4f, 0xea, 0x85,
+	0x5b, 0x52, 0x4f, 0xd8, 0xfa, 0x92, 0xb6, 0x9e, 0xf0, 0x0c, 0x94, 0xd1, 0x77, 0xe2, 0xc6, 0x9e,
+	0x68, 0xbf, 0xc9, 0xc0, 0x2e, 0x7b, 0xe7, 0xf0, 0x58, 0xcf, 0x6c, 0x5d, 0x63, 0xcf, 0x46, 0x08,
+	0x36, 0x63, 0x18, 0xe5, 0xbf, 0xe3, 0x81, 0x95, 0x4d, 0x06, 0xd6, 0x7b, 0x50, 0x0c, 0x4f, 0x44,
+	0x36, 0x50, 0x11, 0x81, 0x3d, 0x6c, 0xb9, 0x8e, 0x85, 0x3d, 0x8a, 0x0d, 0xfc, 0x7a, 0xe1, 0xf8,
+	0x98, 0x1a, 0x4b, 0xcf, 0x79, 0x2d, 0x3b, 0x3c, 0x24, 0x79, 0x6d, 0xc1, 0x1a, 0x7b, 0xce, 0x6b,
+	0xf4, 0x23, 0x38, 0x54, 0x1a, 0xb6, 0xb9, 0xa2, 0xec, 0x9a, 0xcf, 0x35, 0x57, 0x32, 0xbb, 0xef,
+	0x49, 0x6e, 0xcb, 0x5c, 0xd1, 0x11, 0xe1, 0x9a, 0x2b, 0xed, 0x4f, 0x01, 0xf4, 0xa5, 0xe7, 0x39,
+	0xde, 0xf4, 0x39, 0x99, 0xdc, 0xf5, 0x8a, 0x58, 0x8f, 0xbf, 0x22, 0x32, 0x53, 0x6a, 0xc8, 0x0a,
+	0x0e, 0x0d, 0x4c, 0x9e, 0x31, 0xf9, 0xea, 0x44, 0x93, 0x50, 0x92, 0x34, 0xb6, 0x2c, 0xed, 0xef,
+	0x54, 0xb2, 0x79, 0x4e, 0x26, 0x1c, 0xd6, 0x94, 0xd9, 0xf3, 0xc5, 0xa4, 0xb2, 0xe8, 0xa9, 0x21,
+	0xf3, 0x89, 0x45, 0xe6, 0x0b, 0x17, 0x07, 0xb2, 0xec, 0xe5, 0xf4, 0x88, 0x90, 0x2a, 0x7b, 0xb9,
+	0xb0, 0xec, 0xfd, 0x18, 0xca, 0xd2, 0x80, 0xf1, 0x8a, 0x4c, 0x18, 0xf0, 0x92, 0x6d, 0x50, 0xb4,
+	0x43, 0xbd, 0xe4, 0x87, 0xbf, 0xa9, 0xf6, 0x2f, 0x39, 0x99, 0x6d, 0x44, 0x72, 0x94, 0x79, 0xfb,
+	0x13, 0xc8, 0xb3, 0x6c, 0x65, 0xcb, 0x7c, 0x7d, 0x14, 0x9a, 0x49, 0xa6, 0x4c, 0x5d, 0x48, 0xa1,
+	0x1f, 0xc7, 0x12, 0x4e, 0x36, 0x95, 0xe1, 0x6f, 0xa5, 0xb2, 0x58, 0x32, 0xfa, 0x12, 0x8a, 0xbe,
+	0x0c, 0x6b, 0x75, 0x0f, 0xb8, 0x97, 0x54, 0x4c, 0x44, 0xbd, 0x1e, 0x49, 0xa3, 0x73, 0xa8, 0x52,
+	0x81, 0x3c, 0x63, 0x22, 0xa0, 0xa7, 0xf6, 0x7c, 0x94, 0x78, 0xe2, 0x8b, 0xa0, 0x29, 0xde, 0xf6,
+	0xa2, 0x31, 0x45, 0x7f, 0x00, 0x9b, 0xdc, 0x57, 0xf9, 0x75, 0x9b, 0x0c, 0x8f, 0x4a, 0xe7, 0x42,
+	0xa8, 0x03, 0x0f, 0xe5, 0xad, 0x31, 0xf6, 0x9a, 0x9d, 0x46, 0x99, 0x68, 0x0f, 0xee, 0x0b, 0xc1,
+	0xd8, 0x87, 0x9a, 0x38, 0xde, 0x58, 0x5e, 0x5d, 0x2e, 0x58, 0x07, 0x11, 0xf6, 0x95, 0xdb, 0xfc,
+	0x28, 0x77, 0x04, 0x55, 0xb6, 0x95, 0x2c, 0x8b, 0xdb, 0xbe, 0xe9, 0x70, 0x88, 0x88, 0x9b, 0x62,
+	0x38, 0xd6, 0x7e, 0x06, 0xe5, 0x16, 0xfb, 0xad, 0x7a, 0xe0, 0xcf, 0x61, 0x7f, 0xea, 0x9b, 0x16,
+	0x66, 0x49, 0xc2, 0x21, 0x76, 0x68, 0x38, 0x23, 0x22, 0x85, 0xf3, 0x06, 0x9c, 0xa5, 0x9a, 0xd6,
+	0x3f, 0xcb, 0xc0, 0x8e, 0x34, 0x21, 0xcf, 0x3c, 0x3e, 0x5f, 0x26, 0x39, 0x1f, 0x6b, 0x73, 0x6c,
+	0x6c, 0xda, 0xae, 0xe3, 0x61, 0x01, 0x72, 0x81, 0xcb, 0xb2, 0x22, 0xf2, 0xe0, 0x4b, 0x43, 0x30,
+	0xf7, 0x3d, 0x21, 0xf8, 0xab, 0x8c, 0x84, 0xa0, 0x8e, 0xa7, 0x0e, 0x0d, 0x44, 0xfb, 0xc2, 0xaa,
+	0x64, 0xec, 0x65, 0x59, 0xc4, 0x62, 0xd1, 0x0c, 0x1f, 0x95, 0x3f, 0x84, 0x5d, 0xd3, 0xbe, 0x61,
+	0x1e, 0xa6, 0x98, 0xdf, 0xd0, 0x64, 0x6a, 0xd9, 0x09, 0xa9, 0xec, 0x96, 0x86, 0xbe, 0x54, 0x56,
+	0x1c, 0xef, 0x8a, 0xd4, 0x73, 0xeb, 0xb0, 0x19, 0x07, 0xbe, 0x9c, 0x81, 0x91, 0xb4, 0x00, 0x8e,
+	0x6f, 0xad, 0x2a, 0x74, 0xd6, 0x31, 0x14, 0xa4, 0x5d, 0x95, 0x27, 0xc4, 0xf7, 0xd7, 0xae, 0x8d,
+	0xfe, 0x08, 0x4e, 0x66, 0xd8, 0xf4, 0x83, 0x09, 0x36, 0x03, 0xf1, 0x31, 0x8e, 0x3f, 0xe2, 0xcb,
+	0x13, 0x11, 0x8e, 0xab, 0x87, 0x12, 0x5d, 0x29, 0xa0, 0xce, 0xe5, 0x57, 0x59, 0x99, 0x2a, 0x3a,
+	0x4a, 0xe2, 0x6d, 0x73, 0x25, 0x9d, 0x94, 0x4d, 0x3b, 0xe9, 0x1d, 0xe2, 0xeb, 0x7d, 0x28, 0xfd,
+	0x72, 0x89, 0x97, 0xd8, 0xb0, 0xf1, 0x22, 0x98, 0xc9, 0x2e, 0x1a, 0x38, 0xa9, 0xc5, 0x28, 0xb7,
+	0x4e, 0x3b, 0xff, 0xfd, 0x4e, 0x3b, 0x01, 0xb3, 0xad, 0x14, 0xcc, 0xea, 0xb0, 0xcd, 0x42, 0x87,
+	0xb1, 0xc4, 0x33, 0x8a, 0x1a, 0x6a, 0x75, 0x38, 0x4c, 0x7a, 0x45, 0x9d, 0x84, 0xd6, 0x87, 0x3d,
+	0xce, 0x69, 0x61, 0x3f, 0x0e, 0x9f, 0xff, 0xb3, 0xd3, 0xb4, 0xfb, 0x70, 0x6f, 0x8d, 0x41, 0x35,
+	0xdf, 0x63, 0x1b, 0xca, 0x89, 0x0f, 0x8c, 0x87, 0x80, 0x5e, 0xb6, 0x7b, 0xad, 0xbe, 0x6e, 0x8c,
+	0x7b, 0xc3, 0x41, 0xbb, 0xd9, 0x7d, 0xda, 0x6d, 0xb7, 0xaa, 0x1b, 0xa8, 0x08, 0xf9, 0x66, 0x77,
+	0xd8, 0xec, 0x57, 0x33, 0xa8, 0x04, 0xdb, 0xcf, 0xc7, 0xbd, 0xee, 0xa0, 0xad, 0x57, 0xb3, 0x08,
+	0x60, 0xab, 0xa1, 0x77, 0x87, 0xa3, 0x46, 0x35, 0x87, 0x76, 0xa0, 0x38, 0x68, 0x5c, 0xf4, 0x8d,
+	0xc6, 0xc5, 0xa8, 0x5f, 0xdd, 0x64, 0x2a, 0x17, 0xdd, 0xde, 0xf8, 0xeb, 0x6a, 0xfe, 0x71, 0x13,
+	0x2a, 0xa9, 0x0f, 0x7a, 0x08, 0xc1, 0xee, 0x40, 0xef, 0xbe, 0xec, 0x5e, 0xb4, 0x9f, 0xb5, 0x8d,
+	0x5e, 0xbf, 0xd7, 0xae, 0x6e, 0x30, 0x63, 0xed, 0x5e, 0xe3, 0xfc, 0xa2, 0x5d, 0xcd, 0xa0, 0x02,
+	0x6c, 0x0e, 0xc7, 0xad, 0x7e, 0x35, 0x8b, 0xb6, 0x20, 0x3b, 0x1c, 0x57, 0x73, 0x8f, 0xff, 0x3e,
+	0x0b, 0xd5, 0xf4, 0x95, 0x0d, 0xed, 0x73, 0x9a, 0xf1, 0xb4, 0xd1, 0xbd, 0x18, 0xeb, 0xa1, 0xa1,
+	0x3d, 0xa8, 0xc4, 0xa9, 0xad, 0xde, 0xb0, 0x9a, 0x41, 0x1a, 0x9c, 0xc6, 0x89, 0xcd, 0x7e, 0xaf,
+	0xd7, 0x6e, 0x8e, 0xba, 0xfd, 0x9e, 0xa1, 0xb7, 0x9f, 0x8e, 0x87, 0xed, 0x56, 0x35, 0x8b, 0x8e,
+	0x60, 0x2f, 0x2e, 0x33, 0xea, 0x5e, 0xb6, 0xfb, 0xe3, 0x51, 0x35, 0x87, 0xee, 0xc3, 0x71, 0x9c,
+	0xd1, 0x18, 0x8f, 0x3a, 0x86, 0xde, 0x7e, 0xde, 0x6e, 0x8e, 0xda, 0xad, 0xea, 0x26, 0x7a, 0x08,
+	0xf7, 0xe3, 0xec, 0x4e, 0x7f, 0x38, 0x32, 0x5e, 0xb4, 0xff, 0xc4, 0xb8, 0xec, 0x0e, 0x2f, 0x1b,
+	0xa3, 0x66, 0xa7, 0x9a, 0x47, 0x07, 0x50, 0x8b, 0x8b, 0xf4, 0x47, 0x9d, 0xb6, 0x5e, 0xdd, 0x42,
+	0xc7, 0x70, 0x10, 0x27, 0x77, 0x1a, 0xbd, 0xd6, 0xb0, 0xd3, 0x78, 0xd1, 0xae, 0x6e, 0xa3, 0x8f,
+	0xe1, 0xc3, 0xe4, 0xde, 0x8c, 0xe1, 0x78, 0x30, 0xe8, 0xeb, 0xa3, 0x76, 0x4b, 0x2c, 0xe0, 0xb2,
+	0x3d, 0xea, 0xf4, 0x5b, 0xc3, 0x6a, 0xe1, 0xec, 0x5f, 0x37, 0xa1, 0xf6, 0x32, 0xfc, 0x4e, 0xc5,
+	0xde, 0xfd, 0xd8, 0x27, 0xc9, 0x2e, 0x54, 0x78, 0x71, 0x63, 0x95, 0xa3, 0x49, 0xbc, 0x2b, 0x67,
+	0x8a, 0xf6, 0x13, 0xe5, 0x44, 0x26, 0xdc, 0x93, 0xf7, 0x52, 0xd4, 0xc4, 0x77, 0x34, 0x6d, 0xe3,
+	0xf3, 0x0c, 0xfa, 0x9a, 0x3b, 0x46, 0xbe, 0x4f, 0x3a, 0x37, 0x4e, 0xc0, 0x6f, 0xdf, 0xe8, 0xf4,
+	0xce, 0x6b, 0xb9, 0x30, 0xfc, 0x5d, 0xd7, 0x76, 0x6d, 0x03, 0x0d, 0xe1, 0x50, 0x3e, 0x84, 0xa5,
+	0x8d, 0x9f, 0xac, 0x7d, 0x09, 0x14, 0x86, 0xef, 0xad, 0xe5, 0x85, 0x46, 0x9f, 0x43, 0x45, 0x3d,
+	0xdd, 0xb4, 0x64, 0x63, 0x13, 0x7b, 0x57, 0x4c, 0xbd, 0x17, 0x9e, 0x9c, 0xac, 0x63, 0x85, 0xb6,
+	0xbe, 0x86, 0x23, 0x79, 0x35, 0xbb, 0xb5, 0xc2, 0x7b, 0xeb, 0x6f, 0x7e, 0x69, 0xa7, 0xae, 0xb9,
+	0x4b, 0x72, 0xa7, 0x3e, 0x83, 0xf2, 0x33, 0x1c, 0x84, 0x69, 0x3b, 0xb6, 0xc4, 0xf4, 0x05, 0xef,
+	0xe4, 0x2d, 0x59, 0x5e, 0xdb, 0x40, 0x7f, 0x08, 0x79, 0x5e, 0xfd, 0x50, 0xec, 0xa9, 0x2d, 0x56,
+	0x50, 0x4f, 0x0e, 0xd3, 0x64, 0xa5, 0x79, 0xf6, 0x97, 0x59, 0x38, 0xe4, 0xc0, 0x69, 0x12, 0x2f,
+	0xf0, 0x89, 0xeb, 0x62, 0x5f, 0xa1, 0xe7, 0x8f, 0x61, 0x47, 0x14, 0x0b, 0xec, 0xf3, 0x39, 0xd1,
+	0x49, 0x3a, 0xd9, 0x46, 0xf9, 0xe4, 0x44, 0xbb, 0x9b, 0x17, 0x5b, 0xe7, 0x33, 0x28, 0x46, 0x85,
+	0x20, 0xd5, 0xa1, 0x84, 0x8c, 0x93, 0xf7, 0xef, 0x60, 0xc4, 0x0c, 0x7d, 0x05, 0x15, 0x95, 0xd0,
+	0xd4, 0xea, 0xde, 0x4b, 0x6a, 0x25, 0xf3, 0xdd, 0xc9, 0x07, 0x6f, 0xe3, 0x46, 0x86, 0xcf, 0x1f,
+	0xfe, 0xc7, 0x9b, 0xd3, 0xcc, 0x6f, 0xdf, 0x9c, 0x66, 0xfe, 0xfb, 0xcd, 0x69, 0xe6, 0xaf, 0xbf,
+	0x3d, 0xdd, 0xf8, 0xed, 0xb7, 0xa7, 0x1b, 0xff, 0xf5, 0xed, 0xe9, 0xc6, 0xcf, 0xd5, 0x3f, 0x92,
+	0x26, 0x5b, 0xfc, 0x1f, 0x4a, 0x3f, 0xfa, 0xdf, 0x01, 0x00, 0x45, 0x1e, 0x8d, 0x4a, 0xb8, 0x24,
+	0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// VscanAgentServiceClient is the client API for VscanAgentService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type VscanAgentServiceClient interface {
+	BuildScanConfig(ctx context.Context, in *ScanRequest, opts ...grpc.CallOption) (VscanAgentService_BuildScanConfigClient, error)
+	SSHConnectivityTest(ctx context.Context, in *SSHGatewayTestRequest, opts ...grpc.CallOption) (*SSHGatewayTestResponse, error)
+	DeviceConnectivityTest(ctx context.Context, in *DeviceTestRequest, opts ...grpc.CallOption) (*DeviceTestResponse, error)
+	DiscoverDevices(ctx context.Context, in *DiscoveryRequest, opts ...grpc.CallOption) (*DiscoveryResponse, error)
+	BulkSSHConnectivityTest(ctx context.Context, in *BulkSSHTestRequest, opts ...grpc.CallOption) (VscanAgentService_BulkSSHConnectivityTestClient, error)
+	GetAgentInfo(ctx context.Context, in *AgentInfoRequest, opts ...grpc.CallOption) (*AgentInfoResponse, error)
+	Drain(ctx context.Context, in *DrainRequest, opts ...grpc.CallOption) (*DrainResponse, error)
+}
+
+type vscanAgentServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewVscanAgentServiceClient(cc *grpc.ClientConn) VscanAgentServiceClient {
+	return &vscanAgentServiceClient{cc}
+}
+
+func (c *vscanAgentServiceClient) BuildScanConfig(ctx context.Context, in *ScanRequest, opts ...grpc.CallOption) (VscanAgentService_BuildScanConfigClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_VscanAgentService_serviceDesc.Streams[0], "/agentpb.VscanAgentService/BuildScanConfig", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &vscanAgentServiceBuildScanConfigClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type VscanAgentService_BuildScanConfigClient interface {
+	Recv() (*ScanResultsResponse, error)
+	grpc.ClientStream
+}
+
+type vscanAgentServiceBuildScanConfigClient struct {
+	grpc.ClientStream
+}
+
+func (x *vscanAgentServiceBuildScanConfigClient) Recv() (*ScanResultsResponse, error) {
+	m := new(ScanResultsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *vscanAgentServiceClient) SSHConnectivityTest(ctx context.Context, in *SSHGatewayTestRequest, opts ...grpc.CallOption) (*SSHGatewayTestResponse, error) {
+	out := new(SSHGatewayTestResponse)
+	err := c.cc.Invoke(ctx, "/agentpb.VscanAgentService/SSHConnectivityTest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vscanAgentServiceClient) DeviceConnectivityTest(ctx context.Context, in *DeviceTestRequest, opts ...grpc.CallOption) (*DeviceTestResponse, error) {
+	out := new(DeviceTestResponse)
+	err := c.cc.Invoke(ctx, "/agentpb.VscanAgentService/DeviceConnectivityTest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vscanAgentServiceClient) DiscoverDevices(ctx context.Context, in *DiscoveryRequest, opts ...grpc.CallOption) (*DiscoveryResponse, error) {
+	out := new(DiscoveryResponse)
+	err := c.cc.Invoke(ctx, "/agentpb.VscanAgentService/DiscoverDevices", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vscanAgentServiceClient) BulkSSHConnectivityTest(ctx context.Context, in *BulkSSHTestRequest, opts ...grpc.CallOption) (VscanAgentService_BulkSSHConnectivityTestClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_VscanAgentService_serviceDesc.Streams[1], "/agentpb.VscanAgentService/BulkSSHConnectivityTest", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &vscanAgentServiceBulkSSHConnectivityTestClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type VscanAgentService_BulkSSHConnectivityTestClient interface {
+	Recv() (*BulkSSHTestResponse, error)
+	grpc.ClientStream
+}
+
+type vscanAgentServiceBulkSSHConnectivityTestClient struct {
+	grpc.ClientStream
+}
+
+func (x *vscanAgentServiceBulkSSHConnectivityTestClient) Recv() (*BulkSSHTestResponse, error) {
+	m := new(BulkSSHTestResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *vscanAgentServiceClient) GetAgentInfo(ctx context.Context, in *AgentInfoRequest, opts ...grpc.CallOption) (*AgentInfoResponse, error) {
+	out := new(AgentInfoResponse)
+	err := c.cc.Invoke(ctx, "/agentpb.VscanAgentService/GetAgentInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vscanAgentServiceClient) Drain(ctx context.Context, in *DrainRequest, opts ...grpc.CallOption) (*DrainResponse, error) {
+	out := new(DrainResponse)
+	err := c.cc.Invoke(ctx, "/agentpb.VscanAgentService/Drain", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VscanAgentServiceServer is the server API for VscanAgentService service.
+type VscanAgentServiceServer interface {
+	BuildScanConfig(*ScanRequest, VscanAgentService_BuildScanConfigServer) error
+	SSHConnectivityTest(context.Context, *SSHGatewayTestRequest) (*SSHGatewayTestResponse, error)
+	DeviceConnectivityTest(context.Context, *DeviceTestRequest) (*DeviceTestResponse, error)
+	DiscoverDevices(context.Context, *DiscoveryRequest) (*DiscoveryResponse, error)
+	BulkSSHConnectivityTest(*BulkSSHTestRequest, VscanAgentService_BulkSSHConnectivityTestServer) error
+	GetAgentInfo(context.Context, *AgentInfoRequest) (*AgentInfoResponse, error)
+	Drain(context.Context, *DrainRequest) (*DrainResponse, error)
+}
+
+// UnimplementedVscanAgentServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedVscanAgentServiceServer struct {
+}
+
+func (*UnimplementedVscanAgentServiceServer) BuildScanConfig(req *ScanRequest, srv VscanAgentService_BuildScanConfigServer) error {
+	return status.Errorf(codes.Unimplemented, "method BuildScanConfig not implemented")
+}
+func (*UnimplementedVscanAgentServiceServer) SSHConnectivityTest(ctx context.Context, req *SSHGatewayTestRequest) (*SSHGatewayTestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SSHConnectivityTest not implemented")
+}
+func (*UnimplementedVscanAgentServiceServer) DeviceConnectivityTest(ctx context.Context, req *DeviceTestRequest) (*DeviceTestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeviceConnectivityTest not implemented")
+}
+func (*UnimplementedVscanAgentServiceServer) DiscoverDevices(ctx context.Context, req *DiscoveryRequest) (*DiscoveryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiscoverDevices not implemented")
+}
+func (*UnimplementedVscanAgentServiceServer) BulkSSHConnectivityTest(req *BulkSSHTestRequest, srv VscanAgentService_BulkSSHConnectivityTestServer) error {
+	return status.Errorf(codes.Unimplemented, "method BulkSSHConnectivityTest not implemented")
+}
+func (*UnimplementedVscanAgentServiceServer) GetAgentInfo(ctx context.Context, req *AgentInfoRequest) (*AgentInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAgentInfo not implemented")
+}
+func (*UnimplementedVscanAgentServiceServer) Drain(ctx context.Context, req *DrainRequest) (*DrainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Drain not implemented")
+}
+
+func RegisterVscanAgentServiceServer(s *grpc.Server, srv VscanAgentServiceServer) {
+	s.RegisterService(&_VscanAgentService_serviceDesc, srv)
+}
+
+func _VscanAgentService_BuildScanConfig_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ScanRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VscanAgentServiceServer).BuildScanConfig(m, &vscanAgentServiceBuildScanConfigServer{stream})
+}
+
+type VscanAgentService_BuildScanConfigServer interface {
+	Send(*ScanResultsResponse) error
+	grpc.ServerStream
+}
+
+type vscanAgentServiceBuildScanConfigServer struct {
+	grpc.ServerStream
+}
+
+func (x *vscanAgentServiceBuildScanConfigServer) Send(m *ScanResultsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _VscanAgentService_SSHConnectivityTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SSHGatewayTestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VscanAgentServiceServer).SSHConnectivityTest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agentpb.VscanAgentService/SSHConnectivityTest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VscanAgentServiceServer).SSHConnectivityTest(ctx, req.(*SSHGatewayTestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VscanAgentService_DeviceConnectivityTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceTestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VscanAgentServiceServer).DeviceConnectivityTest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agentpb.VscanAgentService/DeviceConnectivityTest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VscanAgentServiceServer).DeviceConnectivityTest(ctx, req.(*DeviceTestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VscanAgentService_DiscoverDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiscoveryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VscanAgentServiceServer).DiscoverDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agentpb.VscanAgentService/DiscoverDevices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VscanAgentServiceServer).DiscoverDevices(ctx, req.(*DiscoveryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VscanAgentService_BulkSSHConnectivityTest_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BulkSSHTestRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VscanAgentServiceServer).BulkSSHConnectivityTest(m, &vscanAgentServiceBulkSSHConnectivityTestServer{stream})
+}
+
+type VscanAgentService_BulkSSHConnectivityTestServer interface {
+	Send(*BulkSSHTestResponse) error
+	grpc.ServerStream
+}
+
+type vscanAgentServiceBulkSSHConnectivityTestServer struct {
+	grpc.ServerStream
+}
+
+func (x *vscanAgentServiceBulkSSHConnectivityTestServer) Send(m *BulkSSHTestResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _VscanAgentService_GetAgentInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VscanAgentServiceServer).GetAgentInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agentpb.VscanAgentService/GetAgentInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VscanAgentServiceServer).GetAgentInfo(ctx, req.(*AgentInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VscanAgentService_Drain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DrainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VscanAgentServiceServer).Drain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agentpb.VscanAgentService/Drain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VscanAgentServiceServer).Drain(ctx, req.(*DrainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _VscanAgentService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "agentpb.VscanAgentService",
+	HandlerType: (*VscanAgentServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SSHConnectivityTest",
+			Handler:    _VscanAgentService_SSHConnectivityTest_Handler,
+		},
+		{
+			MethodName: "DeviceConnectivityTest",
+			Handler:    _VscanAgentService_DeviceConnectivityTest_Handler,
+		},
+		{
+			MethodName: "DiscoverDevices",
+			Handler:    _VscanAgentService_DiscoverDevices_Handler,
+		},
+		{
+			MethodName: "GetAgentInfo",
+			Handler:    _VscanAgentService_GetAgentInfo_Handler,
+		},
+		{
+			MethodName: "Drain",
+			Handler:    _VscanAgentService_Drain_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "BuildScanConfig",
+			Handler:       _VscanAgentService_BuildScanConfig_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "BulkSSHConnectivityTest",
+			Handler:       _VscanAgentService_BulkSSHConnectivityTest_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto/agentpb.proto",
+}
+
+// VscanControllerServiceClient is the client API for VscanControllerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type VscanControllerServiceClient interface {
+	RegisterAgent(ctx context.Context, in *AgentRegistration, opts ...grpc.CallOption) (*AgentRegistrationResponse, error)
+	Heartbeat(ctx context.Context, in *AgentHeartbeat, opts ...grpc.CallOption) (*AgentHeartbeatResponse, error)
+	DeregisterAgent(ctx context.Context, in *AgentDeregistration, opts ...grpc.CallOption) (*AgentDeregistrationResponse, error)
+}
+
+type vscanControllerServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewVscanControllerServiceClient(cc *grpc.ClientConn) VscanControllerServiceClient {
+	return &vscanControllerServiceClient{cc}
+}
+
+func (c *vscanControllerServiceClient) RegisterAgent(ctx context.Context, in *AgentRegistration, opts ...grpc.CallOption) (*AgentRegistrationResponse, error) {
+	out := new(AgentRegistrationResponse)
+	err := c.cc.Invoke(ctx, "/agentpb.VscanControllerService/RegisterAgent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vscanControllerServiceClient) Heartbeat(ctx context.Context, in *AgentHeartbeat, opts ...grpc.CallOption) (*AgentHeartbeatResponse, error) {
+	out := new(AgentHeartbeatResponse)
+	err := c.cc.Invoke(ctx, "/agentpb.VscanControllerService/Heartbeat", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vscanControllerServiceClient) DeregisterAgent(ctx context.Context, in *AgentDeregistration, opts ...grpc.CallOption) (*AgentDeregistrationResponse, error) {
+	out := new(AgentDeregistrationResponse)
+	err := c.cc.Invoke(ctx, "/agentpb.VscanControllerService/DeregisterAgent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VscanControllerServiceServer is the server API for VscanControllerService service.
+type VscanControllerServiceServer interface {
+	RegisterAgent(context.Context, *AgentRegistration) (*AgentRegistrationResponse, error)
+	Heartbeat(context.Context, *AgentHeartbeat) (*AgentHeartbeatResponse, error)
+	DeregisterAgent(context.Context, *AgentDeregistration) (*AgentDeregistrationResponse, error)
+}
+
+// UnimplementedVscanControllerServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedVscanControllerServiceServer struct {
+}
+
+func (*UnimplementedVscanControllerServiceServer) RegisterAgent(ctx context.Context, req *AgentRegistration) (*AgentRegistrationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterAgent not implemented")
+}
+func (*UnimplementedVscanControllerServiceServer) Heartbeat(ctx context.Context, req *AgentHeartbeat) (*AgentHeartbeatResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Heartbeat not implemented")
+}
+func (*UnimplementedVscanControllerServiceServer) DeregisterAgent(ctx context.Context, req *AgentDeregistration) (*AgentDeregistrationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeregisterAgent not implemented")
+}
+
+func RegisterVscanControllerServiceServer(s *grpc.Server, srv VscanControllerServiceServer) {
+	s.RegisterService(&_VscanControllerService_serviceDesc, srv)
+}
+
+func _VscanControllerService_RegisterAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentRegistration)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VscanControllerServiceServer).RegisterAgent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agentpb.VscanControllerService/RegisterAgent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VscanControllerServiceServer).RegisterAgent(ctx, req.(*AgentRegistration))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VscanControllerService_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentHeartbeat)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VscanControllerServiceServer).Heartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agentpb.VscanControllerService/Heartbeat",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VscanControllerServiceServer).Heartbeat(ctx, req.(*AgentHeartbeat))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VscanControllerService_DeregisterAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentDeregistration)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VscanControllerServiceServer).DeregisterAgent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agentpb.VscanControllerService/DeregisterAgent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VscanControllerServiceServer).DeregisterAgent(ctx, req.(*AgentDeregistration))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _VscanControllerService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "agentpb.VscanControllerService",
+	HandlerType: (*VscanControllerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RegisterAgent",
+			Handler:    _VscanControllerService_RegisterAgent_Handler,
+		},
+		{
+			MethodName: "Heartbeat",
+			Handler:    _VscanControllerService_Heartbeat_Handler,
+		},
+		{
+			MethodName: "DeregisterAgent",
+			Handler:    _VscanControllerService_DeregisterAgent_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/agentpb.proto",
+}
+
+func (m *KeyboardInteractiveAnswer) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *KeyboardInteractiveAnswer) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *KeyboardInteractiveAnswer) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Answer) > 0 {
+		i -= len(m.Answer)
+		copy(dAtA[i:], m.Answer)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.Answer)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.PromptPattern) > 0 {
+		i -= len(m.PromptPattern)
+		copy(dAtA[i:], m.PromptPattern)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.PromptPattern)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SSHGateway) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SSHGateway) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SSHGateway) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.GatewayProxyUrl) > 0 {
+		i -= len(m.GatewayProxyUrl)
+		copy(dAtA[i:], m.GatewayProxyUrl)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.GatewayProxyUrl)))
+		i--
+		dAtA[i] = 0x52
+	}
+	if len(m.GatewayKeyboardInteractiveAnswers) > 0 {
+		for iNdEx := len(m.GatewayKeyboardInteractiveAnswers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.GatewayKeyboardInteractiveAnswers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAgentpb(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x4a
+		}
+	}
+	if len(m.GatewayCertificate) > 0 {
+		i -= len(m.GatewayCertificate)
+		copy(dAtA[i:], m.GatewayCertificate)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.GatewayCertificate)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if len(m.GatewayHostKeyFingerprints) > 0 {
+		for iNdEx := len(m.GatewayHostKeyFingerprints) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.GatewayHostKeyFingerprints[iNdEx])
+			copy(dAtA[i:], m.GatewayHostKeyFingerprints[iNdEx])
+			i = encodeVarintAgentpb(dAtA, i, uint64(len(m.GatewayHostKeyFingerprints[iNdEx])))
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if len(m.GatewayPrivateKeyPassphrase) > 0 {
+		i -= len(m.GatewayPrivateKeyPassphrase)
+		copy(dAtA[i:], m.GatewayPrivateKeyPassphrase)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.GatewayPrivateKeyPassphrase)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.GatewayPrivateKey) > 0 {
+		i -= len(m.GatewayPrivateKey)
+		copy(dAtA[i:], m.GatewayPrivateKey)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.GatewayPrivateKey)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.GatewayPassword) > 0 {
+		i -= len(m.GatewayPassword)
+		copy(dAtA[i:], m.GatewayPassword)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.GatewayPassword)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.GatewayUsername) > 0 {
+		i -= len(m.GatewayUsername)
+		copy(dAtA[i:], m.GatewayUsername)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.GatewayUsername)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.GatewayIp) > 0 {
+		i -= len(m.GatewayIp)
+		copy(dAtA[i:], m.GatewayIp)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.GatewayIp)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.GatewayName) > 0 {
+		i -= len(m.GatewayName)
+		copy(dAtA[i:], m.GatewayName)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.GatewayName)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *UserDeviceCredentials) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UserDeviceCredentials) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UserDeviceCredentials) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Certificate) > 0 {
+		i -= len(m.Certificate)
+		copy(dAtA[i:], m.Certificate)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.Certificate)))
+		i--
+		dAtA[i] = 0x5a
+	}
+	if len(m.PrivateKeyPassphrase) > 0 {
+		i -= len(m.PrivateKeyPassphrase)
+		copy(dAtA[i:], m.PrivateKeyPassphrase)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.PrivateKeyPassphrase)))
+		i--
+		dAtA[i] = 0x52
+	}
+	if len(m.PrivilegePassword) > 0 {
+		i -= len(m.PrivilegePassword)
+		copy(dAtA[i:], m.PrivilegePassword)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.PrivilegePassword)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if m.PrivilegeMethod != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.PrivilegeMethod))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.DeviceVendor != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.DeviceVendor))
+		i--
+		dAtA[i] = 0x38
+	}
+	if len(m.PrivateKey) > 0 {
+		i -= len(m.PrivateKey)
+		copy(dAtA[i:], m.PrivateKey)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.PrivateKey)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.IosEnablePassword) > 0 {
+		i -= len(m.IosEnablePassword)
+		copy(dAtA[i:], m.IosEnablePassword)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.IosEnablePassword)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Password) > 0 {
+		i -= len(m.Password)
+		copy(dAtA[i:], m.Password)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.Password)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Username) > 0 {
+		i -= len(m.Username)
+		copy(dAtA[i:], m.Username)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.Username)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.CredentialsDeviceVendor) > 0 {
+		i -= len(m.CredentialsDeviceVendor)
+		copy(dAtA[i:], m.CredentialsDeviceVendor)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.CredentialsDeviceVendor)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.CredentialsName) > 0 {
+		i -= len(m.CredentialsName)
+		copy(dAtA[i:], m.CredentialsName)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.CredentialsName)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Device) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Device) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Device) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.IpAddress) > 0 {
+		i -= len(m.IpAddress)
+		copy(dAtA[i:], m.IpAddress)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.IpAddress)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.DeviceName) > 0 {
+		i -= len(m.DeviceName)
+		copy(dAtA[i:], m.DeviceName)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.DeviceName)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ScanRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ScanRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ScanRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ScanTimeoutSeconds != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.ScanTimeoutSeconds))
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.OvalSourceUrl) > 0 {
+		i -= len(m.OvalSourceUrl)
+		copy(dAtA[i:], m.OvalSourceUrl)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.OvalSourceUrl)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.UserDeviceCredentials != nil {
+		{
+			size, err := m.UserDeviceCredentials.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAgentpb(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.SshGateway != nil {
+		{
+			size, err := m.SshGateway.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAgentpb(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Devices) > 0 {
+		for iNdEx := len(m.Devices) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Devices[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAgentpb(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.JobId) > 0 {
+		i -= len(m.JobId)
+		copy(dAtA[i:], m.JobId)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.JobId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ScanResultsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ScanResultsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ScanResultsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ScanLogsPersist != nil {
+		{
+			size, err := m.ScanLogsPersist.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAgentpb(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.ScanLogsWebsocket != nil {
+		{
+			size, err := m.ScanLogsWebsocket.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAgentpb(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.DeviceName) > 0 {
+		i -= len(m.DeviceName)
+		copy(dAtA[i:], m.DeviceName)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.DeviceName)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.VscanAgentName) > 0 {
+		i -= len(m.VscanAgentName)
+		copy(dAtA[i:], m.VscanAgentName)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.VscanAgentName)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ScanResultsJson) > 0 {
+		i -= len(m.ScanResultsJson)
+		copy(dAtA[i:], m.ScanResultsJson)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.ScanResultsJson)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ScanLogFileResponseWB) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ScanLogFileResponseWB) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ScanLogFileResponseWB) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ScanLogs) > 0 {
+		i -= len(m.ScanLogs)
+		copy(dAtA[i:], m.ScanLogs)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.ScanLogs)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ScanLogFileResponsePS) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ScanLogFileResponsePS) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ScanLogFileResponsePS) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ScanLogs) > 0 {
+		i -= len(m.ScanLogs)
+		copy(dAtA[i:], m.ScanLogs)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.ScanLogs)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SSHGatewayTestRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SSHGatewayTestRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SSHGatewayTestRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.SshGateway != nil {
+		{
+			size, err := m.SshGateway.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAgentpb(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SSHNegotiatedAlgorithms) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SSHNegotiatedAlgorithms) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SSHNegotiatedAlgorithms) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.MacServerToClient) > 0 {
+		i -= len(m.MacServerToClient)
+		copy(dAtA[i:], m.MacServerToClient)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.MacServerToClient)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.MacClientToServer) > 0 {
+		i -= len(m.MacClientToServer)
+		copy(dAtA[i:], m.MacClientToServer)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.MacClientToServer)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.CipherServerToClient) > 0 {
+		i -= len(m.CipherServerToClient)
+		copy(dAtA[i:], m.CipherServerToClient)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.CipherServerToClient)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.CipherClientToServer) > 0 {
+		i -= len(m.CipherClientToServer)
+		copy(dAtA[i:], m.CipherClientToServer)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.CipherClientToServer)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.HostKeyAlgorithm) > 0 {
+		i -= len(m.HostKeyAlgorithm)
+		copy(dAtA[i:], m.HostKeyAlgorithm)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.HostKeyAlgorithm)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.KexAlgorithm) > 0 {
+		i -= len(m.KexAlgorithm)
+		copy(dAtA[i:], m.KexAlgorithm)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.KexAlgorithm)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SSHGatewayTestResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SSHGatewayTestResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SSHGatewayTestResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.AuthMs != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.AuthMs))
+		i--
+		dAtA[i] = 0x50
+	}
+	if m.HandshakeMs != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.HandshakeMs))
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.TcpConnectMs != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.TcpConnectMs))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.SshNegotiatedAlgorithms != nil {
+		{
+			size, err := m.SshNegotiatedAlgorithms.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAgentpb(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.SshServerVersion) > 0 {
+		i -= len(m.SshServerVersion)
+		copy(dAtA[i:], m.SshServerVersion)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.SshServerVersion)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.SshFailureReason != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.SshFailureReason))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.SshHostKeyMatched {
+		i--
+		if m.SshHostKeyMatched {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.SshHostKeyFingerprint) > 0 {
+		i -= len(m.SshHostKeyFingerprint)
+		copy(dAtA[i:], m.SshHostKeyFingerprint)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.SshHostKeyFingerprint)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.SshCanConnect {
+		i--
+		if m.SshCanConnect {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.SshTestResult) > 0 {
+		i -= len(m.SshTestResult)
+		copy(dAtA[i:], m.SshTestResult)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.SshTestResult)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DeviceTestRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeviceTestRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeviceTestRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.TimeoutSeconds != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.TimeoutSeconds))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.CheckEnableMode {
+		i--
+		if m.CheckEnableMode {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.UserDeviceCredentials != nil {
+		{
+			size, err := m.UserDeviceCredentials.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAgentpb(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.SshGateway != nil {
+		{
+			size, err := m.SshGateway.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAgentpb(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Devices) > 0 {
+		for iNdEx := len(m.Devices) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Devices[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAgentpb(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DeviceTestResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeviceTestResult) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeviceTestResult) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.EnableModeOk {
+		i--
+		if m.EnableModeOk {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.EnableModeChecked {
+		i--
+		if m.EnableModeChecked {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.LatencyMs != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.LatencyMs))
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.SshServerBanner) > 0 {
+		i -= len(m.SshServerBanner)
+		copy(dAtA[i:], m.SshServerBanner)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.SshServerBanner)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.SshTestResult) > 0 {
+		i -= len(m.SshTestResult)
+		copy(dAtA[i:], m.SshTestResult)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.SshTestResult)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.SshCanConnect {
+		i--
+		if m.SshCanConnect {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.IpAddress) > 0 {
+		i -= len(m.IpAddress)
+		copy(dAtA[i:], m.IpAddress)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.IpAddress)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.DeviceName) > 0 {
+		i -= len(m.DeviceName)
+		copy(dAtA[i:], m.DeviceName)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.DeviceName)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DeviceTestResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeviceTestResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeviceTestResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.DeviceTestResults) > 0 {
+		for iNdEx := len(m.DeviceTestResults) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.DeviceTestResults[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAgentpb(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DiscoveryRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DiscoveryRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DiscoveryRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.TimeoutSeconds != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.TimeoutSeconds))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.UserDeviceCredentials != nil {
+		{
+			size, err := m.UserDeviceCredentials.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAgentpb(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.SshGateway != nil {
+		{
+			size, err := m.SshGateway.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAgentpb(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Devices) > 0 {
+		for iNdEx := len(m.Devices) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Devices[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAgentpb(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DeviceInventory) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeviceInventory) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeviceInventory) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.SerialNumber) > 0 {
+		i -= len(m.SerialNumber)
+		copy(dAtA[i:], m.SerialNumber)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.SerialNumber)))
+		i--
+		dAtA[i] = 0x52
+	}
+	if len(m.Hostname) > 0 {
+		i -= len(m.Hostname)
+		copy(dAtA[i:], m.Hostname)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.Hostname)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if len(m.OsVersion) > 0 {
+		i -= len(m.OsVersion)
+		copy(dAtA[i:], m.OsVersion)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.OsVersion)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if len(m.OsFamily) > 0 {
+		i -= len(m.OsFamily)
+		copy(dAtA[i:], m.OsFamily)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.OsFamily)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.Platform) > 0 {
+		i -= len(m.Platform)
+		copy(dAtA[i:], m.Platform)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.Platform)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.Vendor != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.Vendor))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.DiscoveryError) > 0 {
+		i -= len(m.DiscoveryError)
+		copy(dAtA[i:], m.DiscoveryError)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.DiscoveryError)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Discovered {
+		i--
+		if m.Discovered {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.IpAddress) > 0 {
+		i -= len(m.IpAddress)
+		copy(dAtA[i:], m.IpAddress)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.IpAddress)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.DeviceName) > 0 {
+		i -= len(m.DeviceName)
+		copy(dAtA[i:], m.DeviceName)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.DeviceName)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DiscoveryResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DiscoveryResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DiscoveryResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.DeviceInventories) > 0 {
+		for iNdEx := len(m.DeviceInventories) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.DeviceInventories[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAgentpb(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BulkSSHTestRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BulkSSHTestRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BulkSSHTestRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.TimeoutSeconds != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.TimeoutSeconds))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Concurrency != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.Concurrency))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.SshTargets) > 0 {
+		for iNdEx := len(m.SshTargets) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.SshTargets[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAgentpb(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BulkSSHTestResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BulkSSHTestResult) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BulkSSHTestResult) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.DurationMs != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.DurationMs))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.FailureReason != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.FailureReason))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.SshTestResponse != nil {
+		{
+			size, err := m.SshTestResponse.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAgentpb(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.GatewayIp) > 0 {
+		i -= len(m.GatewayIp)
+		copy(dAtA[i:], m.GatewayIp)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.GatewayIp)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.GatewayName) > 0 {
+		i -= len(m.GatewayName)
+		copy(dAtA[i:], m.GatewayName)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.GatewayName)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.TargetIndex != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.TargetIndex))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BulkSSHTestSummary) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BulkSSHTestSummary) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BulkSSHTestSummary) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.NoSupportedAuthMethods != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.NoSupportedAuthMethods))
+		i--
+		dAtA[i] = 0x60
+	}
+	if m.HandshakeFailures != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.HandshakeFailures))
+		i--
+		dAtA[i] = 0x58
+	}
+	if m.DurationMs != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.DurationMs))
+		i--
+		dAtA[i] = 0x50
+	}
+	if m.OtherFailures != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.OtherFailures))
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.HostKeyMismatches != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.HostKeyMismatches))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.AuthRejected != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.AuthRejected))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.Timeouts != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.Timeouts))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.ConnectionRefused != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.ConnectionRefused))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.DnsFailures != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.DnsFailures))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Failed != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.Failed))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Succeeded != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.Succeeded))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Total != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.Total))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BulkSSHTestResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BulkSSHTestResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BulkSSHTestResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Result != nil {
+		{
+			size := m.Result.Size()
+			i -= size
+			if _, err := m.Result.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BulkSSHTestResponse_TargetResult) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BulkSSHTestResponse_TargetResult) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.TargetResult != nil {
+		{
+			size, err := m.TargetResult.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAgentpb(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+func (m *BulkSSHTestResponse_Summary) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BulkSSHTestResponse_Summary) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Summary != nil {
+		{
+			size, err := m.Summary.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAgentpb(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	return len(dAtA) - i, nil
+}
+func (m *AgentInfoRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AgentInfoRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AgentInfoRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *AgentBuildInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AgentBuildInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AgentBuildInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.GoVersion) > 0 {
+		i -= len(m.GoVersion)
+		copy(dAtA[i:], m.GoVersion)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.GoVersion)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.BuiltOn) > 0 {
+		i -= len(m.BuiltOn)
+		copy(dAtA[i:], m.BuiltOn)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.BuiltOn)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.BuiltAt) > 0 {
+		i -= len(m.BuiltAt)
+		copy(dAtA[i:], m.BuiltAt)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.BuiltAt)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Commit) > 0 {
+		i -= len(m.Commit)
+		copy(dAtA[i:], m.Commit)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.Commit)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Version) > 0 {
+		i -= len(m.Version)
+		copy(dAtA[i:], m.Version)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.Version)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AgentPlatformInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AgentPlatformInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AgentPlatformInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.CpuCount != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.CpuCount))
+		i--
+		dAtA[i] = 0x40
+	}
+	if len(m.CpuModel) > 0 {
+		i -= len(m.CpuModel)
+		copy(dAtA[i:], m.CpuModel)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.CpuModel)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.Architecture) > 0 {
+		i -= len(m.Architecture)
+		copy(dAtA[i:], m.Architecture)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.Architecture)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.KernelVersion) > 0 {
+		i -= len(m.KernelVersion)
+		copy(dAtA[i:], m.KernelVersion)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.KernelVersion)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.PlatformVersion) > 0 {
+		i -= len(m.PlatformVersion)
+		copy(dAtA[i:], m.PlatformVersion)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.PlatformVersion)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Platform) > 0 {
+		i -= len(m.Platform)
+		copy(dAtA[i:], m.Platform)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.Platform)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Os) > 0 {
+		i -= len(m.Os)
+		copy(dAtA[i:], m.Os)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.Os)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Hostname) > 0 {
+		i -= len(m.Hostname)
+		copy(dAtA[i:], m.Hostname)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.Hostname)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AgentResourceUsage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AgentResourceUsage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AgentResourceUsage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.LoadAverage_15 != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.LoadAverage_15))))
+		i--
+		dAtA[i] = 0x49
+	}
+	if m.LoadAverage_5 != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.LoadAverage_5))))
+		i--
+		dAtA[i] = 0x41
+	}
+	if m.LoadAverage_1 != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.LoadAverage_1))))
+		i--
+		dAtA[i] = 0x39
+	}
+	if m.DiskUsedPercent != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.DiskUsedPercent))))
+		i--
+		dAtA[i] = 0x31
+	}
+	if m.DiskFreeBytes != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.DiskFreeBytes))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.DiskTotalBytes != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.DiskTotalBytes))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.MemoryUsedPercent != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.MemoryUsedPercent))))
+		i--
+		dAtA[i] = 0x19
+	}
+	if m.MemoryAvailableBytes != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.MemoryAvailableBytes))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.MemoryTotalBytes != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.MemoryTotalBytes))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ScannerBackend) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ScannerBackend) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ScannerBackend) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.LicenseDaysToExpiry != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.LicenseDaysToExpiry))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.LicenseExpiresUnix != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.LicenseExpiresUnix))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Available {
+		i--
+		if m.Available {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Version) > 0 {
+		i -= len(m.Version)
+		copy(dAtA[i:], m.Version)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.Version)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RunningJob) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RunningJob) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RunningJob) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.StartedUnix != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.StartedUnix))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Devices != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.Devices))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.JobId) > 0 {
+		i -= len(m.JobId)
+		copy(dAtA[i:], m.JobId)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.JobId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AgentJobCounts) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AgentJobCounts) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AgentJobCounts) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.RunningJobs) > 0 {
+		for iNdEx := len(m.RunningJobs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.RunningJobs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAgentpb(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.Failed != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.Failed))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Completed != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.Completed))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Running != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.Running))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AgentInfoResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AgentInfoResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AgentInfoResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Draining {
+		i--
+		if m.Draining {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.UptimeSeconds != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.UptimeSeconds))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.ServerCertificateDaysToExpiry != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.ServerCertificateDaysToExpiry))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.Jobs != nil {
+		{
+			size, err := m.Jobs.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAgentpb(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.ScannerBackends) > 0 {
+		for iNdEx := len(m.ScannerBackends) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ScannerBackends[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAgentpb(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.Resources != nil {
+		{
+			size, err := m.Resources.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAgentpb(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Platform != nil {
+		{
+			size, err := m.Platform.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAgentpb(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Build != nil {
+		{
+			size, err := m.Build.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAgentpb(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DrainRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DrainRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DrainRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.GracePeriodSeconds != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.GracePeriodSeconds))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DrainResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DrainResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DrainResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.RunningJobs) > 0 {
+		for iNdEx := len(m.RunningJobs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.RunningJobs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAgentpb(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.DeadlineUnix != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.DeadlineUnix))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Draining {
+		i--
+		if m.Draining {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AgentRegistration) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AgentRegistration) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AgentRegistration) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.AgentInfo != nil {
+		{
+			size, err := m.AgentInfo.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAgentpb(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.AdvertiseAddr) > 0 {
+		i -= len(m.AdvertiseAddr)
+		copy(dAtA[i:], m.AdvertiseAddr)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.AdvertiseAddr)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.AgentName) > 0 {
+		i -= len(m.AgentName)
+		copy(dAtA[i:], m.AgentName)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.AgentName)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AgentRegistrationResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AgentRegistrationResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AgentRegistrationResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.HeartbeatIntervalSeconds != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.HeartbeatIntervalSeconds))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.AgentId) > 0 {
+		i -= len(m.AgentId)
+		copy(dAtA[i:], m.AgentId)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.AgentId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AgentHeartbeat) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AgentHeartbeat) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AgentHeartbeat) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Serving {
+		i--
+		if m.Serving {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.Draining {
+		i--
+		if m.Draining {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.RunningJobs) > 0 {
+		for iNdEx := len(m.RunningJobs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.RunningJobs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAgentpb(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if m.QueueDepth != 0 {
+		i = encodeVarintAgentpb(dAtA, i, uint64(m.QueueDepth))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Resources != nil {
+		{
+			size, err := m.Resources.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAgentpb(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.AgentName) > 0 {
+		i -= len(m.AgentName)
+		copy(dAtA[i:], m.AgentName)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.AgentName)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.AgentId) > 0 {
+		i -= len(m.AgentId)
+		copy(dAtA[i:], m.AgentId)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.AgentId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AgentHeartbeatResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AgentHeartbeatResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AgentHeartbeatResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *AgentDeregistration) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AgentDeregistration) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AgentDeregistration) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.AgentName) > 0 {
+		i -= len(m.AgentName)
+		copy(dAtA[i:], m.AgentName)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.AgentName)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.AgentId) > 0 {
+		i -= len(m.AgentId)
+		copy(dAtA[i:], m.AgentId)
+		i = encodeVarintAgentpb(dAtA, i, uint64(len(m.AgentId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AgentDeregistrationResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AgentDeregistrationResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AgentDeregistrationResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintAgentpb(dAtA []byte, offset int, v uint64) int {
+	offset -= sovAgentpb(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *KeyboardInteractiveAnswer) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.PromptPattern)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.Answer)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	return n
+}
+
+func (m *SSHGateway) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.GatewayName)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.GatewayIp)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.GatewayUsername)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.GatewayPassword)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.GatewayPrivateKey)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.GatewayPrivateKeyPassphrase)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	if len(m.GatewayHostKeyFingerprints) > 0 {
+		for _, s := range m.GatewayHostKeyFingerprints {
+			l = len(s)
+			n += 1 + l + sovAgentpb(uint64(l))
+		}
+	}
+	l = len(m.GatewayCertificate)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	if len(m.GatewayKeyboardInteractiveAnswers) > 0 {
+		for _, e := range m.GatewayKeyboardInteractiveAnswers {
+			l = e.Size()
+			n += 1 + l + sovAgentpb(uint64(l))
+		}
+	}
+	l = len(m.GatewayProxyUrl)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	return n
+}
+
+func (m *UserDeviceCredentials) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.CredentialsName)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.CredentialsDeviceVendor)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.Username)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.Password)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.IosEnablePassword)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.PrivateKey)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	if m.DeviceVendor != 0 {
+		n += 1 + sovAgentpb(uint64(m.DeviceVendor))
+	}
+	if m.PrivilegeMethod != 0 {
+		n += 1 + sovAgentpb(uint64(m.PrivilegeMethod))
+	}
+	l = len(m.PrivilegePassword)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.PrivateKeyPassphrase)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.Certificate)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	return n
+}
+
+func (m *Device) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.DeviceName)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.IpAddress)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	return n
+}
+
+func (m *ScanRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.JobId)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	if len(m.Devices) > 0 {
+		for _, e := range m.Devices {
+			l = e.Size()
+			n += 1 + l + sovAgentpb(uint64(l))
+		}
+	}
+	if m.SshGateway != nil {
+		l = m.SshGateway.Size()
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	if m.UserDeviceCredentials != nil {
+		l = m.UserDeviceCredentials.Size()
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.OvalSourceUrl)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	if m.ScanTimeoutSeconds != 0 {
+		n += 1 + sovAgentpb(uint64(m.ScanTimeoutSeconds))
+	}
+	return n
+}
+
+func (m *ScanResultsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ScanResultsJson)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.VscanAgentName)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.DeviceName)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	if m.ScanLogsWebsocket != nil {
+		l = m.ScanLogsWebsocket.Size()
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	if m.ScanLogsPersist != nil {
+		l = m.ScanLogsPersist.Size()
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	return n
+}
+
+func (m *ScanLogFileResponseWB) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ScanLogs)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	return n
+}
+
+func (m *ScanLogFileResponsePS) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ScanLogs)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	return n
+}
+
+func (m *SSHGatewayTestRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SshGateway != nil {
+		l = m.SshGateway.Size()
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	return n
+}
+
+func (m *SSHNegotiatedAlgorithms) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.KexAlgorithm)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.HostKeyAlgorithm)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.CipherClientToServer)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.CipherServerToClient)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.MacClientToServer)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.MacServerToClient)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	return n
+}
+
+func (m *SSHGatewayTestResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.SshTestResult)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	if m.SshCanConnect {
+		n += 2
+	}
+	l = len(m.SshHostKeyFingerprint)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	if m.SshHostKeyMatched {
+		n += 2
+	}
+	if m.SshFailureReason != 0 {
+		n += 1 + sovAgentpb(uint64(m.SshFailureReason))
+	}
+	l = len(m.SshServerVersion)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	if m.SshNegotiatedAlgorithms != nil {
+		l = m.SshNegotiatedAlgorithms.Size()
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	if m.TcpConnectMs != 0 {
+		n += 1 + sovAgentpb(uint64(m.TcpConnectMs))
+	}
+	if m.HandshakeMs != 0 {
+		n += 1 + sovAgentpb(uint64(m.HandshakeMs))
+	}
+	if m.AuthMs != 0 {
+		n += 1 + sovAgentpb(uint64(m.AuthMs))
+	}
+	return n
+}
+
+func (m *DeviceTestRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Devices) > 0 {
+		for _, e := range m.Devices {
+			l = e.Size()
+			n += 1 + l + sovAgentpb(uint64(l))
+		}
+	}
+	if m.SshGateway != nil {
+		l = m.SshGateway.Size()
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	if m.UserDeviceCredentials != nil {
+		l = m.UserDeviceCredentials.Size()
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	if m.CheckEnableMode {
+		n += 2
+	}
+	if m.TimeoutSeconds != 0 {
+		n += 1 + sovAgentpb(uint64(m.TimeoutSeconds))
+	}
+	return n
+}
+
+func (m *DeviceTestResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.DeviceName)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.IpAddress)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	if m.SshCanConnect {
+		n += 2
+	}
+	l = len(m.SshTestResult)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.SshServerBanner)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	if m.LatencyMs != 0 {
+		n += 1 + sovAgentpb(uint64(m.LatencyMs))
+	}
+	if m.EnableModeChecked {
+		n += 2
+	}
+	if m.EnableModeOk {
+		n += 2
+	}
+	return n
+}
+
+func (m *DeviceTestResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.DeviceTestResults) > 0 {
+		for _, e := range m.DeviceTestResults {
+			l = e.Size()
+			n += 1 + l + sovAgentpb(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *DiscoveryRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Devices) > 0 {
+		for _, e := range m.Devices {
+			l = e.Size()
+			n += 1 + l + sovAgentpb(uint64(l))
+		}
+	}
+	if m.SshGateway != nil {
+		l = m.SshGateway.Size()
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	if m.UserDeviceCredentials != nil {
+		l = m.UserDeviceCredentials.Size()
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	if m.TimeoutSeconds != 0 {
+		n += 1 + sovAgentpb(uint64(m.TimeoutSeconds))
+	}
+	return n
+}
+
+func (m *DeviceInventory) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.DeviceName)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.IpAddress)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	if m.Discovered {
+		n += 2
+	}
+	l = len(m.DiscoveryError)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	if m.Vendor != 0 {
+		n += 1 + sovAgentpb(uint64(m.Vendor))
+	}
+	l = len(m.Platform)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.OsFamily)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.OsVersion)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.Hostname)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.SerialNumber)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	return n
+}
+
+func (m *DiscoveryResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.DeviceInventories) > 0 {
+		for _, e := range m.DeviceInventories {
+			l = e.Size()
+			n += 1 + l + sovAgentpb(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *BulkSSHTestRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.SshTargets) > 0 {
+		for _, e := range m.SshTargets {
+			l = e.Size()
+			n += 1 + l + sovAgentpb(uint64(l))
+		}
+	}
+	if m.Concurrency != 0 {
+		n += 1 + sovAgentpb(uint64(m.Concurrency))
+	}
+	if m.TimeoutSeconds != 0 {
+		n += 1 + sovAgentpb(uint64(m.TimeoutSeconds))
+	}
+	return n
+}
+
+func (m *BulkSSHTestResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.TargetIndex != 0 {
+		n += 1 + sovAgentpb(uint64(m.TargetIndex))
+	}
+	l = len(m.GatewayName)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.GatewayIp)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	if m.SshTestResponse != nil {
+		l = m.SshTestResponse.Size()
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	if m.FailureReason != 0 {
+		n += 1 + sovAgentpb(uint64(m.FailureReason))
+	}
+	if m.DurationMs != 0 {
+		n += 1 + sovAgentpb(uint64(m.DurationMs))
+	}
+	return n
+}
+
+func (m *BulkSSHTestSummary) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Total != 0 {
+		n += 1 + sovAgentpb(uint64(m.Total))
+	}
+	if m.Succeeded != 0 {
+		n += 1 + sovAgentpb(uint64(m.Succeeded))
+	}
+	if m.Failed != 0 {
+		n += 1 + sovAgentpb(uint64(m.Failed))
+	}
+	if m.DnsFailures != 0 {
+		n += 1 + sovAgentpb(uint64(m.DnsFailures))
+	}
+	if m.ConnectionRefused != 0 {
+		n += 1 + sovAgentpb(uint64(m.ConnectionRefused))
+	}
+	if m.Timeouts != 0 {
+		n += 1 + sovAgentpb(uint64(m.Timeouts))
+	}
+	if m.AuthRejected != 0 {
+		n += 1 + sovAgentpb(uint64(m.AuthRejected))
+	}
+	if m.HostKeyMismatches != 0 {
+		n += 1 + sovAgentpb(uint64(m.HostKeyMismatches))
+	}
+	if m.OtherFailures != 0 {
+		n += 1 + sovAgentpb(uint64(m.OtherFailures))
+	}
+	if m.DurationMs != 0 {
+		n += 1 + sovAgentpb(uint64(m.DurationMs))
+	}
+	if m.HandshakeFailures != 0 {
+		n += 1 + sovAgentpb(uint64(m.HandshakeFailures))
+	}
+	if m.NoSupportedAuthMethods != 0 {
+		n += 1 + sovAgentpb(uint64(m.NoSupportedAuthMethods))
+	}
+	return n
+}
+
+func (m *BulkSSHTestResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Result != nil {
+		n += m.Result.Size()
+	}
+	return n
+}
+
+func (m *BulkSSHTestResponse_TargetResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.TargetResult != nil {
+		l = m.TargetResult.Size()
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	return n
+}
+func (m *BulkSSHTestResponse_Summary) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Summary != nil {
+		l = m.Summary.Size()
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	return n
+}
+func (m *AgentInfoRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *AgentBuildInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.Commit)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.BuiltAt)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.BuiltOn)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.GoVersion)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	return n
+}
+
+func (m *AgentPlatformInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Hostname)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.Os)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.Platform)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.PlatformVersion)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.KernelVersion)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.Architecture)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.CpuModel)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	if m.CpuCount != 0 {
+		n += 1 + sovAgentpb(uint64(m.CpuCount))
+	}
+	return n
+}
+
+func (m *AgentResourceUsage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MemoryTotalBytes != 0 {
+		n += 1 + sovAgentpb(uint64(m.MemoryTotalBytes))
+	}
+	if m.MemoryAvailableBytes != 0 {
+		n += 1 + sovAgentpb(uint64(m.MemoryAvailableBytes))
+	}
+	if m.MemoryUsedPercent != 0 {
+		n += 9
+	}
+	if m.DiskTotalBytes != 0 {
+		n += 1 + sovAgentpb(uint64(m.DiskTotalBytes))
+	}
+	if m.DiskFreeBytes != 0 {
+		n += 1 + sovAgentpb(uint64(m.DiskFreeBytes))
+	}
+	if m.DiskUsedPercent != 0 {
+		n += 9
+	}
+	if m.LoadAverage_1 != 0 {
+		n += 9
+	}
+	if m.LoadAverage_5 != 0 {
+		n += 9
+	}
+	if m.LoadAverage_15 != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *ScannerBackend) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	if m.Available {
+		n += 2
+	}
+	if m.LicenseExpiresUnix != 0 {
+		n += 1 + sovAgentpb(uint64(m.LicenseExpiresUnix))
+	}
+	if m.LicenseDaysToExpiry != 0 {
+		n += 1 + sovAgentpb(uint64(m.LicenseDaysToExpiry))
+	}
+	return n
+}
+
+func (m *RunningJob) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.JobId)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	if m.Devices != 0 {
+		n += 1 + sovAgentpb(uint64(m.Devices))
+	}
+	if m.StartedUnix != 0 {
+		n += 1 + sovAgentpb(uint64(m.StartedUnix))
+	}
+	return n
+}
+
+func (m *AgentJobCounts) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Running != 0 {
+		n += 1 + sovAgentpb(uint64(m.Running))
+	}
+	if m.Completed != 0 {
+		n += 1 + sovAgentpb(uint64(m.Completed))
+	}
+	if m.Failed != 0 {
+		n += 1 + sovAgentpb(uint64(m.Failed))
+	}
+	if len(m.RunningJobs) > 0 {
+		for _, e := range m.RunningJobs {
+			l = e.Size()
+			n += 1 + l + sovAgentpb(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *AgentInfoResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Build != nil {
+		l = m.Build.Size()
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	if m.Platform != nil {
+		l = m.Platform.Size()
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	if m.Resources != nil {
+		l = m.Resources.Size()
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	if len(m.ScannerBackends) > 0 {
+		for _, e := range m.ScannerBackends {
+			l = e.Size()
+			n += 1 + l + sovAgentpb(uint64(l))
+		}
+	}
+	if m.Jobs != nil {
+		l = m.Jobs.Size()
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	if m.ServerCertificateDaysToExpiry != 0 {
+		n += 1 + sovAgentpb(uint64(m.ServerCertificateDaysToExpiry))
+	}
+	if m.UptimeSeconds != 0 {
+		n += 1 + sovAgentpb(uint64(m.UptimeSeconds))
+	}
+	if m.Draining {
+		n += 2
+	}
+	return n
+}
+
+func (m *DrainRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.GracePeriodSeconds != 0 {
+		n += 1 + sovAgentpb(uint64(m.GracePeriodSeconds))
+	}
+	return n
+}
+
+func (m *DrainResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Draining {
+		n += 2
+	}
+	if m.DeadlineUnix != 0 {
+		n += 1 + sovAgentpb(uint64(m.DeadlineUnix))
+	}
+	if len(m.RunningJobs) > 0 {
+		for _, e := range m.RunningJobs {
+			l = e.Size()
+			n += 1 + l + sovAgentpb(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *AgentRegistration) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.AgentName)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.AdvertiseAddr)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	if m.AgentInfo != nil {
+		l = m.AgentInfo.Size()
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	return n
+}
+
+func (m *AgentRegistrationResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.AgentId)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	if m.HeartbeatIntervalSeconds != 0 {
+		n += 1 + sovAgentpb(uint64(m.HeartbeatIntervalSeconds))
+	}
+	return n
+}
+
+func (m *AgentHeartbeat) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.AgentId)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.AgentName)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	if m.Resources != nil {
+		l = m.Resources.Size()
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	if m.QueueDepth != 0 {
+		n += 1 + sovAgentpb(uint64(m.QueueDepth))
+	}
+	if len(m.RunningJobs) > 0 {
+		for _, e := range m.RunningJobs {
+			l = e.Size()
+			n += 1 + l + sovAgentpb(uint64(l))
+		}
+	}
+	if m.Draining {
+		n += 2
+	}
+	if m.Serving {
+		n += 2
+	}
+	return n
+}
+
+func (m *AgentHeartbeatResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *AgentDeregistration) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.AgentId)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	l = len(m.AgentName)
+	if l > 0 {
+		n += 1 + l + sovAgentpb(uint64(l))
+	}
+	return n
+}
+
+func (m *AgentDeregistrationResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func sovAgentpb(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozAgentpb(x uint64) (n int) {
+	return sovAgentpb(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *KeyboardInteractiveAnswer) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgentpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: KeyboardInteractiveAnswer: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: KeyboardInteractiveAnswer: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PromptPattern", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PromptPattern = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Answer", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Answer = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgentpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SSHGateway) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgentpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SSHGateway: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SSHGateway: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GatewayName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GatewayName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GatewayIp", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GatewayIp = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GatewayUsername", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GatewayUsername = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GatewayPassword", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GatewayPassword = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GatewayPrivateKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GatewayPrivateKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GatewayPrivateKeyPassphrase", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GatewayPrivateKeyPassphrase = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GatewayHostKeyFingerprints", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GatewayHostKeyFingerprints = append(m.GatewayHostKeyFingerprints, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GatewayCertificate", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GatewayCertificate = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GatewayKeyboardInteractiveAnswers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GatewayKeyboardInteractiveAnswers = append(m.GatewayKeyboardInteractiveAnswers, &KeyboardInteractiveAnswer{})
+			if err := m.GatewayKeyboardInteractiveAnswers[len(m.GatewayKeyboardInteractiveAnswers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GatewayProxyUrl", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GatewayProxyUrl = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgentpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UserDeviceCredentials) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgentpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UserDeviceCredentials: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UserDeviceCredentials: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CredentialsName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CredentialsName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CredentialsDeviceVendor", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CredentialsDeviceVendor = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Username", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Username = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Password", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Password = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IosEnablePassword", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IosEnablePassword = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrivateKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PrivateKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeviceVendor", wireType)
+			}
+			m.DeviceVendor = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DeviceVendor |= DeviceVendor(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrivilegeMethod", wireType)
+			}
+			m.PrivilegeMethod = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PrivilegeMethod |= PrivilegeMethod(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrivilegePassword", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PrivilegePassword = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrivateKeyPassphrase", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PrivateKeyPassphrase = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Certificate", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Certificate = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgentpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Device) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgentpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Device: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Device: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeviceName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeviceName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IpAddress", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IpAddress = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgentpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ScanRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgentpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ScanRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ScanRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.JobId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Devices", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Devices = append(m.Devices, &Device{})
+			if err := m.Devices[len(m.Devices)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SshGateway", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SshGateway == nil {
+				m.SshGateway = &SSHGateway{}
+			}
+			if err := m.SshGateway.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UserDeviceCredentials", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.UserDeviceCredentials == nil {
+				m.UserDeviceCredentials = &UserDeviceCredentials{}
+			}
+			if err := m.UserDeviceCredentials.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OvalSourceUrl", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OvalSourceUrl = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ScanTimeoutSeconds", wireType)
+			}
+			m.ScanTimeoutSeconds = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ScanTimeoutSeconds |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgentpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ScanResultsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgentpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ScanResultsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ScanResultsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ScanResultsJson", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ScanResultsJson = append(m.ScanResultsJson[:0], dAtA[iNdEx:postIndex]...)
+			if m.ScanResultsJson == nil {
+				m.ScanResultsJson = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VscanAgentName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.VscanAgentName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeviceName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeviceName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ScanLogsWebsocket", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ScanLogsWebsocket == nil {
+				m.ScanLogsWebsocket = &ScanLogFileResponseWB{}
+			}
+			if err := m.ScanLogsWebsocket.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ScanLogsPersist", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ScanLogsPersist == nil {
+				m.ScanLogsPersist = &ScanLogFileResponsePS{}
+			}
+			if err := m.ScanLogsPersist.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgentpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ScanLogFileResponseWB) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgentpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ScanLogFileResponseWB: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ScanLogFileResponseWB: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ScanLogs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ScanLogs = append(m.ScanLogs[:0], dAtA[iNdEx:postIndex]...)
+			if m.ScanLogs == nil {
+				m.ScanLogs = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgentpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ScanLogFileResponsePS) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgentpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ScanLogFileResponsePS: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ScanLogFileResponsePS: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ScanLogs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ScanLogs = append(m.ScanLogs[:0], dAtA[iNdEx:postIndex]...)
+			if m.ScanLogs == nil {
+				m.ScanLogs = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgentpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SSHGatewayTestRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgentpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SSHGatewayTestRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SSHGatewayTestRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SshGateway", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SshGateway == nil {
+				m.SshGateway = &SSHGateway{}
+			}
+			if err := m.SshGateway.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgentpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SSHNegotiatedAlgorithms) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgentpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SSHNegotiatedAlgorithms: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SSHNegotiatedAlgorithms: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KexAlgorithm", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.KexAlgorithm = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HostKeyAlgorithm", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.HostKeyAlgorithm = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CipherClientToServer", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CipherClientToServer = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CipherServerToClient", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CipherServerToClient = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MacClientToServer", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MacClientToServer = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MacServerToClient", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MacServerToClient = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgentpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SSHGatewayTestResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgentpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SSHGatewayTestResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SSHGatewayTestResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SshTestResult", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SshTestResult = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SshCanConnect", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.SshCanConnect = bool(v != 0)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SshHostKeyFingerprint", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SshHostKeyFingerprint = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SshHostKeyMatched", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.SshHostKeyMatched = bool(v != 0)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SshFailureReason", wireType)
+			}
+			m.SshFailureReason = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SshFailureReason |= SSHFailureReason(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SshServerVersion", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SshServerVersion = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SshNegotiatedAlgorithms", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SshNegotiatedAlgorithms == nil {
+				m.SshNegotiatedAlgorithms = &SSHNegotiatedAlgorithms{}
+			}
+			if err := m.SshNegotiatedAlgorithms.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TcpConnectMs", wireType)
+			}
+			m.TcpConnectMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TcpConnectMs |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HandshakeMs", wireType)
+			}
+			m.HandshakeMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.HandshakeMs |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AuthMs", wireType)
+			}
+			m.AuthMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AuthMs |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgentpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeviceTestRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgentpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeviceTestRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeviceTestRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Devices", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Devices = append(m.Devices, &Device{})
+			if err := m.Devices[len(m.Devices)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SshGateway", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SshGateway == nil {
+				m.SshGateway = &SSHGateway{}
+			}
+			if err := m.SshGateway.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UserDeviceCredentials", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.UserDeviceCredentials == nil {
+				m.UserDeviceCredentials = &UserDeviceCredentials{}
+			}
+			if err := m.UserDeviceCredentials.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CheckEnableMode", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.CheckEnableMode = bool(v != 0)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeoutSeconds", wireType)
+			}
+			m.TimeoutSeconds = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TimeoutSeconds |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgentpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeviceTestResult) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgentpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeviceTestResult: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeviceTestResult: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeviceName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeviceName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IpAddress", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IpAddress = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SshCanConnect", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.SshCanConnect = bool(v != 0)
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SshTestResult", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SshTestResult = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SshServerBanner", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SshServerBanner = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LatencyMs", wireType)
+			}
+			m.LatencyMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LatencyMs |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EnableModeChecked", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.EnableModeChecked = bool(v != 0)
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EnableModeOk", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.EnableModeOk = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgentpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeviceTestResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgentpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeviceTestResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeviceTestResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeviceTestResults", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeviceTestResults = append(m.DeviceTestResults, &DeviceTestResult{})
+			if err := m.DeviceTestResults[len(m.DeviceTestResults)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgentpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DiscoveryRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgentpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DiscoveryRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DiscoveryRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Devices", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Devices = append(m.Devices, &Device{})
+			if err := m.Devices[len(m.Devices)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SshGateway", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SshGateway == nil {
+				m.SshGateway = &SSHGateway{}
+			}
+			if err := m.SshGateway.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UserDeviceCredentials", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.UserDeviceCredentials == nil {
+				m.UserDeviceCredentials = &UserDeviceCredentials{}
+			}
+			if err := m.UserDeviceCredentials.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeoutSeconds", wireType)
+			}
+			m.TimeoutSeconds = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TimeoutSeconds |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgentpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeviceInventory) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgentpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeviceInventory: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeviceInventory: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeviceName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeviceName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IpAddress", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IpAddress = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Discovered", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Discovered = bool(v != 0)
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DiscoveryError", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DiscoveryError = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Vendor", wireType)
+			}
+			m.Vendor = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Vendor |= DeviceVendor(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Platform", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Platform = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OsFamily", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OsFamily = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OsVersion", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OsVersion = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hostname", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hostname = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SerialNumber", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SerialNumber = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgentpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DiscoveryResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgentpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DiscoveryResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DiscoveryResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeviceInventories", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeviceInventories = append(m.DeviceInventories, &DeviceInventory{})
+			if err := m.DeviceInventories[len(m.DeviceInventories)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgentpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BulkSSHTestRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgentpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BulkSSHTestRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BulkSSHTestRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SshTargets", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SshTargets = append(m.SshTargets, &SSHGateway{})
+			if err := m.SshTargets[len(m.SshTargets)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Concurrency", wireType)
+			}
+			m.Concurrency = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Concurrency |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeoutSeconds", wireType)
+			}
+			m.TimeoutSeconds = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TimeoutSeconds |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgentpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BulkSSHTestResult) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgentpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BulkSSHTestResult: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BulkSSHTestResult: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TargetIndex", wireType)
+			}
+			m.TargetIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TargetIndex |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GatewayName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GatewayName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GatewayIp", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GatewayIp = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SshTestResponse", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SshTestResponse == nil {
+				m.SshTestResponse = &SSHGatewayTestResponse{}
+			}
+			if err := m.SshTestResponse.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FailureReason", wireType)
+			}
+			m.FailureReason = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FailureReason |= SSHFailureReason(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DurationMs", wireType)
+			}
+			m.DurationMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DurationMs |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgentpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BulkSSHTestSummary) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgentpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BulkSSHTestSummary: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BulkSSHTestSummary: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Total", wireType)
+			}
+			m.Total = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Total |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Succeeded", wireType)
+			}
+			m.Succeeded = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Succeeded |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Failed", wireType)
+			}
+			m.Failed = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Failed |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DnsFailures", wireType)
+			}
+			m.DnsFailures = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DnsFailures |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConnectionRefused", wireType)
+			}
+			m.ConnectionRefused = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ConnectionRefused |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeouts", wireType)
+			}
+			m.Timeouts = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timeouts |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AuthRejected", wireType)
+			}
+			m.AuthRejected = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AuthRejected |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HostKeyMismatches", wireType)
+			}
+			m.HostKeyMismatches = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.HostKeyMismatches |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OtherFailures", wireType)
+			}
+			m.OtherFailures = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.OtherFailures |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DurationMs", wireType)
+			}
+			m.DurationMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DurationMs |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HandshakeFailures", wireType)
+			}
+			m.HandshakeFailures = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.HandshakeFailures |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NoSupportedAuthMethods", wireType)
+			}
+			m.NoSupportedAuthMethods = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NoSupportedAuthMethods |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgentpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BulkSSHTestResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgentpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BulkSSHTestResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BulkSSHTestResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TargetResult", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &BulkSSHTestResult{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Result = &BulkSSHTestResponse_TargetResult{v}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Summary", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &BulkSSHTestSummary{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Result = &BulkSSHTestResponse_Summary{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgentpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AgentInfoRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgentpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AgentInfoRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AgentInfoRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgentpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AgentBuildInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgentpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AgentBuildInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AgentBuildInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Version = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Commit = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BuiltAt", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BuiltAt = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BuiltOn", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BuiltOn = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GoVersion", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GoVersion = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgentpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AgentPlatformInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgentpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AgentPlatformInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AgentPlatformInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hostname", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hostname = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Os", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Os = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Platform", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Platform = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PlatformVersion", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PlatformVersion = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KernelVersion", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.KernelVersion = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Architecture", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Architecture = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CpuModel", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CpuModel = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CpuCount", wireType)
+			}
+			m.CpuCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CpuCount |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgentpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AgentResourceUsage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgentpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AgentResourceUsage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AgentResourceUsage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemoryTotalBytes", wireType)
+			}
+			m.MemoryTotalBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MemoryTotalBytes |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemoryAvailableBytes", wireType)
+			}
+			m.MemoryAvailableBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MemoryAvailableBytes |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemoryUsedPercent", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.MemoryUsedPercent = float64(math.Float64frombits(v))
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DiskTotalBytes", wireType)
+			}
+			m.DiskTotalBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DiskTotalBytes |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DiskFreeBytes", wireType)
+			}
+			m.DiskFreeBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DiskFreeBytes |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DiskUsedPercent", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.DiskUsedPercent = float64(math.Float64frombits(v))
+		case 7:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LoadAverage_1", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.LoadAverage_1 = float64(math.Float64frombits(v))
+		case 8:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LoadAverage_5", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.LoadAverage_5 = float64(math.Float64frombits(v))
+		case 9:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LoadAverage_15", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.LoadAverage_15 = float64(math.Float64frombits(v))
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgentpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ScannerBackend) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgentpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ScannerBackend: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ScannerBackend: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Version = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Available", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Available = bool(v != 0)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LicenseExpiresUnix", wireType)
+			}
+			m.LicenseExpiresUnix = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LicenseExpiresUnix |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LicenseDaysToExpiry", wireType)
+			}
+			m.LicenseDaysToExpiry = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LicenseDaysToExpiry |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgentpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RunningJob) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgentpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RunningJob: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RunningJob: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.JobId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Devices", wireType)
+			}
+			m.Devices = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Devices |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartedUnix", wireType)
+			}
+			m.StartedUnix = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StartedUnix |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgentpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
-	return n
-}
 
-func sovAgentpb(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
-		}
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
-}
-func sozAgentpb(x uint64) (n int) {
-	return sovAgentpb(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return nil
 }
-func (m *SSHGateway) Unmarshal(dAtA []byte) error {
+func (m *AgentJobCounts) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1341,7 +12069,7 @@ func (m *SSHGateway) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -1349,17 +12077,17 @@ func (m *SSHGateway) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SSHGateway: wiretype end group for non-group")
+			return fmt.Errorf("proto: AgentJobCounts: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SSHGateway: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AgentJobCounts: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GatewayName", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Running", wireType)
 			}
-			var stringLen uint64
+			m.Running = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgentpb
@@ -1369,26 +12097,16 @@ func (m *SSHGateway) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.Running |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthAgentpb
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.GatewayName = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GatewayIp", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Completed", wireType)
 			}
-			var stringLen uint64
+			m.Completed = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgentpb
@@ -1398,26 +12116,16 @@ func (m *SSHGateway) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.Completed |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthAgentpb
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.GatewayIp = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GatewayUsername", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Failed", wireType)
 			}
-			var stringLen uint64
+			m.Failed = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgentpb
@@ -1427,26 +12135,16 @@ func (m *SSHGateway) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.Failed |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthAgentpb
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.GatewayUsername = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GatewayPassword", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RunningJobs", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgentpb
@@ -1456,49 +12154,25 @@ func (m *SSHGateway) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthAgentpb
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.GatewayPassword = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GatewayPrivateKey", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgentpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
 				return ErrInvalidLengthAgentpb
 			}
-			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.GatewayPrivateKey = string(dAtA[iNdEx:postIndex])
+			m.RunningJobs = append(m.RunningJobs, &RunningJob{})
+			if err := m.RunningJobs[len(m.RunningJobs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1509,6 +12183,9 @@ func (m *SSHGateway) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthAgentpb
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAgentpb
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -1521,7 +12198,7 @@ func (m *SSHGateway) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *UserDeviceCredentials) Unmarshal(dAtA []byte) error {
+func (m *AgentInfoResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1536,7 +12213,7 @@ func (m *UserDeviceCredentials) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -1544,17 +12221,17 @@ func (m *UserDeviceCredentials) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: UserDeviceCredentials: wiretype end group for non-group")
+			return fmt.Errorf("proto: AgentInfoResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UserDeviceCredentials: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AgentInfoResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CredentialsName", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Build", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgentpb
@@ -1564,26 +12241,33 @@ func (m *UserDeviceCredentials) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
 				return ErrInvalidLengthAgentpb
 			}
-			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.CredentialsName = string(dAtA[iNdEx:postIndex])
+			if m.Build == nil {
+				m.Build = &AgentBuildInfo{}
+			}
+			if err := m.Build.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CredentialsDeviceVendor", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Platform", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgentpb
@@ -1593,26 +12277,33 @@ func (m *UserDeviceCredentials) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
 				return ErrInvalidLengthAgentpb
 			}
-			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.CredentialsDeviceVendor = string(dAtA[iNdEx:postIndex])
+			if m.Platform == nil {
+				m.Platform = &AgentPlatformInfo{}
+			}
+			if err := m.Platform.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Username", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Resources", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgentpb
@@ -1622,26 +12313,33 @@ func (m *UserDeviceCredentials) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
 				return ErrInvalidLengthAgentpb
 			}
-			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Username = string(dAtA[iNdEx:postIndex])
+			if m.Resources == nil {
+				m.Resources = &AgentResourceUsage{}
+			}
+			if err := m.Resources.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Password", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ScannerBackends", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgentpb
@@ -1651,26 +12349,31 @@ func (m *UserDeviceCredentials) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
 				return ErrInvalidLengthAgentpb
 			}
-			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Password = string(dAtA[iNdEx:postIndex])
+			m.ScannerBackends = append(m.ScannerBackends, &ScannerBackend{})
+			if err := m.ScannerBackends[len(m.ScannerBackends)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IosEnablePassword", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Jobs", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgentpb
@@ -1680,26 +12383,144 @@ func (m *UserDeviceCredentials) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Jobs == nil {
+				m.Jobs = &AgentJobCounts{}
+			}
+			if err := m.Jobs.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServerCertificateDaysToExpiry", wireType)
+			}
+			m.ServerCertificateDaysToExpiry = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ServerCertificateDaysToExpiry |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UptimeSeconds", wireType)
+			}
+			m.UptimeSeconds = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UptimeSeconds |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Draining", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Draining = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgentpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthAgentpb
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DrainRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgentpb
+			}
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			m.IosEnablePassword = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PrivateKey", wireType)
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			var stringLen uint64
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DrainRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DrainRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GracePeriodSeconds", wireType)
+			}
+			m.GracePeriodSeconds = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgentpb
@@ -1709,21 +12530,11 @@ func (m *UserDeviceCredentials) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.GracePeriodSeconds |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthAgentpb
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.PrivateKey = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAgentpb(dAtA[iNdEx:])
@@ -1733,6 +12544,9 @@ func (m *UserDeviceCredentials) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthAgentpb
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAgentpb
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -1745,7 +12559,7 @@ func (m *UserDeviceCredentials) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Device) Unmarshal(dAtA []byte) error {
+func (m *DrainResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1760,7 +12574,7 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -1768,17 +12582,17 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Device: wiretype end group for non-group")
+			return fmt.Errorf("proto: DrainResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Device: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DrainResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DeviceName", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Draining", wireType)
 			}
-			var stringLen uint64
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgentpb
@@ -1788,26 +12602,36 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthAgentpb
+			m.Draining = bool(v != 0)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeadlineUnix", wireType)
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			m.DeadlineUnix = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DeadlineUnix |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			m.DeviceName = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IpAddress", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RunningJobs", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgentpb
@@ -1817,20 +12641,25 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
 				return ErrInvalidLengthAgentpb
 			}
-			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.IpAddress = string(dAtA[iNdEx:postIndex])
+			m.RunningJobs = append(m.RunningJobs, &RunningJob{})
+			if err := m.RunningJobs[len(m.RunningJobs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1841,6 +12670,9 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthAgentpb
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAgentpb
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -1853,7 +12685,7 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ScanRequest) Unmarshal(dAtA []byte) error {
+func (m *AgentRegistration) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1868,7 +12700,7 @@ func (m *ScanRequest) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -1876,15 +12708,15 @@ func (m *ScanRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ScanRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: AgentRegistration: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ScanRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AgentRegistration: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AgentName", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1896,7 +12728,7 @@ func (m *ScanRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -1906,16 +12738,19 @@ func (m *ScanRequest) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthAgentpb
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.JobId = string(dAtA[iNdEx:postIndex])
+			m.AgentName = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Devices", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AdvertiseAddr", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgentpb
@@ -1925,26 +12760,27 @@ func (m *ScanRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
 				return ErrInvalidLengthAgentpb
 			}
-			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Devices = append(m.Devices, &Device{})
-			if err := m.Devices[len(m.Devices)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.AdvertiseAddr = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SshGateway", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AgentInfo", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1956,7 +12792,7 @@ func (m *ScanRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -1965,52 +12801,75 @@ func (m *ScanRequest) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthAgentpb
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.SshGateway == nil {
-				m.SshGateway = &SSHGateway{}
+			if m.AgentInfo == nil {
+				m.AgentInfo = &AgentInfoResponse{}
 			}
-			if err := m.SshGateway.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.AgentInfo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UserDeviceCredentials", wireType)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgentpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgentpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			if skippy < 0 {
+				return ErrInvalidLengthAgentpb
 			}
-			if msglen < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthAgentpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.UserDeviceCredentials == nil {
-				m.UserDeviceCredentials = &UserDeviceCredentials{}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AgentRegistrationResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgentpb
 			}
-			if err := m.UserDeviceCredentials.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			iNdEx = postIndex
-		case 5:
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AgentRegistrationResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AgentRegistrationResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OvalSourceUrl", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AgentId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2022,7 +12881,7 @@ func (m *ScanRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2032,16 +12891,19 @@ func (m *ScanRequest) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthAgentpb
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.OvalSourceUrl = string(dAtA[iNdEx:postIndex])
+			m.AgentId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 6:
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ScanTimeoutSeconds", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field HeartbeatIntervalSeconds", wireType)
 			}
-			m.ScanTimeoutSeconds = 0
+			m.HeartbeatIntervalSeconds = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgentpb
@@ -2051,7 +12913,7 @@ func (m *ScanRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ScanTimeoutSeconds |= (int64(b) & 0x7F) << shift
+				m.HeartbeatIntervalSeconds |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2065,6 +12927,9 @@ func (m *ScanRequest) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthAgentpb
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAgentpb
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -2077,7 +12942,7 @@ func (m *ScanRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ScanResultsResponse) Unmarshal(dAtA []byte) error {
+func (m *AgentHeartbeat) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2092,7 +12957,7 @@ func (m *ScanResultsResponse) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -2100,17 +12965,17 @@ func (m *ScanResultsResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ScanResultsResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: AgentHeartbeat: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ScanResultsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AgentHeartbeat: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ScanResultsJson", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AgentId", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgentpb
@@ -2120,26 +12985,27 @@ func (m *ScanResultsResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
 				return ErrInvalidLengthAgentpb
 			}
-			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ScanResultsJson = append(m.ScanResultsJson[:0], dAtA[iNdEx:postIndex]...)
-			if m.ScanResultsJson == nil {
-				m.ScanResultsJson = []byte{}
-			}
+			m.AgentId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field VscanAgentName", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AgentName", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2151,7 +13017,7 @@ func (m *ScanResultsResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2161,16 +13027,55 @@ func (m *ScanResultsResponse) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthAgentpb
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AgentName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Resources", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.VscanAgentName = string(dAtA[iNdEx:postIndex])
+			if m.Resources == nil {
+				m.Resources = &AgentResourceUsage{}
+			}
+			if err := m.Resources.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DeviceName", wireType)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QueueDepth", wireType)
 			}
-			var stringLen uint64
+			m.QueueDepth = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgentpb
@@ -2180,24 +13085,14 @@ func (m *ScanResultsResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.QueueDepth |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthAgentpb
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.DeviceName = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 4:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ScanLogsWebsocket", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RunningJobs", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2209,7 +13104,7 @@ func (m *ScanResultsResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2218,21 +13113,22 @@ func (m *ScanResultsResponse) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthAgentpb
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgentpb
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ScanLogsWebsocket == nil {
-				m.ScanLogsWebsocket = &ScanLogFileResponseWB{}
-			}
-			if err := m.ScanLogsWebsocket.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.RunningJobs = append(m.RunningJobs, &RunningJob{})
+			if err := m.RunningJobs[len(m.RunningJobs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ScanLogsPersist", wireType)
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Draining", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgentpb
@@ -2242,25 +13138,32 @@ func (m *ScanResultsResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthAgentpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.ScanLogsPersist == nil {
-				m.ScanLogsPersist = &ScanLogFileResponsePS{}
+			m.Draining = bool(v != 0)
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Serving", wireType)
 			}
-			if err := m.ScanLogsPersist.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgentpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
+			m.Serving = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAgentpb(dAtA[iNdEx:])
@@ -2270,6 +13173,9 @@ func (m *ScanResultsResponse) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthAgentpb
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAgentpb
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -2282,7 +13188,7 @@ func (m *ScanResultsResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ScanLogFileResponseWB) Unmarshal(dAtA []byte) error {
+func (m *AgentHeartbeatResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2297,7 +13203,7 @@ func (m *ScanLogFileResponseWB) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -2305,43 +13211,12 @@ func (m *ScanLogFileResponseWB) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ScanLogFileResponseWB: wiretype end group for non-group")
+			return fmt.Errorf("proto: AgentHeartbeatResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ScanLogFileResponseWB: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AgentHeartbeatResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ScanLogs", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgentpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthAgentpb
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ScanLogs = append(m.ScanLogs[:0], dAtA[iNdEx:postIndex]...)
-			if m.ScanLogs == nil {
-				m.ScanLogs = []byte{}
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAgentpb(dAtA[iNdEx:])
@@ -2351,6 +13226,9 @@ func (m *ScanLogFileResponseWB) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthAgentpb
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAgentpb
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -2363,7 +13241,7 @@ func (m *ScanLogFileResponseWB) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ScanLogFileResponsePS) Unmarshal(dAtA []byte) error {
+func (m *AgentDeregistration) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2378,7 +13256,7 @@ func (m *ScanLogFileResponsePS) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -2386,17 +13264,17 @@ func (m *ScanLogFileResponsePS) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ScanLogFileResponsePS: wiretype end group for non-group")
+			return fmt.Errorf("proto: AgentDeregistration: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ScanLogFileResponsePS: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AgentDeregistration: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ScanLogs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AgentId", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgentpb
@@ -2406,78 +13284,29 @@ func (m *ScanLogFileResponsePS) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthAgentpb
 			}
-			postIndex := iNdEx + byteLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ScanLogs = append(m.ScanLogs[:0], dAtA[iNdEx:postIndex]...)
-			if m.ScanLogs == nil {
-				m.ScanLogs = []byte{}
-			}
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipAgentpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
 				return ErrInvalidLengthAgentpb
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *SSHGatewayTestRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowAgentpb
-			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: SSHGatewayTestRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SSHGatewayTestRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.AgentId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SshGateway", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AgentName", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgentpb
@@ -2487,24 +13316,23 @@ func (m *SSHGatewayTestRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgentpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
 				return ErrInvalidLengthAgentpb
 			}
-			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.SshGateway == nil {
-				m.SshGateway = &SSHGateway{}
-			}
-			if err := m.SshGateway.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.AgentName = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2515,6 +13343,9 @@ func (m *SSHGatewayTestRequest) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthAgentpb
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAgentpb
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -2527,7 +13358,7 @@ func (m *SSHGatewayTestRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SSHGatewayTestResponse) Unmarshal(dAtA []byte) error {
+func (m *AgentDeregistrationResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2542,7 +13373,7 @@ func (m *SSHGatewayTestResponse) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -2550,61 +13381,12 @@ func (m *SSHGatewayTestResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SSHGatewayTestResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: AgentDeregistrationResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SSHGatewayTestResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AgentDeregistrationResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SshTestResult", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgentpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthAgentpb
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.SshTestResult = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SshCanConnect", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgentpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.SshCanConnect = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAgentpb(dAtA[iNdEx:])
@@ -2614,6 +13396,9 @@ func (m *SSHGatewayTestResponse) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthAgentpb
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAgentpb
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -2629,6 +13414,7 @@ func (m *SSHGatewayTestResponse) Unmarshal(dAtA []byte) error {
 func skipAgentpb(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
+	depth := 0
 	for iNdEx < l {
 		var wire uint64
 		for shift := uint(0); ; shift += 7 {
@@ -2660,10 +13446,8 @@ func skipAgentpb(dAtA []byte) (n int, err error) {
 					break
 				}
 			}
-			return iNdEx, nil
 		case 1:
 			iNdEx += 8
-			return iNdEx, nil
 		case 2:
 			var length int
 			for shift := uint(0); ; shift += 7 {
@@ -2680,108 +13464,34 @@ func skipAgentpb(dAtA []byte) (n int, err error) {
 					break
 				}
 			}
-			iNdEx += length
 			if length < 0 {
 				return 0, ErrInvalidLengthAgentpb
 			}
-			return iNdEx, nil
+			iNdEx += length
 		case 3:
-			for {
-				var innerWire uint64
-				var start int = iNdEx
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return 0, ErrIntOverflowAgentpb
-					}
-					if iNdEx >= l {
-						return 0, io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					innerWire |= (uint64(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				innerWireType := int(innerWire & 0x7)
-				if innerWireType == 4 {
-					break
-				}
-				next, err := skipAgentpb(dAtA[start:])
-				if err != nil {
-					return 0, err
-				}
-				iNdEx = start + next
-			}
-			return iNdEx, nil
+			depth++
 		case 4:
-			return iNdEx, nil
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupAgentpb
+			}
+			depth--
 		case 5:
 			iNdEx += 4
-			return iNdEx, nil
 		default:
 			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
 		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthAgentpb
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
 	}
-	panic("unreachable")
+	return 0, io.ErrUnexpectedEOF
 }
 
 var (
-	ErrInvalidLengthAgentpb = fmt.Errorf("proto: negative length found during unmarshaling")
-	ErrIntOverflowAgentpb   = fmt.Errorf("proto: integer overflow")
+	ErrInvalidLengthAgentpb        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowAgentpb          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupAgentpb = fmt.Errorf("proto: unexpected end of group")
 )
-
-func init() { proto.RegisterFile("proto/agentpb.proto", fileDescriptor_agentpb_a363b3d4a74a9f5b) }
-
-var fileDescriptor_agentpb_a363b3d4a74a9f5b = []byte{
-	// 775 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0xcd, 0x52, 0x2b, 0x45,
-	0x14, 0xce, 0x24, 0xde, 0x5c, 0x38, 0xb9, 0xd7, 0x40, 0x87, 0x48, 0x44, 0x1d, 0x24, 0x0b, 0x0a,
-	0x5c, 0x20, 0x15, 0x59, 0xb9, 0x83, 0xf8, 0x03, 0x88, 0x54, 0x6a, 0x86, 0x1f, 0xcb, 0xcd, 0xd4,
-	0x64, 0xa6, 0x4d, 0x1a, 0x86, 0xe9, 0x71, 0x4e, 0x4f, 0x28, 0x9e, 0xc0, 0xad, 0x8f, 0xe1, 0x1b,
-	0xe8, 0x23, 0xb8, 0x64, 0xe1, 0xc2, 0xa5, 0x05, 0x2f, 0x62, 0x4d, 0xff, 0x4c, 0x86, 0x10, 0xf4,
-	0xee, 0xd2, 0xdf, 0xf9, 0xfa, 0xf4, 0xf9, 0xbe, 0xef, 0xa4, 0x06, 0x5a, 0x49, 0xca, 0x05, 0xff,
-	0xdc, 0x1f, 0xd1, 0x58, 0x24, 0xc3, 0x1d, 0x79, 0x22, 0xaf, 0xf5, 0xb1, 0xfb, 0x97, 0x05, 0xe0,
-	0xba, 0x87, 0xdf, 0xfa, 0x82, 0xde, 0xfa, 0x77, 0x64, 0x03, 0xde, 0x8c, 0xd4, 0x4f, 0x2f, 0xf6,
-	0x6f, 0x68, 0xc7, 0xfa, 0xd4, 0xda, 0x5a, 0x74, 0x1a, 0x1a, 0x3b, 0xf5, 0x6f, 0x28, 0xf9, 0x04,
-	0xc0, 0x50, 0x58, 0xd2, 0xa9, 0x4a, 0xc2, 0xa2, 0x46, 0x8e, 0x12, 0xb2, 0x0d, 0x4b, 0xa6, 0x9c,
-	0x21, 0x4d, 0x65, 0x97, 0x9a, 0x24, 0x35, 0x35, 0x7e, 0xae, 0xe1, 0x32, 0x35, 0xf1, 0x11, 0x6f,
-	0x79, 0x1a, 0x76, 0xde, 0x7b, 0x42, 0x1d, 0x68, 0x98, 0xec, 0x40, 0xab, 0xa0, 0xa6, 0x6c, 0xe2,
-	0x0b, 0xea, 0x5d, 0xd3, 0xbb, 0xce, 0x2b, 0xc9, 0x5e, 0x36, 0x6c, 0x55, 0xf9, 0x8e, 0xde, 0x75,
-	0x7f, 0xa9, 0x42, 0x3b, 0x7f, 0xe7, 0x2b, 0x3a, 0x61, 0x01, 0xed, 0xa7, 0x34, 0xa4, 0xb1, 0x60,
-	0x7e, 0x84, 0xf9, 0xa3, 0xc1, 0xf4, 0x58, 0x56, 0xd9, 0x2c, 0xe1, 0x52, 0xe9, 0x97, 0xf0, 0x61,
-	0x99, 0x1a, 0xca, 0x5e, 0xde, 0x84, 0xc6, 0x21, 0x4f, 0xb5, 0xf0, 0xd5, 0x12, 0x41, 0xbd, 0x75,
-	0x21, 0xcb, 0x64, 0x0d, 0x16, 0x66, 0xe4, 0x17, 0xe7, 0xbc, 0x36, 0xa3, 0xb7, 0x38, 0xe7, 0x42,
-	0x19, 0x47, 0x8f, 0xc6, 0xfe, 0x30, 0xa2, 0x53, 0x5b, 0xb4, 0x50, 0xc6, 0xf1, 0x6b, 0x59, 0x29,
-	0x8c, 0x59, 0x87, 0x46, 0xd9, 0x90, 0xba, 0xe4, 0x41, 0x32, 0x75, 0xe2, 0x10, 0xea, 0x6a, 0xb0,
-	0x9c, 0xaa, 0x25, 0x94, 0x44, 0x83, 0x82, 0x4c, 0xb2, 0x2c, 0xf1, 0xfc, 0x30, 0x4c, 0x29, 0xa2,
-	0x49, 0x96, 0x25, 0xfb, 0x0a, 0xe8, 0xfe, 0x5e, 0x85, 0x86, 0x1b, 0xf8, 0xb1, 0x43, 0x7f, 0xce,
-	0x28, 0x0a, 0xd2, 0x86, 0xfa, 0x15, 0x1f, 0x7a, 0x2c, 0xd4, 0xad, 0x5e, 0x5d, 0xf1, 0xe1, 0x51,
-	0x48, 0xb6, 0xe1, 0xb5, 0xea, 0x99, 0xb7, 0xa8, 0x6d, 0x35, 0x7a, 0xcd, 0x1d, 0xb3, 0x7b, 0x6a,
-	0x10, 0xc7, 0xd4, 0xc9, 0x1e, 0x34, 0x10, 0xc7, 0x9e, 0x8e, 0x4f, 0xfa, 0xd4, 0xe8, 0xb5, 0x0a,
-	0xfa, 0x74, 0x2f, 0x1d, 0x40, 0x1c, 0x9b, 0x1d, 0xbd, 0x80, 0xd5, 0xdc, 0x4a, 0x93, 0x47, 0x29,
-	0x01, 0xe9, 0x66, 0xa3, 0x67, 0x17, 0x1d, 0xe6, 0xae, 0x80, 0xd3, 0xce, 0xe6, 0x6e, 0xc6, 0x26,
-	0x34, 0xf9, 0xc4, 0x8f, 0x3c, 0xe4, 0x59, 0x1a, 0x50, 0x2f, 0x4b, 0x23, 0x6d, 0xfb, 0xdb, 0x1c,
-	0x76, 0x25, 0x7a, 0x9e, 0x46, 0x64, 0x17, 0x56, 0x30, 0xf0, 0x63, 0x4f, 0xb0, 0x1b, 0xca, 0x33,
-	0xe1, 0x21, 0x0d, 0x78, 0x1c, 0xa2, 0xf4, 0xbe, 0xe6, 0x90, 0xbc, 0x76, 0xa6, 0x4a, 0xae, 0xaa,
-	0x74, 0x7f, 0xab, 0x42, 0x4b, 0x39, 0x87, 0x59, 0x24, 0xd0, 0xa1, 0x98, 0xf0, 0x18, 0x29, 0xf9,
-	0x0c, 0x96, 0x65, 0xa7, 0x54, 0xe1, 0xde, 0x15, 0xf2, 0x58, 0x9a, 0xf9, 0xc6, 0x69, 0xe2, 0x94,
-	0x7f, 0x8c, 0x3c, 0x26, 0x5b, 0xb0, 0x34, 0x91, 0x64, 0xa9, 0x4d, 0x45, 0xa8, 0x22, 0x7a, 0x5f,
-	0xe2, 0xfb, 0x39, 0x2c, 0x63, 0x9c, 0xc9, 0xb9, 0xf6, 0x2c, 0xe7, 0x53, 0x68, 0xc9, 0x4e, 0x11,
-	0x1f, 0xa1, 0x77, 0x4b, 0x87, 0xc8, 0x83, 0x6b, 0x2a, 0x9e, 0x99, 0x97, 0x4f, 0x7c, 0xc2, 0x47,
-	0xdf, 0xb0, 0x88, 0x9a, 0x89, 0x2f, 0x0f, 0x1c, 0x39, 0xf1, 0x09, 0x1f, 0xe1, 0xa5, 0xb9, 0x48,
-	0x8e, 0xb5, 0x0c, 0xd9, 0x2f, 0xa1, 0x29, 0x32, 0x14, 0xd2, 0xba, 0xff, 0xe9, 0x36, 0x70, 0x95,
-	0xcc, 0xbc, 0xdb, 0x40, 0x5d, 0xeb, 0xee, 0x41, 0x7b, 0xee, 0xbb, 0xe4, 0x23, 0x58, 0x2c, 0x1e,
-	0xd1, 0x1e, 0x2d, 0x98, 0xcb, 0x2f, 0xdc, 0x1a, 0xb8, 0xff, 0x7d, 0xeb, 0x7b, 0x68, 0x4f, 0x57,
-	0xec, 0x8c, 0xa2, 0x30, 0x9b, 0x3d, 0xb3, 0x97, 0xd6, 0x3b, 0xed, 0x65, 0x77, 0x0c, 0x1f, 0xcc,
-	0xb6, 0xd3, 0x39, 0x6f, 0x42, 0x33, 0xef, 0x27, 0x28, 0x0a, 0x9d, 0xb5, 0xfe, 0xcb, 0xbc, 0x45,
-	0x1c, 0x6b, 0x66, 0x16, 0x09, 0xc3, 0xcb, 0x07, 0x0e, 0x78, 0x1c, 0xd3, 0x40, 0xc8, 0x88, 0x17,
-	0x24, 0xaf, 0xef, 0xc7, 0x7d, 0x05, 0xf6, 0xfe, 0xb0, 0x60, 0xf9, 0xa2, 0x08, 0xdd, 0xa5, 0xa9,
-	0xfc, 0x7f, 0x1f, 0x41, 0xf3, 0x20, 0x63, 0x51, 0x98, 0x3b, 0xd1, 0xe7, 0xf1, 0x4f, 0x6c, 0x44,
-	0x56, 0x9e, 0xd8, 0xaf, 0xe5, 0xad, 0x7d, 0x3c, 0x83, 0x3e, 0x59, 0xca, 0x6e, 0x65, 0xd7, 0x22,
-	0x3f, 0x40, 0xcb, 0x75, 0x0f, 0xf5, 0x73, 0x6c, 0xc2, 0x84, 0xd4, 0x43, 0xec, 0x39, 0x16, 0x94,
-	0x7c, 0x5b, 0x5b, 0x7f, 0xb1, 0x6e, 0x7a, 0x1f, 0x6c, 0xfc, 0xf9, 0x60, 0x5b, 0xf7, 0x0f, 0xb6,
-	0xf5, 0xcf, 0x83, 0x6d, 0xfd, 0xfa, 0x68, 0x57, 0xee, 0x1f, 0xed, 0xca, 0xdf, 0x8f, 0x76, 0xe5,
-	0x47, 0xf3, 0x49, 0x1a, 0xd6, 0xe5, 0x27, 0xea, 0x8b, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0x2b,
-	0xb9, 0x46, 0x31, 0xb9, 0x06, 0x00, 0x00,
-}
@@ -0,0 +1,38 @@
+package reverse
+
+import (
+	"net"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// tunnelCredentials skips the server TLS handshake on reverse-connect tunnels, already secured by the client TLS
+// handshake performed when dialing the controller, and delegates any other connection to the wrapped credentials.
+type tunnelCredentials struct {
+	credentials.TransportCredentials
+}
+
+// Credentials wraps the gRPC server transport credentials so the server accepts both inbound connections and
+// reverse-connect tunnels. The controller certificate is reported as the peer certificate of the tunnels so the
+// interceptors relying on the client identity apply unchanged.
+func Credentials(inner credentials.TransportCredentials) credentials.TransportCredentials {
+	return &tunnelCredentials{TransportCredentials: inner}
+}
+
+func (c *tunnelCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+
+	tunnel, ok := conn.(*Conn)
+
+	if !ok {
+		return c.TransportCredentials.ServerHandshake(conn)
+	}
+
+	return tunnel, credentials.TLSInfo{
+		State:          tunnel.ConnectionState(),
+		CommonAuthInfo: credentials.CommonAuthInfo{SecurityLevel: credentials.PrivacyAndIntegrity},
+	}, nil
+}
+
+func (c *tunnelCredentials) Clone() credentials.TransportCredentials {
+	return &tunnelCredentials{TransportCredentials: c.TransportCredentials.Clone()}
+}
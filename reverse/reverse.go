@@ -0,0 +1,310 @@
+// Package reverse handles the VSCAN Agent reverse-connect mode where the agent dials out to the controller over
+// mutual TLS and serves its gRPC service over the outbound connections, for agents unable to accept inbound
+// connections.
+//
+// Each tunnel is established as follows:
+//  1. the agent opens a TCP connection to the controller and performs the TLS handshake as client, presenting the
+//     agent certificate and verifying the controller certificate against the CA bundle
+//  2. the agent sends a single line JSON Registration, and the controller replies with a single line JSON
+//     RegistrationReply
+//  3. once accepted, the roles are reversed: the controller acts as the HTTP/2 gRPC client and the agent serves
+//     the VscanAgentService over the connection
+//
+// A tunnel is re-established with exponential backoff whenever it fails or is closed.
+package reverse
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/lucabrasi83/vscan-agent/logging"
+)
+
+// Protocol identifies the reverse-connect registration protocol version
+const Protocol = "vscan-agent-reverse/1"
+
+// maxReplySize bounds the registration reply read from the controller
+const maxReplySize = 4096
+
+// Registration is sent by the agent to the controller once the TLS handshake completed
+type Registration struct {
+	Protocol  string `json:"protocol"`
+	AgentName string `json:"agent_name"`
+	Version   string `json:"version"`
+	Service   string `json:"service"`
+	TunnelID  int    `json:"tunnel_id"`
+}
+
+// RegistrationReply is sent by the controller to accept or refuse the tunnel
+type RegistrationReply struct {
+	Accepted bool   `json:"accepted"`
+	Reason   string `json:"reason"`
+}
+
+// Options represents the reverse-connect settings
+type Options struct {
+	// ControllerAddr is the host:port of the controller reverse-connect endpoint
+	ControllerAddr string
+
+	// TLSConfig returns the client TLS configuration used for each new tunnel so rotated certificates are used
+	TLSConfig func() *tls.Config
+
+	// Registration is sent to the controller on each new tunnel. TunnelID is set per tunnel.
+	Registration Registration
+
+	// Tunnels is the number of tunnels kept established with the controller
+	Tunnels int
+
+	DialTimeout time.Duration
+	MinBackoff  time.Duration
+	MaxBackoff  time.Duration
+}
+
+// Listener is a net.Listener whose connections are dialed out to the controller.
+// It is served by a gRPC server wrapping its transport credentials with Credentials.
+type Listener struct {
+	opts Options
+
+	conns  chan *Conn
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// Listen starts maintaining the tunnels to the controller and returns the Listener accepting them
+func Listen(opts Options) *Listener {
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	l := &Listener{
+		opts:   opts,
+		conns:  make(chan *Conn),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+
+	for i := 0; i < opts.Tunnels; i++ {
+		l.wg.Add(1)
+		go l.maintain(i)
+	}
+
+	return l
+}
+
+// Accept waits for the next tunnel registered with the controller
+func (l *Listener) Accept() (net.Conn, error) {
+	select {
+	case c := <-l.conns:
+		return c, nil
+	case <-l.ctx.Done():
+		return nil, errors.New("reverse-connect listener closed")
+	}
+}
+
+// Close stops re-establishing the tunnels. Tunnels already accepted are closed by the gRPC server.
+func (l *Listener) Close() error {
+	l.cancel()
+	l.wg.Wait()
+	return nil
+}
+
+// Addr returns the controller address
+func (l *Listener) Addr() net.Addr {
+	return controllerAddr(l.opts.ControllerAddr)
+}
+
+// maintain keeps one tunnel established until the listener is closed
+func (l *Listener) maintain(id int) {
+
+	defer l.wg.Done()
+
+	backoff := l.opts.MinBackoff
+
+	for {
+		conn, err := l.connect(id)
+
+		if err != nil {
+
+			logging.VSCANLog("error", "reverse-connect tunnel %v to controller %v failed: %v. Retrying in %v",
+				id, l.opts.ControllerAddr, err, backoff)
+
+			if !l.sleep(jitter(backoff)) {
+				return
+			}
+
+			if backoff *= 2; backoff > l.opts.MaxBackoff {
+				backoff = l.opts.MaxBackoff
+			}
+
+			continue
+		}
+
+		backoff = l.opts.MinBackoff
+
+		logging.VSCANLog("info", "reverse-connect tunnel %v registered with controller %v", id, l.opts.ControllerAddr)
+
+		select {
+		case l.conns <- conn:
+		case <-l.ctx.Done():
+			conn.Close()
+			return
+		}
+
+		// Wait for the gRPC server or the controller to close the tunnel before re-establishing it
+		select {
+		case <-conn.closed:
+			logging.VSCANLog("warning", "reverse-connect tunnel %v to controller %v closed", id,
+				l.opts.ControllerAddr)
+		case <-l.ctx.Done():
+			return
+		}
+
+		if !l.sleep(jitter(l.opts.MinBackoff)) {
+			return
+		}
+	}
+}
+
+// sleep waits for d and returns false if the listener was closed in the meantime
+func (l *Listener) sleep(d time.Duration) bool {
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-l.ctx.Done():
+		return false
+	}
+}
+
+// connect dials the controller, performs the TLS handshake and registers the tunnel
+func (l *Listener) connect(id int) (*Conn, error) {
+
+	ctx, cancel := context.WithTimeout(l.ctx, l.opts.DialTimeout)
+	defer cancel()
+
+	dialer := &net.Dialer{KeepAlive: 30 * time.Second}
+
+	rawConn, err := dialer.DialContext(ctx, "tcp", l.opts.ControllerAddr)
+
+	if err != nil {
+		return nil, fmt.Errorf("error while dialing controller: %v", err)
+	}
+
+	deadline, _ := ctx.Deadline()
+
+	if err := rawConn.SetDeadline(deadline); err != nil {
+		rawConn.Close()
+		return nil, fmt.Errorf("error while setting tunnel deadline: %v", err)
+	}
+
+	tlsConn := tls.Client(rawConn, l.opts.TLSConfig())
+
+	if err := tlsConn.Handshake(); err != nil {
+		rawConn.Close()
+		return nil, fmt.Errorf("TLS handshake with controller failed: %v", err)
+	}
+
+	if err := register(tlsConn, l.opts.Registration, id); err != nil {
+		tlsConn.Close()
+		return nil, err
+	}
+
+	if err := tlsConn.SetDeadline(time.Time{}); err != nil {
+		tlsConn.Close()
+		return nil, fmt.Errorf("error while clearing tunnel deadline: %v", err)
+	}
+
+	return &Conn{Conn: tlsConn, closed: make(chan struct{})}, nil
+}
+
+// register sends the agent registration and reads the controller reply
+func register(conn *tls.Conn, registration Registration, id int) error {
+
+	registration.Protocol = Protocol
+	registration.TunnelID = id
+
+	payload, err := json.Marshal(registration)
+
+	if err != nil {
+		return fmt.Errorf("error while encoding registration: %v", err)
+	}
+
+	if _, err := conn.Write(append(payload, '\n')); err != nil {
+		return fmt.Errorf("error while sending registration: %v", err)
+	}
+
+	// The reply is read one byte at a time so that no byte of the gRPC traffic following it is consumed
+	var line []byte
+
+	buf := make([]byte, 1)
+
+	for {
+		if _, err := conn.Read(buf); err != nil {
+			return fmt.Errorf("error while reading registration reply: %v", err)
+		}
+
+		if buf[0] == '\n' {
+			break
+		}
+
+		if line = append(line, buf[0]); len(line) > maxReplySize {
+			return fmt.Errorf("registration reply exceeds %v bytes", maxReplySize)
+		}
+	}
+
+	var reply RegistrationReply
+
+	if err := json.Unmarshal(line, &reply); err != nil {
+		return fmt.Errorf("invalid registration reply: %v", err)
+	}
+
+	if !reply.Accepted {
+		return fmt.Errorf("registration refused by controller: %v", reply.Reason)
+	}
+
+	return nil
+}
+
+// jitter spreads reconnections of many agents by returning a random duration between d/2 and d
+func jitter(d time.Duration) time.Duration {
+
+	if d <= 1 {
+		return d
+	}
+
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// Conn is an established tunnel to the controller
+type Conn struct {
+	*tls.Conn
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+// Close closes the tunnel and lets the listener re-establish it
+func (c *Conn) Close() error {
+
+	err := c.Conn.Close()
+
+	c.closeOnce.Do(func() { close(c.closed) })
+
+	return err
+}
+
+// controllerAddr implements net.Addr for the controller address
+type controllerAddr string
+
+func (a controllerAddr) Network() string { return "tcp" }
+func (a controllerAddr) String() string  { return string(a) }
@@ -0,0 +1,272 @@
+package reverse
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"io"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// controllerTLS returns the TLS configuration of a stand-in controller with a self-signed certificate and the
+// client TLS configuration trusting it
+func controllerTLS(t *testing.T) (server *tls.Config, client *tls.Config) {
+
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	if err != nil {
+		t.Fatalf("unable to generate controller key: %v", err)
+	}
+
+	tpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "controller.vscan.test"},
+		DNSNames:              []string{"controller.vscan.test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tpl, tpl, key.Public(), key)
+
+	if err != nil {
+		t.Fatalf("unable to create controller certificate: %v", err)
+	}
+
+	cert, _ := x509.ParseCertificate(der)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+
+	server = &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}}}
+	client = &tls.Config{RootCAs: pool, ServerName: "controller.vscan.test"}
+
+	return server, client
+}
+
+// registrationAttempt is a registration received by the stand-in controller
+type registrationAttempt struct {
+	at           time.Time
+	registration Registration
+}
+
+// startController starts a stand-in controller replying to each registration with reply(attempt number). Accepted
+// tunnels echo the traffic following the registration. It returns the controller address and the registrations
+// received.
+func startController(t *testing.T, tlsConfig *tls.Config,
+	reply func(attempt int) RegistrationReply) (string, <-chan registrationAttempt) {
+
+	t.Helper()
+
+	lis, err := tls.Listen("tcp", "127.0.0.1:0", tlsConfig)
+
+	if err != nil {
+		t.Fatalf("unable to start stand-in controller: %v", err)
+	}
+
+	attempts := make(chan registrationAttempt, 100)
+
+	var mu sync.Mutex
+	var attempt int
+
+	var conns sync.WaitGroup
+
+	t.Cleanup(func() {
+		lis.Close()
+		conns.Wait()
+	})
+
+	go func() {
+		for {
+			conn, err := lis.Accept()
+
+			if err != nil {
+				return
+			}
+
+			conns.Add(1)
+
+			go func() {
+				defer conns.Done()
+				defer conn.Close()
+
+				r := bufio.NewReader(conn)
+
+				line, err := r.ReadBytes('\n')
+
+				if err != nil {
+					return
+				}
+
+				var registration Registration
+
+				if err := json.Unmarshal(line, &registration); err != nil {
+					t.Errorf("stand-in controller received an invalid registration %q: %v", line, err)
+					return
+				}
+
+				attempts <- registrationAttempt{at: time.Now(), registration: registration}
+
+				mu.Lock()
+				attempt++
+				rep := reply(attempt)
+				mu.Unlock()
+
+				payload, _ := json.Marshal(rep)
+
+				if _, err := conn.Write(append(payload, '\n')); err != nil || !rep.Accepted {
+					return
+				}
+
+				_, _ = io.Copy(conn, r)
+			}()
+		}
+	}()
+
+	return lis.Addr().String(), attempts
+}
+
+func TestListenerRegistration(t *testing.T) {
+
+	serverTLS, clientTLS := controllerTLS(t)
+
+	addr, attempts := startController(t, serverTLS, func(int) RegistrationReply {
+		return RegistrationReply{Accepted: true}
+	})
+
+	l := Listen(Options{
+		ControllerAddr: addr,
+		TLSConfig:      func() *tls.Config { return clientTLS.Clone() },
+		Registration:   Registration{AgentName: "agent-1", Version: "test", Service: "agentpb.VscanAgentService"},
+		Tunnels:        2,
+		DialTimeout:    5 * time.Second,
+		MinBackoff:     10 * time.Millisecond,
+		MaxBackoff:     100 * time.Millisecond,
+	})
+
+	defer l.Close()
+
+	tunnelIDs := make(map[int]bool)
+
+	for i := 0; i < 2; i++ {
+
+		a := <-attempts
+
+		if a.registration.Protocol != Protocol || a.registration.AgentName != "agent-1" ||
+			a.registration.Service != "agentpb.VscanAgentService" {
+			t.Errorf("controller received registration %+v", a.registration)
+		}
+
+		tunnelIDs[a.registration.TunnelID] = true
+	}
+
+	if !tunnelIDs[0] || !tunnelIDs[1] {
+		t.Errorf("controller received tunnel IDs %v, want 0 and 1", tunnelIDs)
+	}
+
+	conn, err := l.Accept()
+
+	if err != nil {
+		t.Fatalf("no tunnel accepted: %v", err)
+	}
+
+	defer conn.Close()
+
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	if _, err := io.WriteString(conn, "ping"); err != nil {
+		t.Fatalf("unable to write through the tunnel: %v", err)
+	}
+
+	got := make([]byte, 4)
+
+	if _, err := io.ReadFull(conn, got); err != nil || string(got) != "ping" {
+		t.Fatalf("read %q through the tunnel (%v), want %q", got, err, "ping")
+	}
+
+	// Tunnel RPCs are reported with the controller certificate as the peer identity
+	_, authInfo, err := Credentials(credentials.NewTLS(&tls.Config{})).ServerHandshake(conn)
+
+	if err != nil {
+		t.Fatalf("tunnel server handshake failed: %v", err)
+	}
+
+	tlsInfo, ok := authInfo.(credentials.TLSInfo)
+
+	if !ok || len(tlsInfo.State.PeerCertificates) == 0 ||
+		tlsInfo.State.PeerCertificates[0].Subject.CommonName != "controller.vscan.test" {
+		t.Errorf("tunnel peer identity is not the controller certificate: %+v", authInfo)
+	}
+}
+
+func TestListenerReconnectBackoff(t *testing.T) {
+
+	const (
+		minBackoff = 20 * time.Millisecond
+		maxBackoff = 80 * time.Millisecond
+		refusals   = 5
+	)
+
+	serverTLS, clientTLS := controllerTLS(t)
+
+	addr, attempts := startController(t, serverTLS, func(attempt int) RegistrationReply {
+		return RegistrationReply{Accepted: attempt > refusals, Reason: "agent not enrolled yet"}
+	})
+
+	l := Listen(Options{
+		ControllerAddr: addr,
+		TLSConfig:      func() *tls.Config { return clientTLS.Clone() },
+		Tunnels:        1,
+		DialTimeout:    5 * time.Second,
+		MinBackoff:     minBackoff,
+		MaxBackoff:     maxBackoff,
+	})
+
+	defer l.Close()
+
+	conn, err := l.Accept()
+
+	if err != nil {
+		t.Fatalf("no tunnel accepted after the refusals: %v", err)
+	}
+
+	conn.Close()
+
+	var previous time.Time
+
+	backoff := minBackoff
+
+	for i := 0; i <= refusals; i++ {
+
+		a := <-attempts
+
+		if i > 0 {
+
+			// Retries are delayed by a jittered exponential backoff, between half and the full backoff
+			if gap := a.at.Sub(previous); gap < backoff/2 {
+				t.Errorf("registration %v retried after %v, want at least %v", i, gap, backoff/2)
+			}
+
+			if backoff *= 2; backoff > maxBackoff {
+				backoff = maxBackoff
+			}
+		}
+
+		previous = a.at
+	}
+}
@@ -0,0 +1,310 @@
+package scanagent
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lucabrasi83/vscan-agent/config"
+	"github.com/lucabrasi83/vscan-agent/initializer"
+	"github.com/lucabrasi83/vscan-agent/logging"
+	agentpb "github.com/lucabrasi83/vscan-agent/proto"
+	"github.com/shirou/gopsutil/cpu"
+	"github.com/shirou/gopsutil/disk"
+	"github.com/shirou/gopsutil/host"
+	"github.com/shirou/gopsutil/load"
+	"github.com/shirou/gopsutil/mem"
+)
+
+// jovalBackendName is the name of the Joval scanner backend reported to the controller
+const jovalBackendName = "joval"
+
+// licenseDateLayouts are the date formats accepted for the Joval license expiry
+var licenseDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+	"01/02/2006",
+	"Jan 2, 2006",
+	"2 Jan 2006",
+}
+
+// GetAgentInfo returns the VSCAN Agent build, platform, live resource usage, scanner backends and scan jobs
+// details. Details which cannot be fetched are left empty and logged.
+func (s *AgentServer) GetAgentInfo(ctx context.Context, req *agentpb.AgentInfoRequest) (*agentpb.AgentInfoResponse,
+	error) {
+	return s.agentInfo(), nil
+}
+
+// agentInfo gathers the VSCAN Agent details returned by GetAgentInfo and sent on registration to the controller
+func (s *AgentServer) agentInfo() *agentpb.AgentInfoResponse {
+
+	info := &agentpb.AgentInfoResponse{
+		Build: &agentpb.AgentBuildInfo{
+			Version:   initializer.Version,
+			Commit:    initializer.Commit,
+			BuiltAt:   initializer.BuiltAt,
+			BuiltOn:   initializer.BuiltOn,
+			GoVersion: runtime.Version(),
+		},
+		Platform:        platformInfo(),
+		Resources:       resourceUsage(),
+		ScannerBackends: []*agentpb.ScannerBackend{jovalBackend()},
+		Jobs:            scanJobs.counts(),
+	}
+
+	if s.drainer != nil {
+		info.Draining = s.drainer.draining()
+	}
+
+	if s.certManager != nil {
+		info.ServerCertificateDaysToExpiry = int32(s.certManager.ServerDaysToExpiry())
+	}
+
+	if !s.startedAt.IsZero() {
+		info.UptimeSeconds = int64(time.Since(s.startedAt).Seconds())
+	}
+
+	return info
+}
+
+// platformInfo returns the host, operating system and CPU details
+func platformInfo() *agentpb.AgentPlatformInfo {
+
+	platform := &agentpb.AgentPlatformInfo{
+		Hostname:     hostname,
+		Architecture: runtime.GOARCH,
+		CpuCount:     int32(runtime.NumCPU()),
+	}
+
+	hostDetails, err := host.Info()
+
+	if err != nil {
+		logging.VSCANLog("error", "Unable to fetch platform details %v", err)
+	} else {
+		platform.Os = hostDetails.OS
+		platform.Platform = hostDetails.Platform
+		platform.PlatformVersion = hostDetails.PlatformVersion
+		platform.KernelVersion = hostDetails.KernelVersion
+	}
+
+	cpuDetails, err := cpu.Info()
+
+	if err != nil {
+		logging.VSCANLog("error", "Unable to fetch CPU details: %v", err)
+	} else if len(cpuDetails) > 0 {
+		platform.CpuModel = cpuDetails[0].ModelName
+	}
+
+	return platform
+}
+
+// resourceUsage returns the live memory usage, disk usage of the scan jobs partition and System Load averages
+func resourceUsage() *agentpb.AgentResourceUsage {
+
+	usage := &agentpb.AgentResourceUsage{}
+
+	memUsage, err := mem.VirtualMemory()
+
+	if err != nil {
+		logging.VSCANLog("error", "Unable to fetch Memory details: %v", err)
+	} else {
+		usage.MemoryTotalBytes = memUsage.Total
+		usage.MemoryAvailableBytes = memUsage.Available
+		usage.MemoryUsedPercent = memUsage.UsedPercent
+	}
+
+	diskUsage, err := disk.Usage(config.Get().Joval.ScanJobsDir)
+
+	if err != nil {
+		logging.VSCANLog("error", "Unable to fetch disk Usage details: %v", err)
+	} else {
+		usage.DiskTotalBytes = diskUsage.Total
+		usage.DiskFreeBytes = diskUsage.Free
+		usage.DiskUsedPercent = diskUsage.UsedPercent
+	}
+
+	loadAverages, err := load.Avg()
+
+	if err != nil {
+		logging.VSCANLog("error", "Unable to fetch System Load details: %v", err)
+	} else {
+		usage.LoadAverage_1 = loadAverages.Load1
+		usage.LoadAverage_5 = loadAverages.Load5
+		usage.LoadAverage_15 = loadAverages.Load15
+	}
+
+	return usage
+}
+
+// jovalBackend returns the Joval scanner version, license expiry and availability
+func jovalBackend() *agentpb.ScannerBackend {
+
+	jovalConfig := config.Get().Joval
+
+	backend := &agentpb.ScannerBackend{Name: jovalBackendName}
+
+	version, err := jarVersion(jovalConfig.JarFile)
+
+	if err != nil {
+		logging.VSCANLog("error", "Unable to fetch Joval version: %v", err)
+	}
+
+	backend.Version = version
+
+	expiry, err := licenseExpiry(jovalConfig.LicenseFile)
+
+	if err != nil {
+		logging.VSCANLog("error", "Unable to fetch Joval license expiry: %v", err)
+	} else {
+		backend.LicenseExpiresUnix = expiry.Unix()
+		backend.LicenseDaysToExpiry = int32(time.Until(expiry).Hours() / 24)
+	}
+
+	_, errJava := exec.LookPath(jovalConfig.JavaBin)
+
+	backend.Available = errJava == nil &&
+		checkRegularFile(jovalConfig.JarFile) == nil &&
+		checkLicenseFile(jovalConfig.LicenseFile) == nil
+
+	return backend
+}
+
+// jarVersion returns the Implementation-Version, or Bundle-Version, of a JAR file manifest
+func jarVersion(jarFile string) (string, error) {
+
+	jar, err := zip.OpenReader(jarFile)
+
+	if err != nil {
+		return "", fmt.Errorf("error while opening JAR file %v: %v", jarFile, err)
+	}
+
+	defer jar.Close()
+
+	for _, f := range jar.File {
+
+		if f.Name != "META-INF/MANIFEST.MF" {
+			continue
+		}
+
+		manifest, err := f.Open()
+
+		if err != nil {
+			return "", fmt.Errorf("error while reading JAR file %v manifest: %v", jarFile, err)
+		}
+
+		defer manifest.Close()
+
+		attributes := make(map[string]string)
+
+		scanner := bufio.NewScanner(manifest)
+
+		for scanner.Scan() {
+			if kv := strings.SplitN(scanner.Text(), ":", 2); len(kv) == 2 {
+				attributes[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+			}
+		}
+
+		for _, attr := range []string{"Implementation-Version", "Bundle-Version", "Specification-Version"} {
+			if v := attributes[attr]; v != "" {
+				return v, nil
+			}
+		}
+
+		return "", fmt.Errorf("JAR file %v manifest has no version attribute", jarFile)
+	}
+
+	return "", fmt.Errorf("JAR file %v has no manifest", jarFile)
+}
+
+// licenseExpiry returns the expiry time found in the Joval license file.
+// The first element or attribute whose name contains "expir" (e.g. expiration, expires) and holds a date is used.
+func licenseExpiry(licenseFile string) (time.Time, error) {
+
+	f, err := os.Open(licenseFile)
+
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	defer f.Close()
+
+	decoder := xml.NewDecoder(f)
+
+	var inExpiryElement bool
+
+	for {
+		token, err := decoder.Token()
+
+		if err == io.EOF {
+			return time.Time{}, fmt.Errorf("no expiry date found in license file %v", licenseFile)
+		}
+
+		if err != nil {
+			return time.Time{}, fmt.Errorf("license file %v is invalid: %v", licenseFile, err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+
+			for _, attr := range t.Attr {
+				if isExpiryName(attr.Name.Local) {
+					if expiry, ok := parseLicenseDate(attr.Value); ok {
+						return expiry, nil
+					}
+				}
+			}
+
+			inExpiryElement = isExpiryName(t.Name.Local)
+
+		case xml.CharData:
+
+			if inExpiryElement {
+				if expiry, ok := parseLicenseDate(string(t)); ok {
+					return expiry, nil
+				}
+			}
+
+		case xml.EndElement:
+			inExpiryElement = false
+		}
+	}
+}
+
+func isExpiryName(name string) bool {
+	return strings.Contains(strings.ToLower(name), "expir")
+}
+
+// parseLicenseDate parses a date in one of the supported layouts or a Unix epoch in seconds or milliseconds
+func parseLicenseDate(value string) (time.Time, bool) {
+
+	value = strings.TrimSpace(value)
+
+	for _, layout := range licenseDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+
+	epoch, err := strconv.ParseInt(value, 10, 64)
+
+	if err != nil || epoch <= 0 {
+		return time.Time{}, false
+	}
+
+	// Epochs beyond year 5138 in seconds are assumed to be in milliseconds
+	if epoch > 1e11 {
+		return time.Unix(0, epoch*int64(time.Millisecond)), true
+	}
+
+	return time.Unix(epoch, 0), true
+}
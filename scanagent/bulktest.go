@@ -0,0 +1,173 @@
+package scanagent
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/lucabrasi83/vscan-agent/config"
+	"github.com/lucabrasi83/vscan-agent/logging"
+	agentpb "github.com/lucabrasi83/vscan-agent/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultBulkTestConcurrency is the number of targets tested in parallel if not specified in the request
+	defaultBulkTestConcurrency = 10
+
+	// maxBulkTestConcurrency is the maximum number of targets tested in parallel regardless of the request
+	maxBulkTestConcurrency = 100
+)
+
+func (*AgentServer) BulkSSHConnectivityTest(req *agentpb.BulkSSHTestRequest,
+	stream agentpb.VscanAgentService_BulkSSHConnectivityTestServer) error {
+
+	logging.VSCANLog("info", "Received Request to Test SSH connectivity to %d target(s) with concurrency %d",
+		len(req.GetSshTargets()), req.GetConcurrency())
+
+	if len(req.GetSshTargets()) == 0 {
+		return status.Errorf(codes.InvalidArgument, "Agent %v - no SSH target specified in argument", hostname)
+	}
+
+	concurrency := int(req.GetConcurrency())
+
+	switch {
+	case concurrency <= 0:
+		concurrency = defaultBulkTestConcurrency
+	case concurrency > maxBulkTestConcurrency:
+		concurrency = maxBulkTestConcurrency
+	}
+
+	timeout := config.Get().SSH.DialTimeout
+
+	if req.GetTimeoutSeconds() > 0 {
+		timeout = time.Duration(req.GetTimeoutSeconds()) * time.Second
+	}
+
+	start := time.Now()
+
+	// Targets are dispatched to the workers pool through the jobs channel.
+	// Workers report results through the results channel which is the only one sending on the stream
+	// as grpc.ServerStream SendMsg is not safe to call from multiple goroutines.
+	jobs := make(chan int)
+	results := make(chan *agentpb.BulkSSHTestResult)
+
+	var wg sync.WaitGroup
+
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range jobs {
+				results <- bulkTestTarget(stream.Context(), i, req.GetSshTargets()[i], timeout)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+
+		for i := range req.GetSshTargets() {
+			select {
+			case jobs <- i:
+			case <-stream.Context().Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	summary := &agentpb.BulkSSHTestSummary{}
+
+	var errStream error
+
+	for res := range results {
+
+		countFailure(summary, res.GetFailureReason())
+
+		// Keep draining results so workers can exit but stop streaming once the client is gone
+		if errStream != nil {
+			continue
+		}
+
+		errStream = stream.Send(&agentpb.BulkSSHTestResponse{
+			Result: &agentpb.BulkSSHTestResponse_TargetResult{TargetResult: res},
+		})
+
+		if errStream != nil {
+			logging.VSCANLog("error", "Failed to send bulk SSH test result stream: %v", errStream)
+		}
+	}
+
+	if errStream != nil {
+		return status.Errorf(codes.Internal, "agent %v - failed to send bulk SSH test result stream: %v",
+			hostname, errStream)
+	}
+
+	if err := stream.Context().Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+
+	summary.DurationMs = time.Since(start).Milliseconds()
+
+	return stream.Send(&agentpb.BulkSSHTestResponse{
+		Result: &agentpb.BulkSSHTestResponse_Summary{Summary: summary},
+	})
+}
+
+// bulkTestTarget tests SSH connectivity to a single target of a bulk test request
+func bulkTestTarget(ctx context.Context, index int, target *agentpb.SSHGateway,
+	timeout time.Duration) *agentpb.BulkSSHTestResult {
+
+	start := time.Now()
+
+	res, _ := testSSHGateway(ctx, target, timeout)
+
+	return &agentpb.BulkSSHTestResult{
+		TargetIndex:     int32(index),
+		GatewayName:     target.GetGatewayName(),
+		GatewayIp:       target.GetGatewayIp(),
+		SshTestResponse: res,
+		FailureReason:   res.GetSshFailureReason(),
+		DurationMs:      time.Since(start).Milliseconds(),
+	}
+}
+
+// countFailure increments the bulk test summary counters for the given failure reason
+func countFailure(summary *agentpb.BulkSSHTestSummary, reason agentpb.SSHFailureReason) {
+
+	summary.Total++
+
+	if reason == agentpb.SSHFailureReason_SSH_FAILURE_NONE {
+		summary.Succeeded++
+		return
+	}
+
+	summary.Failed++
+
+	switch reason {
+	case agentpb.SSHFailureReason_SSH_FAILURE_DNS:
+		summary.DnsFailures++
+	case agentpb.SSHFailureReason_SSH_FAILURE_CONNECTION_REFUSED:
+		summary.ConnectionRefused++
+	case agentpb.SSHFailureReason_SSH_FAILURE_TIMEOUT:
+		summary.Timeouts++
+	case agentpb.SSHFailureReason_SSH_FAILURE_AUTH_REJECTED:
+		summary.AuthRejected++
+	case agentpb.SSHFailureReason_SSH_FAILURE_HOST_KEY_MISMATCH:
+		summary.HostKeyMismatches++
+	case agentpb.SSHFailureReason_SSH_FAILURE_HANDSHAKE:
+		summary.HandshakeFailures++
+	case agentpb.SSHFailureReason_SSH_FAILURE_NO_SUPPORTED_AUTH_METHODS:
+		summary.NoSupportedAuthMethods++
+	default:
+		summary.OtherFailures++
+	}
+}
@@ -0,0 +1,78 @@
+package scanagent
+
+import (
+	"context"
+
+	"github.com/lucabrasi83/vscan-agent/certmanager"
+	"github.com/lucabrasi83/vscan-agent/config"
+	"github.com/lucabrasi83/vscan-agent/logging"
+)
+
+// loadCertificates enrolls the server certificate from the ACME CA if enabled and loads the TLS certificates.
+// Certificates renewal, reload on file change and expiry monitoring run in the background until stop is closed.
+func loadCertificates(stop <-chan struct{}) *certmanager.Manager {
+
+	tlsConfig := config.Get().TLS
+
+	var enroller *certmanager.Enroller
+
+	if acmeConfig := config.Get().ACME; acmeConfig.Enabled {
+
+		var err error
+
+		enroller, err = certmanager.NewEnroller(certmanager.ACMEConfig{
+			DirectoryURL:        acmeConfig.DirectoryURL,
+			Email:               acmeConfig.Email,
+			Domains:             acmeConfig.Domains,
+			HTTPChallengeAddr:   acmeConfig.HTTPChallengeAddr,
+			AccountKeyFile:      acmeConfig.AccountKeyFile,
+			DirectoryCACertFile: acmeConfig.DirectoryCACertFile,
+			RenewBefore:         acmeConfig.RenewBefore,
+		}, tlsConfig.CertFile, tlsConfig.KeyFile)
+
+		if err != nil {
+			logging.VSCANLog("fatal", "unable to set up ACME certificate enrollment: %v", err)
+		}
+
+		if enroller.NeedsRenewal() {
+
+			if err := enroller.Enroll(context.Background()); err != nil {
+
+				// Keep serving with the current certificate while it is still valid
+				if !enroller.HasValidCertificate() {
+					logging.VSCANLog("fatal", "unable to enroll VSCAN agent server certificate: %v", err)
+				}
+
+				logging.VSCANLog("error", "unable to renew VSCAN agent server certificate: %v", err)
+			}
+		}
+	}
+
+	certManager, err := certmanager.New(tlsConfig.CertFile, tlsConfig.KeyFile, tlsConfig.CACertFile)
+
+	if err != nil {
+		logging.VSCANLog("fatal", "unable to load TLS certificates: %v", err)
+	}
+
+	certManager.CheckExpiry(tlsConfig.ExpiryWarningDays)
+
+	if tlsConfig.RefuseExpired && certManager.ServerExpired() {
+		logging.VSCANLog("fatal", "VSCAN agent server certificate %v is expired, refusing to start", tlsConfig.CertFile)
+	}
+
+	certManager.MonitorExpiry(tlsConfig.ExpiryCheckInterval, tlsConfig.ExpiryWarningDays, stop)
+
+	if err := certManager.Watch(stop); err != nil {
+		logging.VSCANLog("error", "TLS certificates will only be reloaded on SIGHUP: %v", err)
+	}
+
+	if enroller != nil {
+		enroller.RenewLoop(config.Get().ACME.CheckInterval, stop, func() {
+			if err := certManager.Reload(); err != nil {
+				logging.VSCANLog("error", "unable to reload renewed TLS certificates: %v", err)
+			}
+		})
+	}
+
+	return certManager
+}
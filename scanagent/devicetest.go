@@ -0,0 +1,412 @@
+package scanagent
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lucabrasi83/vscan-agent/config"
+	"github.com/lucabrasi83/vscan-agent/inibuilder"
+	"github.com/lucabrasi83/vscan-agent/logging"
+	agentpb "github.com/lucabrasi83/vscan-agent/proto"
+	"github.com/lucabrasi83/vscan-agent/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/crypto/ssh"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxConcurrentDeviceTests is the maximum number of devices tested in parallel for a single request
+const maxConcurrentDeviceTests = 10
+
+func (*AgentServer) DeviceConnectivityTest(ctx context.Context, req *agentpb.DeviceTestRequest) (*agentpb.
+	DeviceTestResponse, error) {
+
+	logging.VSCANLog("info", "Received Request to Test SSH connectivity to Device(s): %v", req.GetDevices())
+
+	if len(req.GetDevices()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Agent %v - no device specified in argument", hostname)
+	}
+
+	if err := validateSSHCertificates(req.GetSshGateway(), req.GetUserDeviceCredentials()); err != nil {
+		return nil, err
+	}
+
+	timeout := config.Get().SSH.DialTimeout
+
+	if req.GetTimeoutSeconds() > 0 {
+		timeout = time.Duration(req.GetTimeoutSeconds()) * time.Second
+	}
+
+	results := make([]*agentpb.DeviceTestResult, len(req.GetDevices()))
+
+	runThroughGateway(ctx, req.GetSshGateway(), req.GetDevices(), timeout,
+		func(i int, d *agentpb.Device, gwClient *gatewayClient) {
+			results[i] = testDevice(ctx, gwClient, d, req.GetUserDeviceCredentials(), req.GetCheckEnableMode(),
+				timeout)
+		},
+		func(i int, d *agentpb.Device, errGateway error) {
+			results[i] = &agentpb.DeviceTestResult{
+				DeviceName:    d.GetDeviceName(),
+				IpAddress:     d.GetIpAddress(),
+				SshTestResult: errGateway.Error(),
+			}
+		})
+
+	return &agentpb.DeviceTestResponse{DeviceTestResults: results}, nil
+}
+
+// gatewayClient is an SSH connection to a gateway used to reach devices
+type gatewayClient struct {
+	*ssh.Client
+
+	// ip is the gateway IP address as set in the request
+	ip string
+}
+
+// runThroughGateway connects to the SSH gateway when one is specified and calls fn for each device with the gateway
+// client, nil without gateway, as runPerDevice does. If the gateway cannot be reached, fail is called for each
+// device with the gateway error instead.
+func runThroughGateway(ctx context.Context, gw *agentpb.SSHGateway, devices []*agentpb.Device,
+	timeout time.Duration, fn func(i int, d *agentpb.Device, gwClient *gatewayClient),
+	fail func(i int, d *agentpb.Device, errGateway error)) {
+
+	var gwClient *gatewayClient
+
+	if gw.GetGatewayIp() != "" {
+
+		client, _, _, err := dialGateway(ctx, gw, timeout)
+
+		if err != nil {
+			errGateway := fmt.Errorf("unable to connect to SSH gateway %v: %v", gw.GetGatewayIp(), err)
+
+			for i, d := range devices {
+				fail(i, d, errGateway)
+			}
+
+			return
+		}
+
+		defer client.Close()
+
+		gwClient = &gatewayClient{Client: client, ip: gw.GetGatewayIp()}
+	}
+
+	runPerDevice(devices, func(i int, d *agentpb.Device) {
+		fn(i, d, gwClient)
+	})
+}
+
+// runPerDevice calls fn for each device, with at most maxConcurrentDeviceTests devices handled in parallel.
+// It returns once fn returned for all devices.
+func runPerDevice(devices []*agentpb.Device, fn func(i int, d *agentpb.Device)) {
+
+	// Semaphore channel to limit the number of devices handled in parallel
+	sem := make(chan struct{}, maxConcurrentDeviceTests)
+
+	var wg sync.WaitGroup
+
+	for i, d := range devices {
+
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(i int, d *agentpb.Device) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			fn(i, d)
+		}(i, d)
+	}
+
+	wg.Wait()
+}
+
+// testDevice connects to the device with its credentials and optionally verifies enable mode
+func testDevice(ctx context.Context, gwClient *gatewayClient, d *agentpb.Device, creds *agentpb.UserDeviceCredentials,
+	checkEnable bool, timeout time.Duration) (result *agentpb.DeviceTestResult) {
+
+	result = &agentpb.DeviceTestResult{
+		DeviceName: d.GetDeviceName(),
+		IpAddress:  d.GetIpAddress(),
+	}
+
+	ctx, span := tracing.StartSpan(ctx, "ssh.testDevice", attribute.String("device.ip", d.GetIpAddress()))
+
+	defer func() {
+		var errTest error
+
+		if !result.SshCanConnect || (result.EnableModeChecked && !result.EnableModeOk) {
+			errTest = errors.New(result.SshTestResult)
+		}
+
+		span.SetAttributes(attribute.Int64("ssh.latency_ms", result.LatencyMs))
+		tracing.EndSpan(span, errTest)
+	}()
+
+	start := time.Now()
+
+	client, err := dialDevice(ctx, gwClient, d.GetIpAddress(), creds, timeout)
+
+	if err != nil {
+		result.SshTestResult = err.Error()
+		return result
+	}
+
+	defer client.Close()
+
+	result.LatencyMs = time.Since(start).Milliseconds()
+	result.SshCanConnect = true
+	result.SshServerBanner = string(client.ServerVersion())
+	result.SshTestResult = "SSH connection successful"
+
+	if !checkEnable {
+		return result
+	}
+
+	result.EnableModeChecked = true
+
+	// The enable mode is verified with the privilege method and password Joval scans with
+	method, privPassword := inibuilder.CredentialsPrivilege(creds)
+
+	if method != agentpb.PrivilegeMethod_ENABLE {
+		result.SshTestResult = fmt.Sprintf("enable mode check requires %v privilege method, got %v",
+			agentpb.PrivilegeMethod_ENABLE, method)
+
+		return result
+	}
+
+	if err := checkEnableMode(ctx, client, privPassword, timeout); err != nil {
+		result.SshTestResult = fmt.Sprintf("enable mode check failed: %v", err)
+		return result
+	}
+
+	result.EnableModeOk = true
+	result.SshTestResult = "SSH connection and enable mode successful"
+
+	return result
+}
+
+// dialDevice establishes an SSH connection to the device, tunneled through the gateway client if not nil.
+// The host keys of devices reached through a gateway are verified against the known_hosts entries of the gateway
+// as sites behind different gateways often reuse the same private addresses.
+func dialDevice(ctx context.Context, gwClient *gatewayClient, ip string, creds *agentpb.UserDeviceCredentials,
+	timeout time.Duration) (*ssh.Client, error) {
+
+	sshAuthMethods, err := buildDeviceAuthMethods(creds)
+
+	if err != nil {
+		return nil, err
+	}
+
+	hostKey := newHostKeyVerifier(nil)
+
+	sshConfig := &ssh.ClientConfig{
+		User:            creds.GetUsername(),
+		Auth:            sshAuthMethods,
+		HostKeyCallback: hostKey.Callback,
+		Timeout:         timeout,
+	}
+
+	addr := net.JoinHostPort(ip, "22")
+
+	// Devices reached without gateway go through the agent default SSH proxy if any
+	if gwClient == nil {
+
+		client, _, err := dialSSH(ctx, addr, "", sshConfig, timeout)
+
+		if err != nil {
+			return nil, err
+		}
+
+		hostKey.recordTrusted()
+
+		return client, nil
+	}
+
+	hostKey.scopeToGateway(gwClient.ip)
+
+	conn, err := gwClient.Dial("tcp", addr)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to reach device %v through SSH gateway: %v", ip, err)
+	}
+
+	// Abort the SSH handshake if it does not complete within the timeout or the request is canceled.
+	// ssh.ClientConfig Timeout only applies to the TCP connection which is already established by the gateway.
+	ctxTimeout, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	stop := closeOnCancel(ctxTimeout, conn)
+
+	c, chans, reqs, err := ssh.NewClientConn(conn, addr, sshConfig)
+
+	if !stop() && err == nil {
+		c.Close()
+		err = ctxTimeout.Err()
+	}
+
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	hostKey.recordTrusted()
+
+	return ssh.NewClient(c, chans, reqs), nil
+}
+
+// checkEnableMode opens an interactive shell on the device and verifies the enable password grants privileged mode.
+// The shell is closed once ctx is done.
+func checkEnableMode(ctx context.Context, client *ssh.Client, enablePassword string, timeout time.Duration) error {
+
+	session, err := client.NewSession()
+
+	if err != nil {
+		return fmt.Errorf("unable to open SSH session: %v", err)
+	}
+
+	defer session.Close()
+
+	defer closeOnCancel(ctx, session)()
+
+	modes := ssh.TerminalModes{
+		ssh.ECHO:          0,
+		ssh.TTY_OP_ISPEED: 14400,
+		ssh.TTY_OP_OSPEED: 14400,
+	}
+
+	if err := session.RequestPty("vt100", 80, 200, modes); err != nil {
+		return fmt.Errorf("unable to request pseudo terminal: %v", err)
+	}
+
+	stdin, err := session.StdinPipe()
+
+	if err != nil {
+		return err
+	}
+
+	stdout, err := session.StdoutPipe()
+
+	if err != nil {
+		return err
+	}
+
+	if err := session.Shell(); err != nil {
+		return fmt.Errorf("unable to start shell: %v", err)
+	}
+
+	sh := newShellReader(stdout)
+
+	prompt, err := sh.expect(timeout, ">", "#")
+
+	if err != nil {
+		return fmt.Errorf("no CLI prompt received: %v", err)
+	}
+
+	// Already in privileged mode
+	if prompt == "#" {
+		return nil
+	}
+
+	if _, err := io.WriteString(stdin, "enable\n"); err != nil {
+		return err
+	}
+
+	if _, err := sh.expect(timeout, "assword:"); err != nil {
+		return fmt.Errorf("no enable password prompt received: %v", err)
+	}
+
+	if _, err := io.WriteString(stdin, enablePassword+"\n"); err != nil {
+		return err
+	}
+
+	prompt, err = sh.expect(timeout, "#", ">", "assword:")
+
+	if err != nil {
+		return fmt.Errorf("no CLI prompt received after enable password: %v", err)
+	}
+
+	if prompt != "#" {
+		return fmt.Errorf("enable password rejected by device")
+	}
+
+	_, _ = io.WriteString(stdin, "exit\n")
+
+	return nil
+}
+
+// shellReader accumulates the output of an interactive shell to match expected prompts
+type shellReader struct {
+	mu     sync.Mutex
+	buf    bytes.Buffer
+	update chan struct{}
+	err    error
+}
+
+func newShellReader(r io.Reader) *shellReader {
+
+	sh := &shellReader{update: make(chan struct{}, 1)}
+
+	go func() {
+		chunk := make([]byte, 1024)
+		for {
+			n, err := r.Read(chunk)
+
+			sh.mu.Lock()
+			sh.buf.Write(chunk[:n])
+			sh.err = err
+			sh.mu.Unlock()
+
+			select {
+			case sh.update <- struct{}{}:
+			default:
+			}
+
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	return sh
+}
+
+// expect waits until the trimmed shell output ends with one of the patterns and returns the matched pattern.
+// The shell output is consumed on match.
+func (sh *shellReader) expect(timeout time.Duration, patterns ...string) (string, error) {
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		sh.mu.Lock()
+		out := strings.TrimRight(sh.buf.String(), " \r\n")
+		readErr := sh.err
+
+		for _, p := range patterns {
+			if strings.HasSuffix(out, p) {
+				sh.buf.Reset()
+				sh.mu.Unlock()
+				return p, nil
+			}
+		}
+		sh.mu.Unlock()
+
+		if readErr != nil {
+			return "", readErr
+		}
+
+		select {
+		case <-sh.update:
+		case <-timer.C:
+			return "", fmt.Errorf("timeout after %v waiting for %q", timeout, patterns)
+		}
+	}
+}
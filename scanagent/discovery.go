@@ -0,0 +1,328 @@
+package scanagent
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/lucabrasi83/vscan-agent/config"
+	"github.com/lucabrasi83/vscan-agent/inibuilder"
+	"github.com/lucabrasi83/vscan-agent/logging"
+	agentpb "github.com/lucabrasi83/vscan-agent/proto"
+	"github.com/lucabrasi83/vscan-agent/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/crypto/ssh"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fingerprinter describes how to identify a device vendor from a CLI command output and parse its inventory details
+type fingerprinter struct {
+	vendor  agentpb.DeviceVendor
+	command string
+	match   *regexp.Regexp
+	parse   func(out string, inv *agentpb.DeviceInventory)
+}
+
+// fingerprinters is the ordered list of fingerprinters used to probe a device when its vendor is not specified.
+// Fingerprinters sharing the same command are evaluated against a single command output.
+var fingerprinters = []fingerprinter{
+	{
+		vendor:  agentpb.DeviceVendor_CISCO,
+		command: "show version",
+		match:   regexp.MustCompile(`(?i)cisco (ios|nexus|adaptive security|internetwork)`),
+		parse:   parseCiscoVersion,
+	},
+	{
+		vendor:  agentpb.DeviceVendor_ARISTA,
+		command: "show version",
+		match:   regexp.MustCompile(`(?i)arista`),
+		parse:   parseAristaVersion,
+	},
+	{
+		vendor:  agentpb.DeviceVendor_JUNIPER,
+		command: "show version",
+		match:   regexp.MustCompile(`(?i)junos`),
+		parse:   parseJuniperVersion,
+	},
+	{
+		vendor:  agentpb.DeviceVendor_PALO_ALTO,
+		command: "show system info",
+		match:   regexp.MustCompile(`(?m)^\s*sw-version:`),
+		parse:   parsePaloAltoSystemInfo,
+	},
+	{
+		vendor:  agentpb.DeviceVendor_LINUX,
+		command: "cat /etc/os-release; echo \"UNAME=$(uname -sr)\"; echo \"HOSTNAME=$(hostname)\"",
+		match:   regexp.MustCompile(`(?m)^UNAME=Linux`),
+		parse:   parseLinuxRelease,
+	},
+}
+
+var (
+	ciscoIOSXERegexp   = regexp.MustCompile(`IOS[ -]XE Software.*?Version ([^\s,]+)`)
+	ciscoIOSXRRegexp   = regexp.MustCompile(`Cisco IOS XR Software.*?Version ([^\s,\[]+)`)
+	ciscoNXOSRegexp    = regexp.MustCompile(`(?m)(?:NXOS|system):\s+version\s+(\S+)`)
+	ciscoASARegexp     = regexp.MustCompile(`Cisco Adaptive Security Appliance Software Version (\S+)`)
+	ciscoIOSRegexp     = regexp.MustCompile(`Cisco (?:IOS|Internetwork Operating System) Software.*?Version ([^\s,]+)`)
+	ciscoPlatformRe    = regexp.MustCompile(`(?mi)^\s*cisco (.+?) (?:\(.*\) processor|chassis|processor)`)
+	ciscoASAPlatformRe = regexp.MustCompile(`(?m)^Hardware:\s+([^,\s]+)`)
+	ciscoHostnameRe    = regexp.MustCompile(`(?m)^\s*(\S+) uptime is`)
+	ciscoSerialRe      = regexp.MustCompile(`(?mi)(?:processor board id|system serial number\s*:|serial number:)\s*(\S+)`)
+
+	aristaPlatformRe = regexp.MustCompile(`(?m)^Arista (\S+)`)
+	aristaVersionRe  = regexp.MustCompile(`(?m)^Software image version:\s*(\S+)`)
+	aristaSerialRe   = regexp.MustCompile(`(?m)^Serial number:\s*(\S+)`)
+
+	juniperHostnameRe = regexp.MustCompile(`(?m)^Hostname:\s*(\S+)`)
+	juniperModelRe    = regexp.MustCompile(`(?m)^Model:\s*(\S+)`)
+	juniperVersionRe  = regexp.MustCompile(`(?m)^(?:Junos:\s*(\S+)|JUNOS .*?\[(\S+)\])`)
+
+	paloAltoFieldRe = regexp.MustCompile(`(?m)^\s*([a-z-]+):\s*(.*?)\s*$`)
+	linuxFieldRe    = regexp.MustCompile(`(?m)^([A-Z_]+)="?(.*?)"?\s*$`)
+)
+
+func (*AgentServer) DiscoverDevices(ctx context.Context, req *agentpb.DiscoveryRequest) (*agentpb.
+	DiscoveryResponse, error) {
+
+	logging.VSCANLog("info", "Received Request to Discover Device(s): %v", req.GetDevices())
+
+	if len(req.GetDevices()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Agent %v - no device specified in argument", hostname)
+	}
+
+	if err := validateSSHCertificates(req.GetSshGateway(), req.GetUserDeviceCredentials()); err != nil {
+		return nil, err
+	}
+
+	timeout := config.Get().SSH.DialTimeout
+
+	if req.GetTimeoutSeconds() > 0 {
+		timeout = time.Duration(req.GetTimeoutSeconds()) * time.Second
+	}
+
+	inventories := make([]*agentpb.DeviceInventory, len(req.GetDevices()))
+
+	runThroughGateway(ctx, req.GetSshGateway(), req.GetDevices(), timeout,
+		func(i int, d *agentpb.Device, gwClient *gatewayClient) {
+			inventories[i] = discoverDevice(ctx, gwClient, d, req.GetUserDeviceCredentials(), timeout)
+		},
+		func(i int, d *agentpb.Device, errGateway error) {
+			inventories[i] = &agentpb.DeviceInventory{
+				DeviceName:     d.GetDeviceName(),
+				IpAddress:      d.GetIpAddress(),
+				DiscoveryError: errGateway.Error(),
+			}
+		})
+
+	return &agentpb.DiscoveryResponse{DeviceInventories: inventories}, nil
+}
+
+// discoverDevice connects to the device and runs the fingerprinting commands of the credentials vendor,
+// or of every supported vendor if not specified, until one matches the command output
+func discoverDevice(ctx context.Context, gwClient *gatewayClient, d *agentpb.Device,
+	creds *agentpb.UserDeviceCredentials, timeout time.Duration) (inv *agentpb.DeviceInventory) {
+
+	inv = &agentpb.DeviceInventory{
+		DeviceName: d.GetDeviceName(),
+		IpAddress:  d.GetIpAddress(),
+	}
+
+	ctx, span := tracing.StartSpan(ctx, "ssh.discoverDevice", attribute.String("device.ip", d.GetIpAddress()))
+
+	defer func() {
+		var errDiscovery error
+
+		if !inv.Discovered {
+			errDiscovery = errors.New(inv.DiscoveryError)
+		}
+
+		span.SetAttributes(attribute.String("device.vendor", inv.Vendor.String()))
+		tracing.EndSpan(span, errDiscovery)
+	}()
+
+	client, err := dialDevice(ctx, gwClient, d.GetIpAddress(), creds, timeout)
+
+	if err != nil {
+		inv.DiscoveryError = err.Error()
+		return inv
+	}
+
+	defer client.Close()
+
+	vendor := inibuilder.CredentialsVendor(creds)
+
+	// Cache command outputs as several fingerprinters share the same command
+	outputs := make(map[string]string)
+
+	var lastErr error
+
+	for _, fp := range fingerprinters {
+
+		if vendor != agentpb.DeviceVendor_VENDOR_UNSPECIFIED && fp.vendor != vendor {
+			continue
+		}
+
+		out, ok := outputs[fp.command]
+
+		if !ok {
+			out, err = runCommand(ctx, client, fp.command, timeout)
+
+			if err != nil {
+				lastErr = err
+			}
+			outputs[fp.command] = out
+		}
+
+		if !fp.match.MatchString(out) {
+			continue
+		}
+
+		inv.Discovered = true
+		inv.Vendor = fp.vendor
+		fp.parse(out, inv)
+
+		return inv
+	}
+
+	if lastErr != nil {
+		inv.DiscoveryError = fmt.Sprintf("unable to fingerprint device: %v", lastErr)
+	} else {
+		inv.DiscoveryError = "unable to fingerprint device: no supported vendor matched the device output"
+	}
+
+	return inv
+}
+
+// runCommand executes the command in a new SSH session and returns its combined output.
+// The session is closed if the command does not complete within the timeout or once ctx is done.
+func runCommand(ctx context.Context, client *ssh.Client, cmd string, timeout time.Duration) (string, error) {
+
+	session, err := client.NewSession()
+
+	if err != nil {
+		return "", fmt.Errorf("unable to open SSH session: %v", err)
+	}
+
+	defer session.Close()
+
+	var out bytes.Buffer
+	session.Stdout = &out
+	session.Stderr = &out
+
+	// Closing the session unblocks Run if the device does not return within the timeout
+	timer := time.AfterFunc(timeout, func() { session.Close() })
+	defer timer.Stop()
+
+	defer closeOnCancel(ctx, session)()
+
+	if err := session.Run(cmd); err != nil {
+		return out.String(), fmt.Errorf("command %q failed: %v", cmd, err)
+	}
+
+	return out.String(), nil
+}
+
+// submatch returns the first non empty capturing group of the regular expression in the output.
+// It returns an empty string if the regular expression does not match.
+func submatch(re *regexp.Regexp, out string) string {
+
+	matches := re.FindStringSubmatch(out)
+
+	if matches == nil {
+		return ""
+	}
+
+	for _, m := range matches[1:] {
+		if m != "" {
+			return strings.TrimSpace(m)
+		}
+	}
+
+	return ""
+}
+
+func parseCiscoVersion(out string, inv *agentpb.DeviceInventory) {
+
+	// Order matters as IOS-XE output also contains the IOS banner. IOS-XE 3.x banners start as IOS ones
+	// ("Cisco IOS Software, IOS-XE Software, ...") so IOS-XE is matched anywhere in the banner.
+	switch {
+	case ciscoIOSXERegexp.MatchString(out):
+		inv.OsFamily = "IOS-XE"
+		inv.OsVersion = submatch(ciscoIOSXERegexp, out)
+	case ciscoIOSXRRegexp.MatchString(out):
+		inv.OsFamily = "IOS-XR"
+		inv.OsVersion = submatch(ciscoIOSXRRegexp, out)
+	case ciscoNXOSRegexp.MatchString(out):
+		inv.OsFamily = "NX-OS"
+		inv.OsVersion = submatch(ciscoNXOSRegexp, out)
+	case ciscoASARegexp.MatchString(out):
+		inv.OsFamily = "ASA"
+		inv.OsVersion = submatch(ciscoASARegexp, out)
+		inv.Platform = submatch(ciscoASAPlatformRe, out)
+	case ciscoIOSRegexp.MatchString(out):
+		inv.OsFamily = "IOS"
+		inv.OsVersion = submatch(ciscoIOSRegexp, out)
+	}
+
+	if inv.Platform == "" {
+		inv.Platform = submatch(ciscoPlatformRe, out)
+	}
+
+	inv.Hostname = submatch(ciscoHostnameRe, out)
+	inv.SerialNumber = submatch(ciscoSerialRe, out)
+}
+
+func parseAristaVersion(out string, inv *agentpb.DeviceInventory) {
+
+	inv.OsFamily = "EOS"
+	inv.Platform = submatch(aristaPlatformRe, out)
+	inv.OsVersion = submatch(aristaVersionRe, out)
+	inv.SerialNumber = submatch(aristaSerialRe, out)
+}
+
+func parseJuniperVersion(out string, inv *agentpb.DeviceInventory) {
+
+	inv.OsFamily = "JUNOS"
+	inv.Hostname = submatch(juniperHostnameRe, out)
+	inv.Platform = submatch(juniperModelRe, out)
+	inv.OsVersion = submatch(juniperVersionRe, out)
+}
+
+func parsePaloAltoSystemInfo(out string, inv *agentpb.DeviceInventory) {
+
+	inv.OsFamily = "PAN-OS"
+
+	for _, m := range paloAltoFieldRe.FindAllStringSubmatch(out, -1) {
+		switch m[1] {
+		case "hostname":
+			inv.Hostname = m[2]
+		case "model":
+			inv.Platform = m[2]
+		case "sw-version":
+			inv.OsVersion = m[2]
+		case "serial":
+			inv.SerialNumber = m[2]
+		}
+	}
+}
+
+func parseLinuxRelease(out string, inv *agentpb.DeviceInventory) {
+
+	for _, m := range linuxFieldRe.FindAllStringSubmatch(out, -1) {
+		switch m[1] {
+		case "NAME":
+			inv.OsFamily = m[2]
+		case "VERSION_ID":
+			inv.OsVersion = m[2]
+		case "UNAME":
+			inv.Platform = m[2]
+		case "HOSTNAME":
+			inv.Hostname = m[2]
+		}
+	}
+}
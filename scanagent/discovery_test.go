@@ -0,0 +1,59 @@
+package scanagent
+
+import (
+	"testing"
+
+	agentpb "github.com/lucabrasi83/vscan-agent/proto"
+)
+
+func TestParseCiscoVersion(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		out         string
+		wantFamily  string
+		wantVersion string
+	}{
+		{
+			name: "IOS",
+			out: "Cisco IOS Software, C2900 Software (C2900-UNIVERSALK9-M), Version 15.4(3)M3, " +
+				"RELEASE SOFTWARE (fc2)\n",
+			wantFamily:  "IOS",
+			wantVersion: "15.4(3)M3",
+		},
+		{
+			name: "IOS-XE 3.x",
+			out: "Cisco IOS Software, IOS-XE Software, Catalyst 4500 L3 Switch Software (cat4500e-UNIVERSALK9-M), " +
+				"Version 03.06.06E RELEASE SOFTWARE (fc1)\n",
+			wantFamily:  "IOS-XE",
+			wantVersion: "03.06.06E",
+		},
+		{
+			name: "IOS-XE 16.x",
+			out: "Cisco IOS XE Software, Version 16.09.04\n" +
+				"Cisco IOS Software [Fuji], ISR Software (X86_64_LINUX_IOSD-UNIVERSALK9-M), Version 16.9.4, " +
+				"RELEASE SOFTWARE (fc2)\n",
+			wantFamily:  "IOS-XE",
+			wantVersion: "16.09.04",
+		},
+		{
+			name:        "ASA",
+			out:         "Cisco Adaptive Security Appliance Software Version 9.8(4)\nHardware:   ASA5516, 8192 MB RAM\n",
+			wantFamily:  "ASA",
+			wantVersion: "9.8(4)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			inv := &agentpb.DeviceInventory{}
+
+			parseCiscoVersion(tt.out, inv)
+
+			if inv.OsFamily != tt.wantFamily || inv.OsVersion != tt.wantVersion {
+				t.Errorf("parsed %v %v, want %v %v", inv.OsFamily, inv.OsVersion, tt.wantFamily, tt.wantVersion)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package scanagent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/lucabrasi83/vscan-agent/config"
+	"github.com/lucabrasi83/vscan-agent/logging"
+	agentpb "github.com/lucabrasi83/vscan-agent/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// cancelledJobsTimeout is the time given to the cancelled scan jobs to report their failure to the controller
+const cancelledJobsTimeout = 10 * time.Second
+
+// interruptedJob represents a scan job cancelled at the end of the drain grace period.
+// It holds what the controller needs to resubmit the job, credentials excepted.
+type interruptedJob struct {
+	JobID         string    `json:"job_id"`
+	Devices       []string  `json:"devices"`
+	OvalSourceURL string    `json:"oval_source_url"`
+	Agent         string    `json:"agent"`
+	StartedAt     time.Time `json:"started_at"`
+	InterruptedAt time.Time `json:"interrupted_at"`
+}
+
+// drainer stops the agent from accepting new scan jobs and lets the running ones finish for a grace period
+// before cancelling them
+type drainer struct {
+	jobs   *jobRegistry
+	health *healthChecker
+
+	once     sync.Once
+	deadline time.Time
+	idle     <-chan struct{}
+	done     chan struct{}
+
+	interruptOnce sync.Once
+}
+
+func newDrainer(jobs *jobRegistry, health *healthChecker) *drainer {
+	return &drainer{jobs: jobs, health: health, done: make(chan struct{})}
+}
+
+// start puts the agent in drain mode. Draining cannot be undone and further calls keep the first grace period.
+// It returns the time at which the remaining scan jobs are cancelled.
+func (d *drainer) start(gracePeriod time.Duration) time.Time {
+
+	d.once.Do(func() {
+
+		d.deadline = time.Now().Add(gracePeriod)
+
+		d.idle = d.jobs.drain()
+
+		// Report NOT_SERVING to health checks so the controller stops sending requests
+		if d.health != nil {
+			d.health.shutdown()
+		}
+
+		logging.VSCANLog("warning", "VSCAN Agent is draining. %v running scan job(s) have %v to finish",
+			len(d.jobs.runningJobs()), gracePeriod)
+
+		go d.run(gracePeriod)
+	})
+
+	return d.deadline
+}
+
+// run waits for the running scan jobs to finish or cancels them once the grace period elapsed
+func (d *drainer) run(gracePeriod time.Duration) {
+
+	defer close(d.done)
+
+	timer := time.NewTimer(gracePeriod)
+	defer timer.Stop()
+
+	select {
+	case <-d.idle:
+		logging.VSCANLog("info", "VSCAN Agent drained: no scan job running")
+		return
+	case <-timer.C:
+	}
+
+	logging.VSCANLog("warning", "drain grace period elapsed")
+
+	d.interrupt()
+}
+
+// interrupt cancels the running scan jobs, records them in the interrupted scan jobs file and waits for them to
+// stop. It is called at the end of the grace period or when the shutdown is forced before.
+func (d *drainer) interrupt() {
+
+	if d.idle == nil {
+		return
+	}
+
+	d.interruptOnce.Do(func() {
+
+		cancelled := d.jobs.cancelAll()
+
+		logging.VSCANLog("warning", "cancelling %v running scan job(s)", len(cancelled))
+
+		if err := saveInterruptedJobs(config.Get().Server.InterruptedJobsFile, cancelled); err != nil {
+			logging.VSCANLog("error", "unable to persist interrupted scan jobs: %v", err)
+		}
+	})
+
+	select {
+	case <-d.idle:
+	case <-time.After(cancelledJobsTimeout):
+		logging.VSCANLog("error", "cancelled scan jobs did not stop within %v", cancelledJobsTimeout)
+	}
+}
+
+// draining returns whether the agent is draining
+func (d *drainer) draining() bool {
+	return d.jobs.isDraining()
+}
+
+// wait blocks until the agent is drained or ctx is done
+func (d *drainer) wait(ctx context.Context) error {
+	select {
+	case <-d.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// saveInterruptedJobs appends the interrupted scan jobs to the JSON file
+func saveInterruptedJobs(file string, jobs []runningJob) error {
+
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	var records []interruptedJob
+
+	content, err := ioutil.ReadFile(file)
+
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(content, &records); err != nil {
+			logging.VSCANLog("warning", "overwriting invalid interrupted scan jobs file %v: %v", file, err)
+			records = nil
+		}
+	case !os.IsNotExist(err):
+		return fmt.Errorf("error while reading interrupted scan jobs file %v: %v", file, err)
+	}
+
+	now := time.Now()
+
+	for _, job := range jobs {
+		records = append(records, interruptedJob{
+			JobID:         job.jobID,
+			Devices:       job.devices,
+			OvalSourceURL: job.ovalSourceURL,
+			Agent:         hostname,
+			StartedAt:     job.startedAt,
+			InterruptedAt: now,
+		})
+	}
+
+	content, err = json.MarshalIndent(records, "", "  ")
+
+	if err != nil {
+		return fmt.Errorf("error while encoding interrupted scan jobs: %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(file), 0750); err != nil {
+		return fmt.Errorf("error while creating interrupted scan jobs directory: %v", err)
+	}
+
+	// Write to a temporary file first so a crash never leaves a truncated file behind
+	tmp := file + ".tmp"
+
+	if err := ioutil.WriteFile(tmp, content, 0640); err != nil {
+		return fmt.Errorf("error while writing interrupted scan jobs file %v: %v", tmp, err)
+	}
+
+	if err := os.Rename(tmp, file); err != nil {
+		return fmt.Errorf("error while writing interrupted scan jobs file %v: %v", file, err)
+	}
+
+	logging.VSCANLog("info", "%v interrupted scan job(s) recorded in %v", len(jobs), file)
+
+	return nil
+}
+
+// Drain puts the agent in drain mode: new scan jobs are rejected with Unavailable while the running ones are
+// allowed to finish for the grace period before being cancelled.
+// Drain is refused while authz is disabled as any client could then take the agent out of rotation.
+func (s *AgentServer) Drain(ctx context.Context, req *agentpb.DrainRequest) (*agentpb.DrainResponse, error) {
+
+	if !config.Get().Authz.Enabled {
+		return nil, status.Errorf(codes.PermissionDenied,
+			"Agent %v - Drain requires authz to be enabled with a Drain policy", hostname)
+	}
+
+	gracePeriod := config.Get().Server.DrainGracePeriod
+
+	if req.GetGracePeriodSeconds() > 0 {
+		gracePeriod = time.Duration(req.GetGracePeriodSeconds()) * time.Second
+	}
+
+	deadline := s.drainer.start(gracePeriod)
+
+	return &agentpb.DrainResponse{
+		Draining:     true,
+		DeadlineUnix: deadline.Unix(),
+		RunningJobs:  scanJobs.runningJobs(),
+	}, nil
+}
@@ -0,0 +1,242 @@
+package scanagent
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lucabrasi83/vscan-agent/config"
+	agentpb "github.com/lucabrasi83/vscan-agent/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// useInterruptedJobsFile sets a temporary interrupted scan jobs file for the test and returns its name
+func useInterruptedJobsFile(t *testing.T) string {
+
+	t.Helper()
+
+	serverConfig := &config.Get().Server
+	previous := *serverConfig
+
+	serverConfig.InterruptedJobsFile = filepath.Join(t.TempDir(), "state", "interrupted-jobs.json")
+
+	t.Cleanup(func() { *serverConfig = previous })
+
+	return serverConfig.InterruptedJobsFile
+}
+
+// loadInterruptedJobs reads back the interrupted scan jobs file
+func loadInterruptedJobs(t *testing.T, file string) []interruptedJob {
+
+	t.Helper()
+
+	content, err := ioutil.ReadFile(file)
+
+	if err != nil {
+		t.Fatalf("unable to read interrupted scan jobs file: %v", err)
+	}
+
+	var records []interruptedJob
+
+	if err := json.Unmarshal(content, &records); err != nil {
+		t.Fatalf("invalid interrupted scan jobs file: %v", err)
+	}
+
+	return records
+}
+
+func startJob(t *testing.T, jobs *jobRegistry, jobID string) context.Context {
+
+	t.Helper()
+
+	ctx, err := jobs.start(&agentpb.ScanRequest{JobId: jobID, OvalSourceUrl: "https://oval.vscan.internal/ios.xml",
+		Devices: []*agentpb.Device{{DeviceName: "dev-1"}, {DeviceName: "dev-2"}}})
+
+	if err != nil {
+		t.Fatalf("unable to start scan job %v: %v", jobID, err)
+	}
+
+	return ctx
+}
+
+func TestDrainerJobsFinishWithinGracePeriod(t *testing.T) {
+
+	file := useInterruptedJobsFile(t)
+
+	jobs := newJobRegistry()
+	startJob(t, jobs, "job-1")
+
+	d := newDrainer(jobs, nil)
+
+	if d.draining() {
+		t.Fatalf("agent draining before Drain")
+	}
+
+	deadline := d.start(time.Hour)
+
+	if !d.draining() {
+		t.Fatalf("agent not draining after Drain")
+	}
+
+	if _, err := jobs.start(&agentpb.ScanRequest{JobId: "job-2"}); err != errDraining {
+		t.Errorf("scan job submitted while draining error = %v, want %v", err, errDraining)
+	}
+
+	// Draining cannot be restarted with another grace period
+	if again := d.start(time.Second); !again.Equal(deadline) {
+		t.Errorf("second drain deadline = %v, want the first one %v", again, deadline)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if err := d.wait(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("drain completed with a scan job running: %v", err)
+	}
+
+	jobs.finish("job-1", true)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := d.wait(ctx); err != nil {
+		t.Fatalf("drain not completed once the last scan job finished: %v", err)
+	}
+
+	if _, err := os.Stat(file); !os.IsNotExist(err) {
+		t.Errorf("interrupted scan jobs file written although no scan job was interrupted: %v", err)
+	}
+}
+
+func TestDrainerGracePeriodElapsed(t *testing.T) {
+
+	file := useInterruptedJobsFile(t)
+
+	jobs := newJobRegistry()
+
+	// The scan job reports its failure once interrupted, as the scanner does when its context is cancelled
+	jobCtx := startJob(t, jobs, "job-1")
+
+	go func() {
+		<-jobCtx.Done()
+		jobs.finish("job-1", false)
+	}()
+
+	d := newDrainer(jobs, nil)
+	d.start(10 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := d.wait(ctx); err != nil {
+		t.Fatalf("drain not completed once the grace period elapsed: %v", err)
+	}
+
+	records := loadInterruptedJobs(t, file)
+
+	if len(records) != 1 || records[0].JobID != "job-1" || len(records[0].Devices) != 2 ||
+		records[0].OvalSourceURL != "https://oval.vscan.internal/ios.xml" {
+		t.Errorf("interrupted scan jobs = %+v, want job-1 with its devices and OVAL source", records)
+	}
+
+	if counts := jobs.counts(); counts.GetRunning() != 0 || counts.GetFailed() != 1 {
+		t.Errorf("scan jobs counts = %v, want no running and 1 failed", counts)
+	}
+}
+
+func TestSaveInterruptedJobs(t *testing.T) {
+
+	file := filepath.Join(t.TempDir(), "state", "interrupted-jobs.json")
+
+	job := func(jobID string) runningJob {
+		return runningJob{jobID: jobID, devices: []string{"dev-1"}, startedAt: time.Now().Add(-time.Minute)}
+	}
+
+	if err := saveInterruptedJobs(file, nil); err != nil {
+		t.Fatalf("unable to save no interrupted scan job: %v", err)
+	}
+
+	if _, err := os.Stat(file); !os.IsNotExist(err) {
+		t.Fatalf("interrupted scan jobs file written without interrupted scan job: %v", err)
+	}
+
+	if err := saveInterruptedJobs(file, []runningJob{job("job-1")}); err != nil {
+		t.Fatalf("unable to save interrupted scan jobs: %v", err)
+	}
+
+	// Jobs interrupted by a later drain are appended to the ones recorded before
+	if err := saveInterruptedJobs(file, []runningJob{job("job-2"), job("job-3")}); err != nil {
+		t.Fatalf("unable to save interrupted scan jobs: %v", err)
+	}
+
+	records := loadInterruptedJobs(t, file)
+
+	if len(records) != 3 || records[0].JobID != "job-1" || records[2].JobID != "job-3" {
+		t.Fatalf("interrupted scan jobs = %+v, want job-1, job-2 and job-3", records)
+	}
+
+	if records[0].Agent != hostname || records[0].InterruptedAt.Before(records[0].StartedAt) {
+		t.Errorf("interrupted scan job = %+v, want agent %v and interruption after start", records[0], hostname)
+	}
+
+	if _, err := os.Stat(file + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("temporary interrupted scan jobs file left behind: %v", err)
+	}
+
+	// An unreadable file is replaced rather than blocking the jobs interrupted now from being recorded
+	if err := ioutil.WriteFile(file, []byte("{truncated"), 0640); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := saveInterruptedJobs(file, []runningJob{job("job-4")}); err != nil {
+		t.Fatalf("unable to save interrupted scan jobs over an invalid file: %v", err)
+	}
+
+	if records := loadInterruptedJobs(t, file); len(records) != 1 || records[0].JobID != "job-4" {
+		t.Errorf("interrupted scan jobs = %+v, want job-4", records)
+	}
+}
+
+func TestDrainRequiresAuthz(t *testing.T) {
+
+	useInterruptedJobsFile(t)
+
+	authzConfig := &config.Get().Authz
+	previous := *authzConfig
+
+	t.Cleanup(func() { *authzConfig = previous })
+
+	s := &AgentServer{drainer: newDrainer(newJobRegistry(), nil)}
+
+	authzConfig.Enabled = false
+
+	if _, err := s.Drain(context.Background(), &agentpb.DrainRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("Drain without authz error = %v, want %v", err, codes.PermissionDenied)
+	}
+
+	if s.drainer.draining() {
+		t.Fatalf("agent draining after a refused Drain")
+	}
+
+	authzConfig.Enabled = true
+
+	resp, err := s.Drain(context.Background(), &agentpb.DrainRequest{GracePeriodSeconds: 60})
+
+	if err != nil {
+		t.Fatalf("Drain with authz refused: %v", err)
+	}
+
+	if !resp.GetDraining() || !s.drainer.draining() {
+		t.Errorf("agent not draining after Drain")
+	}
+
+	if remaining := time.Until(time.Unix(resp.GetDeadlineUnix(), 0)); remaining > time.Minute ||
+		remaining < 58*time.Second {
+		t.Errorf("drain deadline in %v, want the requested 60s grace period", remaining)
+	}
+}
@@ -0,0 +1,229 @@
+package scanagent
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/lucabrasi83/vscan-agent/certmanager"
+	"github.com/lucabrasi83/vscan-agent/config"
+	"github.com/lucabrasi83/vscan-agent/logging"
+	"github.com/lucabrasi83/vscan-agent/middleware"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// agentServiceName is the gRPC service name reported by the health checking service
+const agentServiceName = "agentpb.VscanAgentService"
+
+// scanMethod is the full method name of the scan RPC whose admission policy drives the readiness
+const scanMethod = "/" + agentServiceName + "/BuildScanConfig"
+
+// readinessCheck is a named check the agent must pass to serve scan requests
+type readinessCheck struct {
+	name  string
+	check func() error
+}
+
+// healthChecker drives the gRPC health checking service status from the agent readiness checks
+type healthChecker struct {
+	server *health.Server
+	checks []readinessCheck
+
+	// lastFailures holds the failed checks reported by the previous evaluation to log status changes only
+	lastFailures string
+}
+
+func newHealthChecker(certManager *certmanager.Manager, admission *middleware.AdmissionController) *healthChecker {
+
+	jovalConfig := config.Get().Joval
+
+	h := &healthChecker{server: health.NewServer()}
+
+	h.checks = []readinessCheck{
+		{name: "certificates", check: func() error {
+			if certManager.ServerExpired() {
+				return fmt.Errorf("server certificate is expired")
+			}
+			return nil
+		}},
+		{name: "java", check: func() error {
+			_, err := exec.LookPath(jovalConfig.JavaBin)
+			return err
+		}},
+		{name: "joval", check: func() error {
+			return checkRegularFile(jovalConfig.JarFile)
+		}},
+		{name: "license", check: func() error {
+			return checkLicenseFile(jovalConfig.LicenseFile)
+		}},
+		{name: "scanjobs", check: func() error {
+			return checkWritableDir(jovalConfig.ScanJobsDir)
+		}},
+		{name: "resources", check: func() error {
+			return admission.Check(scanMethod)
+		}},
+	}
+
+	return h
+}
+
+// run evaluates the readiness checks now and then every interval until stop is closed
+func (h *healthChecker) run(interval time.Duration, stop <-chan struct{}) {
+
+	h.evaluate()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				h.evaluate()
+			}
+		}
+	}()
+}
+
+// evaluate runs the readiness checks and updates the serving status of the agent service.
+// The server-wide "" service is the liveness status: it stays SERVING until shutdown as failed readiness checks,
+// such as all scan slots being in use, do not call for a restart.
+func (h *healthChecker) evaluate() {
+
+	var failures []string
+
+	for _, c := range h.checks {
+		if err := c.check(); err != nil {
+			failures = append(failures, c.name+": "+err.Error())
+		}
+	}
+
+	servingStatus := healthpb.HealthCheckResponse_SERVING
+
+	if len(failures) > 0 {
+		servingStatus = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+
+	h.server.SetServingStatus(agentServiceName, servingStatus)
+
+	report := strings.Join(failures, "; ")
+
+	if report == h.lastFailures {
+		return
+	}
+
+	h.lastFailures = report
+
+	if len(failures) > 0 {
+		logging.VSCANLog("error", "VSCAN Agent is not ready: %v", report)
+	} else {
+		logging.VSCANLog("info", "VSCAN Agent is ready to serve requests")
+	}
+}
+
+// serving returns whether the agent service currently reports SERVING
+func (h *healthChecker) serving() bool {
+
+	resp, err := h.server.Check(context.Background(), &healthpb.HealthCheckRequest{Service: agentServiceName})
+
+	return err == nil && resp.GetStatus() == healthpb.HealthCheckResponse_SERVING
+}
+
+// shutdown sets the serving status to NOT_SERVING and ignores any further status update
+func (h *healthChecker) shutdown() {
+	h.server.Shutdown()
+}
+
+// checkRegularFile verifies the file exists and is a regular file
+func checkRegularFile(file string) error {
+
+	info, err := os.Stat(file)
+
+	if err != nil {
+		return err
+	}
+
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("%v is not a regular file", file)
+	}
+
+	return nil
+}
+
+// checkLicenseFile verifies the Joval license file is a well-formed XML document and is not expired.
+// A license without a recognizable expiry date is assumed valid.
+func checkLicenseFile(file string) error {
+
+	f, err := os.Open(file)
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	decoder := xml.NewDecoder(f)
+
+	for elements := 0; ; {
+
+		token, err := decoder.Token()
+
+		if err == io.EOF {
+			if elements == 0 {
+				return fmt.Errorf("license file %v is empty", file)
+			}
+			return checkLicenseExpiry(file)
+		}
+
+		if err != nil {
+			return fmt.Errorf("license file %v is invalid: %v", file, err)
+		}
+
+		if _, ok := token.(xml.StartElement); ok {
+			elements++
+		}
+	}
+}
+
+// checkLicenseExpiry verifies the expiry date of the Joval license file, if any, is not reached
+func checkLicenseExpiry(file string) error {
+
+	expiry, err := licenseExpiry(file)
+
+	if err != nil {
+		return nil
+	}
+
+	if !time.Now().Before(expiry) {
+		return fmt.Errorf("license file %v expired on %v", file, expiry.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// checkWritableDir verifies a file can be created in the directory
+func checkWritableDir(dir string) error {
+
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(dir, ".healthcheck")
+
+	if err != nil {
+		return fmt.Errorf("directory %v is not writable: %v", dir, err)
+	}
+
+	f.Close()
+
+	return os.Remove(f.Name())
+}
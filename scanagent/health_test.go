@@ -0,0 +1,197 @@
+package scanagent
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lucabrasi83/vscan-agent/certmanager"
+	"github.com/lucabrasi83/vscan-agent/config"
+	"github.com/lucabrasi83/vscan-agent/middleware"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// writeSelfSignedCertificate writes a self-signed agent certificate expiring at notAfter, its private key and
+// the certificate as CA bundle into dir
+func writeSelfSignedCertificate(t *testing.T, dir string, notAfter time.Time) {
+
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: "agent.vscan.internal"},
+		NotBefore:             notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+
+	files := map[string][]byte{
+		"agent.crt": certPEM,
+		"agent.key": pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		"ca.crt":    certPEM,
+	}
+
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), content, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// useJovalInstallation sets up the Java binary, Joval jar, license and scan jobs directory checked by the readiness
+// checks
+func useJovalInstallation(t *testing.T) {
+
+	t.Helper()
+
+	dir := t.TempDir()
+
+	jovalConfig := &config.Get().Joval
+	previous := *jovalConfig
+
+	t.Cleanup(func() { *jovalConfig = previous })
+
+	// The test binary stands in for the Java binary
+	javaBin, err := os.Executable()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jovalConfig.JavaBin = javaBin
+	jovalConfig.JarFile = filepath.Join(dir, "joval.jar")
+	jovalConfig.LicenseFile = filepath.Join(dir, "license.xml")
+	jovalConfig.ScanJobsDir = filepath.Join(dir, "scanjobs")
+
+	if err := ioutil.WriteFile(jovalConfig.JarFile, []byte("PK"), 0640); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(jovalConfig.LicenseFile, []byte("<license><owner>vscan</owner></license>"),
+		0640); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHealthCheckerReadiness(t *testing.T) {
+
+	useJovalInstallation(t)
+
+	certDir := t.TempDir()
+	writeSelfSignedCertificate(t, certDir, time.Now().Add(365*24*time.Hour))
+
+	certManager, err := certmanager.New(filepath.Join(certDir, "agent.crt"), filepath.Join(certDir, "agent.key"),
+		filepath.Join(certDir, "ca.crt"))
+
+	if err != nil {
+		t.Fatalf("unable to load certificates: %v", err)
+	}
+
+	jovalProcesses := 0
+
+	admission := middleware.NewAdmissionController(middleware.AdmissionOptions{
+		Default:        middleware.AdmissionPolicy{MaxJovalProcesses: 2},
+		JovalProcesses: func() int { return jovalProcesses },
+	})
+
+	h := newHealthChecker(certManager, admission)
+
+	// expect evaluates the readiness checks and verifies the agent service and server-wide statuses
+	expect := func(agentStatus, serverStatus healthpb.HealthCheckResponse_ServingStatus) {
+
+		t.Helper()
+
+		h.evaluate()
+
+		for service, want := range map[string]healthpb.HealthCheckResponse_ServingStatus{
+			agentServiceName: agentStatus,
+			"":               serverStatus,
+		} {
+
+			resp, err := h.server.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+
+			if err != nil {
+				t.Fatalf("health check of service %q failed: %v", service, err)
+			}
+
+			if resp.GetStatus() != want {
+				t.Errorf("service %q status = %v, want %v (failed checks: %v)", service, resp.GetStatus(), want,
+					h.lastFailures)
+			}
+		}
+	}
+
+	serving, notServing := healthpb.HealthCheckResponse_SERVING, healthpb.HealthCheckResponse_NOT_SERVING
+
+	expect(serving, serving)
+
+	if !h.serving() {
+		t.Errorf("serving() = false for a ready agent")
+	}
+
+	// An expired server certificate fails readiness only: a restart would not renew it
+	writeSelfSignedCertificate(t, certDir, time.Now().Add(-time.Hour))
+
+	if err := certManager.Reload(); err != nil {
+		t.Fatalf("unable to reload certificates: %v", err)
+	}
+
+	expect(notServing, serving)
+
+	writeSelfSignedCertificate(t, certDir, time.Now().Add(365*24*time.Hour))
+
+	if err := certManager.Reload(); err != nil {
+		t.Fatalf("unable to reload certificates: %v", err)
+	}
+
+	expect(serving, serving)
+
+	// Saturated admission control fails readiness until scan slots free up
+	jovalProcesses = 2
+
+	expect(notServing, serving)
+
+	jovalProcesses = 0
+
+	expect(serving, serving)
+
+	// Draining reports NOT_SERVING for both services and ignores later evaluations
+	newDrainer(newJobRegistry(), h).start(time.Hour)
+
+	expect(notServing, notServing)
+
+	if h.serving() {
+		t.Errorf("serving() = true for a draining agent")
+	}
+}
@@ -0,0 +1,226 @@
+package scanagent
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/lucabrasi83/vscan-agent/config"
+	"github.com/lucabrasi83/vscan-agent/logging"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// knownHostsMu serializes access to the known_hosts file when recording new host keys
+var knownHostsMu sync.Mutex
+
+// errHostKeyMismatch is returned when the host key presented by the SSH server does not match the expected one
+var errHostKeyMismatch = errors.New("ssh host key mismatch")
+
+// hostKeyVerifier verifies the host key presented by an SSH server against the agent host key policy
+// and the fingerprints pinned in the request. It keeps track of the observed key fingerprint.
+// Unknown host keys accepted by the TOFU policy are only recorded by recordTrusted, once authentication succeeded.
+type hostKeyVerifier struct {
+	pinnedFingerprints []string
+	fingerprint        string
+	matched            bool
+
+	// unknown holds the unknown host key accepted by the TOFU policy, pending its recording
+	unknown *unknownHostKey
+
+	// gateway is the IP address of the SSH gateway the host is reached through, if any
+	gateway string
+}
+
+// unknownHostKey represents a host key not present in the known_hosts file
+type unknownHostKey struct {
+	hostname string
+	remote   net.Addr
+	key      ssh.PublicKey
+
+	// recordRemote records the remote address along with the hostname
+	recordRemote bool
+}
+
+func newHostKeyVerifier(pinnedFingerprints []string) *hostKeyVerifier {
+	return &hostKeyVerifier{pinnedFingerprints: pinnedFingerprints}
+}
+
+// scopeToGateway verifies the host key against the known_hosts entries of the host reached through the gateway,
+// recorded as "<gateway IP>/<host>", rather than the entries of the host address itself
+func (v *hostKeyVerifier) scopeToGateway(gatewayIP string) {
+	v.gateway = gatewayIP
+}
+
+// knownHostsAddr returns the known_hosts address of the host, scoped to the gateway if any
+func (v *hostKeyVerifier) knownHostsAddr(hostname string) string {
+
+	if v.gateway == "" {
+		return hostname
+	}
+
+	host, port, err := net.SplitHostPort(hostname)
+
+	if err != nil {
+		host, port = hostname, "22"
+	}
+
+	return net.JoinHostPort(v.gateway+"/"+host, port)
+}
+
+// Fingerprint returns the SHA256 fingerprint of the host key presented by the SSH server
+func (v *hostKeyVerifier) Fingerprint() string {
+	return v.fingerprint
+}
+
+// Matched returns whether the host key presented by the SSH server matched a pinned fingerprint or
+// a known_hosts entry. Host keys accepted by the insecure policy or recorded by the TOFU policy did not match.
+func (v *hostKeyVerifier) Matched() bool {
+	return v.matched
+}
+
+// Callback implements ssh.HostKeyCallback
+func (v *hostKeyVerifier) Callback(hostname string, remote net.Addr, key ssh.PublicKey) error {
+
+	v.fingerprint = ssh.FingerprintSHA256(key)
+
+	if len(v.pinnedFingerprints) > 0 {
+
+		for _, pin := range v.pinnedFingerprints {
+			if fingerprintMatch(pin, key) {
+				v.matched = true
+				return nil
+			}
+		}
+
+		return fmt.Errorf("%w: host %v presented key %v which is not pinned", errHostKeyMismatch, hostname,
+			v.fingerprint)
+	}
+
+	if config.Get().SSH.HostKeyPolicy == config.HostKeyPolicyInsecure {
+		return nil
+	}
+
+	hostname = v.knownHostsAddr(hostname)
+
+	knownHostsMu.Lock()
+	defer knownHostsMu.Unlock()
+
+	err := checkKnownHosts(hostname, remote, key)
+
+	var keyErr *knownhosts.KeyError
+
+	switch {
+	case err == nil:
+		v.matched = true
+		return nil
+
+	case errors.As(err, &keyErr) && len(keyErr.Want) > 0:
+		return fmt.Errorf("%w: host %v presented key %v which differs from the known_hosts entry", errHostKeyMismatch,
+			hostname, v.fingerprint)
+
+	case errors.As(err, &keyErr) && config.Get().SSH.HostKeyPolicy == config.HostKeyPolicyTOFU:
+		// The remote address of a host reached through a gateway is not recorded as it is only meaningful
+		// behind that gateway
+		v.unknown = &unknownHostKey{hostname: hostname, remote: remote, key: key, recordRemote: v.gateway == ""}
+		return nil
+
+	case errors.As(err, &keyErr):
+		return fmt.Errorf("%w: host %v is not present in known_hosts file %v", errHostKeyMismatch, hostname,
+			config.Get().SSH.KnownHostsFile)
+
+	default:
+		return err
+	}
+}
+
+// recordTrusted records the unknown host key accepted by the TOFU policy, if any, in the known_hosts file.
+// It must be called once the SSH server authenticated the client so failed or unauthenticated connections do not
+// write to the known_hosts file.
+func (v *hostKeyVerifier) recordTrusted() {
+
+	if v.unknown == nil {
+		return
+	}
+
+	knownHostsMu.Lock()
+	defer knownHostsMu.Unlock()
+
+	// The host key may have been recorded by a concurrent connection to the same host
+	if checkKnownHosts(v.unknown.hostname, v.unknown.remote, v.unknown.key) == nil {
+		return
+	}
+
+	logging.VSCANLog("warning", "recording unknown SSH host key %v for host %v in %v", v.fingerprint,
+		v.unknown.hostname, config.Get().SSH.KnownHostsFile)
+
+	var remote net.Addr
+
+	if v.unknown.recordRemote {
+		remote = v.unknown.remote
+	}
+
+	if err := recordKnownHost(v.unknown.hostname, remote, v.unknown.key); err != nil {
+		logging.VSCANLog("error", "unable to record SSH host key of host %v: %v", v.unknown.hostname, err)
+	}
+}
+
+// fingerprintMatch compares a pinned fingerprint in either SHA256 or legacy MD5 format against a public key
+func fingerprintMatch(pin string, key ssh.PublicKey) bool {
+
+	pin = strings.TrimSpace(pin)
+
+	if strings.HasPrefix(pin, "SHA256:") {
+		return pin == ssh.FingerprintSHA256(key)
+	}
+
+	return strings.EqualFold(strings.TrimPrefix(pin, "MD5:"), ssh.FingerprintLegacyMD5(key))
+}
+
+// checkKnownHosts verifies the host key against the known_hosts file. A missing file is treated as empty.
+func checkKnownHosts(hostname string, remote net.Addr, key ssh.PublicKey) error {
+
+	if _, err := os.Stat(config.Get().SSH.KnownHostsFile); os.IsNotExist(err) {
+		return &knownhosts.KeyError{}
+	}
+
+	callback, err := knownhosts.New(config.Get().SSH.KnownHostsFile)
+
+	if err != nil {
+		return fmt.Errorf("unable to load known_hosts file %v: %v", config.Get().SSH.KnownHostsFile, err)
+	}
+
+	return callback(hostname, remote, key)
+}
+
+// recordKnownHost appends the host key to the known_hosts file, creating the file if it does not exist
+func recordKnownHost(hostname string, remote net.Addr, key ssh.PublicKey) error {
+
+	if err := os.MkdirAll(filepath.Dir(config.Get().SSH.KnownHostsFile), 0700); err != nil {
+		return fmt.Errorf("unable to create known_hosts directory: %v", err)
+	}
+
+	f, err := os.OpenFile(config.Get().SSH.KnownHostsFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+
+	if err != nil {
+		return fmt.Errorf("unable to open known_hosts file %v: %v", config.Get().SSH.KnownHostsFile, err)
+	}
+
+	defer f.Close()
+
+	addresses := []string{knownhosts.Normalize(hostname)}
+
+	if remote != nil && knownhosts.Normalize(remote.String()) != addresses[0] {
+		addresses = append(addresses, knownhosts.Normalize(remote.String()))
+	}
+
+	if _, err := fmt.Fprintln(f, knownhosts.Line(addresses, key)); err != nil {
+		return fmt.Errorf("unable to write known_hosts file %v: %v", config.Get().SSH.KnownHostsFile, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package scanagent
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/lucabrasi83/vscan-agent/config"
+	"golang.org/x/crypto/ssh"
+)
+
+func newHostKey(t *testing.T) ssh.PublicKey {
+
+	t.Helper()
+
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := ssh.NewPublicKey(pub)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return key
+}
+
+// useHostKeyPolicy sets the SSH host key policy with an empty known_hosts file for the test
+func useHostKeyPolicy(t *testing.T, policy string) {
+
+	t.Helper()
+
+	sshConfig := &config.Get().SSH
+	previous := *sshConfig
+
+	sshConfig.HostKeyPolicy = policy
+	sshConfig.KnownHostsFile = filepath.Join(t.TempDir(), "known_hosts")
+
+	t.Cleanup(func() { *sshConfig = previous })
+}
+
+func TestHostKeyVerifierGatewayScope(t *testing.T) {
+
+	useHostKeyPolicy(t, config.HostKeyPolicyTOFU)
+
+	const device = "10.0.0.5:22"
+
+	remote := &net.TCPAddr{IP: net.ParseIP("10.0.0.5"), Port: 22}
+
+	siteA, siteB := newHostKey(t), newHostKey(t)
+
+	// verify runs the host key callback of a connection to the device through the gateway and records the key
+	verify := func(gateway string, key ssh.PublicKey) error {
+
+		v := newHostKeyVerifier(nil)
+
+		if gateway != "" {
+			v.scopeToGateway(gateway)
+		}
+
+		err := v.Callback(device, remote, key)
+
+		if err == nil {
+			v.recordTrusted()
+		}
+
+		return err
+	}
+
+	if err := verify("192.0.2.1", siteA); err != nil {
+		t.Fatalf("unknown device key behind gateway A rejected: %v", err)
+	}
+
+	// The same private address behind another gateway is another device
+	if err := verify("198.51.100.1", siteB); err != nil {
+		t.Fatalf("device key behind gateway B rejected as %v", err)
+	}
+
+	if err := verify("192.0.2.1", siteA); err != nil {
+		t.Errorf("recorded device key behind gateway A rejected: %v", err)
+	}
+
+	if err := verify("192.0.2.1", siteB); !errors.Is(err, errHostKeyMismatch) {
+		t.Errorf("changed device key behind gateway A error = %v, want %v", err, errHostKeyMismatch)
+	}
+
+	// Keys recorded behind a gateway do not apply to the address reached directly
+	if err := verify("", siteB); err != nil {
+		t.Errorf("device key reached without gateway rejected: %v", err)
+	}
+
+	if err := verify("", siteA); !errors.Is(err, errHostKeyMismatch) {
+		t.Errorf("changed device key reached without gateway error = %v, want %v", err, errHostKeyMismatch)
+	}
+}
@@ -0,0 +1,188 @@
+package scanagent
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	agentpb "github.com/lucabrasi83/vscan-agent/proto"
+)
+
+// scanJobs tracks the scan jobs handled by the VSCAN Agent
+var scanJobs = newJobRegistry()
+
+// errDraining is returned when a scan job is submitted while the agent is draining
+var errDraining = errors.New("agent is draining and does not accept new scan jobs")
+
+// errJobRunning is returned when a scan job is submitted with the job ID of a running scan job
+var errJobRunning = errors.New("a scan job with the same job ID is already running")
+
+// runningJob represents a scan job currently running
+type runningJob struct {
+	jobID         string
+	devices       []string
+	ovalSourceURL string
+	startedAt     time.Time
+	cancel        context.CancelFunc
+}
+
+// jobRegistry keeps track of the running scan jobs and of the number of jobs completed since the agent started.
+// Once draining, it rejects new scan jobs and signals when the last running job finished.
+type jobRegistry struct {
+	mu        sync.Mutex
+	running   map[string]*runningJob
+	completed int64
+	failed    int64
+
+	draining bool
+	idle     chan struct{}
+}
+
+func newJobRegistry() *jobRegistry {
+	return &jobRegistry{running: make(map[string]*runningJob)}
+}
+
+// start records a scan job as running. It returns the context of the job, cancelled if the job is interrupted,
+// errDraining if the agent is draining or errJobRunning if a scan job with the same job ID is running.
+func (r *jobRegistry) start(req *agentpb.ScanRequest) (context.Context, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.draining {
+		return nil, errDraining
+	}
+
+	if _, ok := r.running[req.GetJobId()]; ok {
+		return nil, errJobRunning
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	job := &runningJob{
+		jobID:         req.GetJobId(),
+		ovalSourceURL: req.GetOvalSourceUrl(),
+		startedAt:     time.Now(),
+		cancel:        cancel,
+	}
+
+	for _, d := range req.GetDevices() {
+		job.devices = append(job.devices, d.GetDeviceName())
+	}
+
+	r.running[job.jobID] = job
+
+	return ctx, nil
+}
+
+// finish records the completion of a running scan job
+func (r *jobRegistry) finish(jobID string, succeeded bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if job, ok := r.running[jobID]; ok {
+		job.cancel()
+		delete(r.running, jobID)
+	}
+
+	if succeeded {
+		r.completed++
+	} else {
+		r.failed++
+	}
+
+	if r.draining && len(r.running) == 0 {
+		r.closeIdle()
+	}
+}
+
+// drain stops accepting new scan jobs. The returned channel is closed once no scan job is running.
+func (r *jobRegistry) drain() <-chan struct{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.draining {
+		r.draining = true
+		r.idle = make(chan struct{})
+
+		if len(r.running) == 0 {
+			r.closeIdle()
+		}
+	}
+
+	return r.idle
+}
+
+// closeIdle closes the idle channel once. It must be called with the lock held.
+func (r *jobRegistry) closeIdle() {
+	select {
+	case <-r.idle:
+	default:
+		close(r.idle)
+	}
+}
+
+// isDraining returns whether the registry rejects new scan jobs
+func (r *jobRegistry) isDraining() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.draining
+}
+
+// cancelAll cancels the running scan jobs and returns them
+func (r *jobRegistry) cancelAll() []runningJob {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cancelled := make([]runningJob, 0, len(r.running))
+
+	for _, job := range r.running {
+		job.cancel()
+		cancelled = append(cancelled, *job)
+	}
+
+	return cancelled
+}
+
+// runningJobs returns the running scan jobs sorted by start time
+func (r *jobRegistry) runningJobs() []*agentpb.RunningJob {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.runningJobsLocked()
+}
+
+func (r *jobRegistry) runningJobsLocked() []*agentpb.RunningJob {
+
+	jobs := make([]*agentpb.RunningJob, 0, len(r.running))
+
+	for _, job := range r.running {
+		jobs = append(jobs, &agentpb.RunningJob{
+			JobId:       job.jobID,
+			Devices:     int32(len(job.devices)),
+			StartedUnix: job.startedAt.Unix(),
+		})
+	}
+
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].StartedUnix < jobs[j].StartedUnix
+	})
+
+	return jobs
+}
+
+// counts returns a snapshot of the scan jobs counts
+func (r *jobRegistry) counts() *agentpb.AgentJobCounts {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return &agentpb.AgentJobCounts{
+		Running:     int32(len(r.running)),
+		Completed:   r.completed,
+		Failed:      r.failed,
+		RunningJobs: r.runningJobsLocked(),
+	}
+}
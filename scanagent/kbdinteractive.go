@@ -0,0 +1,73 @@
+package scanagent
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/lucabrasi83/vscan-agent/inibuilder"
+	agentpb "github.com/lucabrasi83/vscan-agent/proto"
+	"golang.org/x/crypto/ssh"
+)
+
+// errNoKbdAnswer is returned when no answer is configured for a keyboard-interactive prompt
+var errNoKbdAnswer = errors.New("no answer configured for keyboard-interactive prompt")
+
+// passwordPromptRegexp matches the keyboard-interactive prompts asking for the account password
+var passwordPromptRegexp = regexp.MustCompile(`(?i)password`)
+
+// kbdAnswer is a compiled keyboard-interactive prompt / answer mapping
+type kbdAnswer struct {
+	prompt *regexp.Regexp
+	answer string
+}
+
+// keyboardInteractive returns an SSH keyboard-interactive authentication method replying to the server prompts
+// with the first matching answer. Password prompts without a matching answer are replied with the password.
+// It returns nil if there is neither answer nor password to reply with.
+func keyboardInteractive(answers []*agentpb.KeyboardInteractiveAnswer, password string) (ssh.AuthMethod, error) {
+
+	if len(answers) == 0 && password == "" {
+		return nil, nil
+	}
+
+	compiled := make([]kbdAnswer, 0, len(answers))
+
+	for _, a := range answers {
+
+		re, err := inibuilder.CompilePromptPattern(a.GetPromptPattern())
+
+		if err != nil {
+			return nil, err
+		}
+
+		compiled = append(compiled, kbdAnswer{prompt: re, answer: a.GetAnswer()})
+	}
+
+	challenge := func(user, instruction string, questions []string, echos []bool) ([]string, error) {
+
+		replies := make([]string, len(questions))
+
+	questionsLoop:
+		for i, q := range questions {
+
+			for _, a := range compiled {
+				if a.prompt.MatchString(q) {
+					replies[i] = a.answer
+					continue questionsLoop
+				}
+			}
+
+			if password != "" && passwordPromptRegexp.MatchString(q) {
+				replies[i] = password
+				continue
+			}
+
+			return nil, fmt.Errorf("%w %q", errNoKbdAnswer, q)
+		}
+
+		return replies, nil
+	}
+
+	return ssh.KeyboardInteractive(challenge), nil
+}
@@ -4,22 +4,35 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sync"
 	"time"
 
+	"github.com/lucabrasi83/vscan-agent/certmanager"
+	"github.com/lucabrasi83/vscan-agent/config"
 	"github.com/lucabrasi83/vscan-agent/inibuilder"
 	"github.com/lucabrasi83/vscan-agent/logging"
+	"github.com/lucabrasi83/vscan-agent/metrics"
 	agentpb "github.com/lucabrasi83/vscan-agent/proto"
+	"github.com/lucabrasi83/vscan-agent/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
-type AgentServer struct{}
+// AgentServer implements the VSCAN Agent gRPC service
+type AgentServer struct {
+	certManager *certmanager.Manager
+	drainer     *drainer
+	startedAt   time.Time
+}
 
 var (
 	hostname string
@@ -35,7 +48,8 @@ func init() {
 	}
 
 }
-func (*AgentServer) BuildScanConfig(req *agentpb.ScanRequest, stream agentpb.VscanAgentService_BuildScanConfigServer) error {
+func (*AgentServer) BuildScanConfig(req *agentpb.ScanRequest,
+	stream agentpb.VscanAgentService_BuildScanConfigServer) (errScan error) {
 
 	logging.VSCANLog("info",
 		"Received scan request: Job ID %v - Target Device(s): %v - Requested Timeout (sec): %d\n",
@@ -53,6 +67,33 @@ func (*AgentServer) BuildScanConfig(req *agentpb.ScanRequest, stream agentpb.Vsc
 		)
 	}
 
+	jobCtx, err := scanJobs.start(req)
+
+	if errors.Is(err, errJobRunning) {
+		return status.Errorf(codes.AlreadyExists, "Agent %v - job ID %v: %v", hostname, jobID, err)
+	}
+
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "Agent %v - %v", hostname, err)
+	}
+
+	scanStart := time.Now()
+	metrics.ScanStarted()
+
+	defer func() {
+		metrics.ScanFinished(time.Since(scanStart), len(req.GetDevices()), errScan == nil)
+		scanJobs.finish(jobID, errScan == nil)
+	}()
+
+	ctx := stream.Context()
+
+	trace.SpanFromContext(ctx).SetAttributes(
+		attribute.String("scan.job_id", jobID),
+		attribute.Int("scan.devices", len(req.GetDevices())),
+	)
+
+	_, iniSpan := tracing.StartSpan(ctx, "inibuilder.BuildIni")
+
 	configBuf, err := inibuilder.BuildIni(
 		req.GetJobId(),
 		req.GetDevices(),
@@ -61,6 +102,8 @@ func (*AgentServer) BuildScanConfig(req *agentpb.ScanRequest, stream agentpb.Vsc
 		req.UserDeviceCredentials,
 	)
 
+	tracing.EndSpan(iniSpan, err)
+
 	if err != nil {
 		return status.Errorf(
 			codes.InvalidArgument,
@@ -68,7 +111,16 @@ func (*AgentServer) BuildScanConfig(req *agentpb.ScanRequest, stream agentpb.Vsc
 		)
 	}
 
-	scanLogs, err := execScan(jobID, scanTimeout, stream, configBuf)
+	// The Joval process runs within the job context so it is killed if the job is interrupted
+	scanLogs, err := execScan(trace.ContextWithSpan(jobCtx, trace.SpanFromContext(ctx)), jobID, scanTimeout, stream,
+		configBuf)
+
+	if jobCtx.Err() == context.Canceled {
+		return status.Errorf(
+			codes.Unavailable,
+			"Agent %v - scan job %v interrupted as the agent is shutting down", hostname, jobID,
+		)
+	}
 
 	if err != nil {
 		return status.Errorf(
@@ -77,7 +129,7 @@ func (*AgentServer) BuildScanConfig(req *agentpb.ScanRequest, stream agentpb.Vsc
 		)
 	}
 
-	reportDir := filepath.FromSlash("/opt/joval/scanjobs/" + jobID + "/reports/")
+	reportDir := filepath.Join(config.Get().Joval.ScanJobsDir, jobID, "reports")
 
 	if _, err := os.Stat(reportDir); !os.IsNotExist(err) {
 
@@ -95,12 +147,17 @@ func (*AgentServer) BuildScanConfig(req *agentpb.ScanRequest, stream agentpb.Vsc
 				)
 			}
 			if !info.IsDir() {
+				_, sendSpan := tracing.StartSpan(ctx, "scan.sendReport", attribute.String("scan.device", info.Name()))
+
 				reportFile, err := ioutil.ReadFile(path)
 
 				if err != nil {
+					tracing.EndSpan(sendSpan, err)
 					return fmt.Errorf("agent %v - error while reading report file %v: %v", hostname, path, err)
 				}
 
+				sendSpan.SetAttributes(attribute.Int("scan.report_bytes", len(reportFile)))
+
 				errStream := stream.Send(
 
 					&agentpb.ScanResultsResponse{
@@ -111,12 +168,16 @@ func (*AgentServer) BuildScanConfig(req *agentpb.ScanRequest, stream agentpb.Vsc
 					},
 				)
 
+				tracing.EndSpan(sendSpan, errStream)
+
 				if errStream != nil {
 					return status.Errorf(
 						codes.Internal,
 						fmt.Sprintf("agent %v - failed to send Joval JSON report stream: %v", hostname, errStream),
 					)
 				}
+
+				metrics.ReportStreamed(len(reportFile))
 			}
 			return nil
 		})
@@ -139,23 +200,34 @@ func (*AgentServer) BuildScanConfig(req *agentpb.ScanRequest, stream agentpb.Vsc
 	)
 }
 
-func execScan(job string, t int64, stream agentpb.VscanAgentService_BuildScanConfigServer, config io.Reader) (*agentpb.
-	ScanLogFileResponsePS, error) {
+func execScan(ctx context.Context, job string, t int64, stream agentpb.VscanAgentService_BuildScanConfigServer,
+	iniConfig io.Reader) (scanLogs *agentpb.ScanLogFileResponsePS, err error) {
+
+	_, span := tracing.StartSpan(ctx, "execScan",
+		attribute.String("scan.job_id", job),
+		attribute.Int64("scan.timeout_seconds", t),
+	)
+
+	defer func() { tracing.EndSpan(span, err) }()
 
-	ctxTimeout, cancel := context.WithTimeout(context.Background(), time.Duration(t)*time.Second)
+	ctxTimeout, cancel := context.WithTimeout(ctx, time.Duration(t)*time.Second)
 
 	defer cancel()
 
-	cmd := exec.CommandContext(ctxTimeout, "java",
-		"-Dlicense.file=/opt/joval/tatacommunications.com.sig.xml",
-		"-jar", "/opt/joval/Joval-Utilities.jar", "scan", "-c", "-",
+	jovalConfig := config.Get().Joval
+
+	cmd := exec.CommandContext(ctxTimeout, jovalConfig.JavaBin,
+		"-Dlicense.file="+jovalConfig.LicenseFile,
+		"-jar", jovalConfig.JarFile, "scan", "-c", "-",
 	)
 
-	cmd.Stdin = config
+	cmd.Stdin = iniConfig
 
 	// Copy Joval scan log output to logFile real-time
-	// bufStream will be sent to the gRPC stream as the log lines from Joval are generated
-	bufStream := bytes.NewBuffer(make([]byte, 0, 4096))
+	// bufStream will be sent to the gRPC stream as the log lines from Joval are generated.
+	// It is written by the command output copy while read by the streaming goroutine.
+	bufStream := &syncBuffer{}
+	bufStream.buf.Grow(4096)
 
 	// bufPersist will store the entire log and used for persistency in VSCAN DB
 	bufPersist := new(bytes.Buffer)
@@ -171,7 +243,7 @@ func execScan(job string, t int64, stream agentpb.VscanAgentService_BuildScanCon
 
 	// Go Routine to stream the scan job logs
 	go func() {
-		bufTicket := time.NewTicker(500 * time.Millisecond)
+		bufTicket := time.NewTicker(jovalConfig.LogStreamInterval)
 		defer bufTicket.Stop()
 		for {
 			select {
@@ -207,7 +279,15 @@ func execScan(job string, t int64, stream agentpb.VscanAgentService_BuildScanCon
 		}
 
 	}()
-	err := cmd.Run()
+	err = cmd.Start()
+
+	if err == nil {
+		span.AddEvent("Joval process started", trace.WithAttributes(attribute.Int("process.pid", cmd.Process.Pid)))
+
+		metrics.TrackJovalProcess(cmd.Process.Pid)
+		err = cmd.Wait()
+		metrics.UntrackJovalProcess(cmd.Process.Pid)
+	}
 
 	done <- true
 
@@ -221,3 +301,23 @@ func execScan(job string, t int64, stream agentpb.VscanAgentService_BuildScanCon
 
 	return &agentpb.ScanLogFileResponsePS{ScanLogs: bufPersist.Bytes()}, nil
 }
+
+// syncBuffer is a bytes.Buffer safe for a concurrent writer and reader
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) Read(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Read(p)
+}
@@ -0,0 +1,137 @@
+package scanagent
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/lucabrasi83/vscan-agent/config"
+	agentpb "github.com/lucabrasi83/vscan-agent/proto"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+)
+
+// scanStream is a stand-in BuildScanConfig server stream recording the responses sent
+type scanStream struct {
+	grpc.ServerStream
+
+	ctx context.Context
+
+	mu        sync.Mutex
+	responses []*agentpb.ScanResultsResponse
+}
+
+func (s *scanStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *scanStream) Send(res *agentpb.ScanResultsResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.responses = append(s.responses, res)
+
+	return nil
+}
+
+// fakeJoval sets up the Joval settings to run a stand-in scanner and writes the report of deviceName for jobID
+func fakeJoval(t *testing.T, jobID string, deviceName string) {
+
+	t.Helper()
+
+	dir := t.TempDir()
+
+	javaBin := filepath.Join(dir, "java")
+
+	script := "#!/bin/sh\ncat > /dev/null\necho 'scan completed' >&2\n"
+
+	if err := ioutil.WriteFile(javaBin, []byte(script), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	reportDir := filepath.Join(dir, "scanjobs", jobID, "reports")
+
+	if err := os.MkdirAll(reportDir, 0750); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(reportDir, deviceName), []byte(`{"results":[]}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	jovalConfig := &config.Get().Joval
+	previous := *jovalConfig
+
+	jovalConfig.JavaBin = javaBin
+	jovalConfig.ScanJobsDir = filepath.Join(dir, "scanjobs")
+
+	t.Cleanup(func() { *jovalConfig = previous })
+}
+
+func TestBuildScanConfigSpansContinueIncomingTrace(t *testing.T) {
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	previousProvider := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+
+	defer otel.SetTracerProvider(previousProvider)
+
+	const jobID = "trace-job"
+
+	fakeJoval(t, jobID, "device-1")
+
+	// The incoming trace context as extracted from the controller request metadata
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+
+	incoming := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+
+	stream := &scanStream{ctx: trace.ContextWithRemoteSpanContext(context.Background(), incoming)}
+
+	err := (&AgentServer{}).BuildScanConfig(&agentpb.ScanRequest{
+		JobId:              jobID,
+		Devices:            []*agentpb.Device{{DeviceName: "device-1", IpAddress: "192.0.2.1"}},
+		ScanTimeoutSeconds: 30,
+	}, stream)
+
+	if err != nil {
+		t.Fatalf("scan failed: %v", err)
+	}
+
+	spans := make(map[string]tracetest.SpanStub)
+
+	for _, s := range exporter.GetSpans() {
+		spans[s.Name] = s
+	}
+
+	for _, name := range []string{"inibuilder.BuildIni", "execScan", "scan.sendReport"} {
+
+		s, ok := spans[name]
+
+		if !ok {
+			t.Errorf("span %v not recorded", name)
+			continue
+		}
+
+		if s.SpanContext.TraceID() != traceID {
+			t.Errorf("span %v trace ID = %v, want the incoming trace %v", name, s.SpanContext.TraceID(), traceID)
+		}
+
+		if s.Parent.SpanID() != spanID {
+			t.Errorf("span %v parent = %v, want the incoming span %v", name, s.Parent.SpanID(), spanID)
+		}
+	}
+}
@@ -2,75 +2,236 @@ package scanagent
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
+	"github.com/lucabrasi83/vscan-agent/config"
 	"github.com/lucabrasi83/vscan-agent/logging"
 	agentpb "github.com/lucabrasi83/vscan-agent/proto"
+	"github.com/lucabrasi83/vscan-agent/tracing"
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/crypto/ssh"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// errCertificateWithoutKey is returned when an SSH certificate is provided without the private key it certifies
+var errCertificateWithoutKey = errors.New("SSH certificate requires the private key it certifies")
+
 func (*AgentServer) SSHConnectivityTest(ctx context.Context, req *agentpb.SSHGatewayTestRequest) (*agentpb.
 	SSHGatewayTestResponse,
 	error) {
 
-	logging.VSCANLog("info", "Received Request to Test SSH Gateway %v", req.SshGateway.GatewayIp)
+	logging.VSCANLog("info", "Received Request to Test SSH Gateway %v", req.GetSshGateway().GetGatewayIp())
+
+	if err := validateSSHCertificates(req.GetSshGateway(), nil); err != nil {
+		return nil, err
+	}
+
+	res, _ := testSSHGateway(ctx, req.GetSshGateway(), config.Get().SSH.DialTimeout)
+
+	return res, nil
+}
+
+// testSSHGateway tests SSH connectivity to the gateway and returns the test response
+// along with the error that made the test fail, if any
+func testSSHGateway(ctx context.Context, gw *agentpb.SSHGateway, timeout time.Duration) (*agentpb.
+	SSHGatewayTestResponse, error) {
+
+	_, span := tracing.StartSpan(ctx, "ssh.testGateway", attribute.String("ssh.gateway_ip", gw.GetGatewayIp()))
+
+	conn, hostKey, stats, err := dialGateway(ctx, gw, timeout)
+
+	span.SetAttributes(
+		attribute.String("ssh.failure_reason", classifySSHError(err).String()),
+		attribute.Int64("ssh.tcp_connect_ms", stats.tcpConnect.Milliseconds()),
+		attribute.Int64("ssh.handshake_ms", stats.handshake.Milliseconds()),
+		attribute.Int64("ssh.auth_ms", stats.auth.Milliseconds()),
+	)
+	tracing.EndSpan(span, err)
+
+	res := &agentpb.SSHGatewayTestResponse{
+		SshHostKeyFingerprint:   hostKey.Fingerprint(),
+		SshHostKeyMatched:       hostKey.Matched(),
+		SshFailureReason:        classifySSHError(err),
+		SshNegotiatedAlgorithms: stats.algorithms,
+		TcpConnectMs:            stats.tcpConnect.Milliseconds(),
+		HandshakeMs:             stats.handshake.Milliseconds(),
+		AuthMs:                  stats.auth.Milliseconds(),
+	}
+
+	if err != nil {
+		res.SshTestResult = err.Error()
+		return res, err
+	}
+
+	defer conn.Close()
 
-	sshAuthMethods, err := buildAuthMethods(req)
+	res.SshTestResult = string(conn.ServerVersion())
+	res.SshServerVersion = string(conn.ServerVersion())
+	res.SshCanConnect = true
+
+	return res, nil
+}
+
+// dialGateway establishes an SSH connection to the gateway.
+// The returned hostKeyVerifier and sshDialStats hold the gateway host key fingerprint and connection details
+// even if the connection failed.
+func dialGateway(ctx context.Context, gw *agentpb.SSHGateway, timeout time.Duration) (*ssh.Client,
+	*hostKeyVerifier, *sshDialStats, error) {
+
+	// Verify the gateway host key against the agent host key policy and pinned fingerprints
+	hostKey := newHostKeyVerifier(gw.GetGatewayHostKeyFingerprints())
+
+	sshAuthMethods, err := buildGatewayAuthMethods(gw)
 
 	if err != nil {
-		return &agentpb.
-			SSHGatewayTestResponse{
-			SshTestResult: err.Error(),
-			SshCanConnect: false,
-		}, nil
+		return nil, hostKey, &sshDialStats{}, err
 	}
 
 	// Build SSH Config
 	sshConfig := &ssh.ClientConfig{
-		User:            req.SshGateway.GatewayUsername,
+		User:            gw.GetGatewayUsername(),
 		Auth:            sshAuthMethods,
-		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
-		Timeout:         30 * time.Second,
+		HostKeyCallback: hostKey.Callback,
+		Timeout:         timeout,
 	}
 
-	conn, err := ssh.Dial("tcp", req.SshGateway.GatewayIp+":22", sshConfig)
+	conn, stats, err := dialSSH(ctx, gw.GetGatewayIp()+":22", gw.GetGatewayProxyUrl(), sshConfig, timeout)
 
 	if err != nil {
-		return &agentpb.
-			SSHGatewayTestResponse{
-			SshTestResult: err.Error(),
-			SshCanConnect: false,
-		}, nil
+		return nil, hostKey, stats, err
 	}
 
-	defer conn.Close()
+	hostKey.recordTrusted()
 
-	return &agentpb.
-		SSHGatewayTestResponse{
-		SshTestResult: string(conn.ServerVersion()),
-		SshCanConnect: true,
-	}, nil
+	return conn, hostKey, stats, nil
 }
 
-func publicKey(key string) (ssh.AuthMethod, error) {
+// publicKey parses the PEM private key, decrypting it with the passphrase when it is encrypted.
+// A passphrase provided with an unencrypted key is ignored.
+// The decrypted key only lives in memory for the duration of the SSH test.
+// When an OpenSSH certificate is provided, the key is presented along with the certificate.
+func publicKey(key string, passphrase string, certificate string) (ssh.AuthMethod, error) {
 
 	signer, err := ssh.ParsePrivateKey([]byte(key))
+
+	if _, ok := err.(*ssh.PassphraseMissingError); ok {
+
+		if passphrase == "" {
+			return nil, fmt.Errorf("private key is encrypted and no passphrase was provided")
+		}
+
+		signer, err = ssh.ParsePrivateKeyWithPassphrase([]byte(key), []byte(passphrase))
+	}
+
 	if err != nil {
 		return nil, err
 	}
+
+	if certificate != "" {
+		signer, err = certSigner(certificate, signer)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return ssh.PublicKeys(signer), nil
 }
 
-func buildAuthMethods(req *agentpb.SSHGatewayTestRequest) ([]ssh.AuthMethod, error) {
+// certSigner parses the OpenSSH certificate and returns a signer presenting it along with the private key
+func certSigner(certificate string, signer ssh.Signer) (ssh.Signer, error) {
+
+	pubKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(certificate))
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse SSH certificate: %v", err)
+	}
+
+	cert, ok := pubKey.(*ssh.Certificate)
+
+	if !ok {
+		return nil, fmt.Errorf("SSH certificate is a %v public key and not an OpenSSH certificate", pubKey.Type())
+	}
+
+	if cert.CertType != ssh.UserCert {
+		return nil, fmt.Errorf("SSH certificate %v is not a user certificate", cert.KeyId)
+	}
+
+	now := uint64(time.Now().Unix())
+
+	if cert.ValidBefore != ssh.CertTimeInfinity && now >= cert.ValidBefore {
+		return nil, fmt.Errorf("SSH certificate %v expired on %v", cert.KeyId,
+			time.Unix(int64(cert.ValidBefore), 0).UTC())
+	}
+
+	if now < cert.ValidAfter {
+		return nil, fmt.Errorf("SSH certificate %v is not valid before %v", cert.KeyId,
+			time.Unix(int64(cert.ValidAfter), 0).UTC())
+	}
+
+	return ssh.NewCertSigner(cert, signer)
+}
+
+func buildGatewayAuthMethods(gw *agentpb.SSHGateway) ([]ssh.AuthMethod, error) {
+
+	authMethod := make([]ssh.AuthMethod, 0)
+
+	if gw.GetGatewayCertificate() != "" && gw.GetGatewayPrivateKey() == "" {
+		return nil, errCertificateWithoutKey
+	}
+
+	if gw.GetGatewayPassword() != "" {
+		authMethod = append(authMethod, ssh.Password(gw.GetGatewayPassword()))
+	}
+
+	if gw.GetGatewayPrivateKey() != "" {
+		keyAuth, err := publicKey(
+			gw.GetGatewayPrivateKey(),
+			gw.GetGatewayPrivateKeyPassphrase(),
+			gw.GetGatewayCertificate(),
+		)
+
+		if err != nil {
+			return nil, err
+		}
+		authMethod = append(authMethod, keyAuth)
+
+	}
+
+	kbdAuth, err := keyboardInteractive(gw.GetGatewayKeyboardInteractiveAnswers(), gw.GetGatewayPassword())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if kbdAuth != nil {
+		authMethod = append(authMethod, kbdAuth)
+	}
+
+	return authMethod, nil
+}
+
+func buildDeviceAuthMethods(creds *agentpb.UserDeviceCredentials) ([]ssh.AuthMethod, error) {
 
 	authMethod := make([]ssh.AuthMethod, 0)
 
-	if req.SshGateway.GatewayPassword != "" {
-		authMethod = append(authMethod, ssh.Password(req.SshGateway.GatewayPassword))
+	if creds.GetCertificate() != "" && creds.GetPrivateKey() == "" {
+		return nil, errCertificateWithoutKey
+	}
+
+	if creds.GetPassword() != "" {
+		authMethod = append(authMethod, ssh.Password(creds.GetPassword()))
 	}
 
-	if req.SshGateway.GatewayPrivateKey != "" {
-		keyAuth, err := publicKey(req.SshGateway.GatewayPrivateKey)
+	if creds.GetPrivateKey() != "" {
+		keyAuth, err := publicKey(
+			creds.GetPrivateKey(),
+			creds.GetPrivateKeyPassphrase(),
+			creds.GetCertificate(),
+		)
 
 		if err != nil {
 			return nil, err
@@ -78,5 +239,33 @@ func buildAuthMethods(req *agentpb.SSHGatewayTestRequest) ([]ssh.AuthMethod, err
 		authMethod = append(authMethod, keyAuth)
 
 	}
+
+	// Network devices commonly prompt for the password through keyboard-interactive authentication
+	kbdAuth, err := keyboardInteractive(nil, creds.GetPassword())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if kbdAuth != nil {
+		authMethod = append(authMethod, kbdAuth)
+	}
+
 	return authMethod, nil
 }
+
+// validateSSHCertificates returns an InvalidArgument status error if an SSH certificate of the gateway or of the
+// device credentials is provided without its private key
+func validateSSHCertificates(gw *agentpb.SSHGateway, creds *agentpb.UserDeviceCredentials) error {
+
+	if gw.GetGatewayCertificate() != "" && gw.GetGatewayPrivateKey() == "" {
+		return status.Errorf(codes.InvalidArgument, "Agent %v - SSH gateway %v", hostname, errCertificateWithoutKey)
+	}
+
+	if creds.GetCertificate() != "" && creds.GetPrivateKey() == "" {
+		return status.Errorf(codes.InvalidArgument, "Agent %v - device credentials %v", hostname,
+			errCertificateWithoutKey)
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package scanagent
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"testing"
+)
+
+func TestPublicKey(t *testing.T) {
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(edKey)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unencrypted := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}))
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Legacy PEM encryption, as produced by ssh-keygen -m PEM with a passphrase
+	block, err := x509.EncryptPEMBlock(rand.Reader, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey),
+		[]byte("s3cr3t"), x509.PEMCipherAES256)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encrypted := string(pem.EncodeToMemory(block))
+
+	tests := []struct {
+		name       string
+		key        string
+		passphrase string
+		wantErr    string
+	}{
+		{name: "unencrypted key without passphrase", key: unencrypted},
+		{name: "unencrypted key with passphrase", key: unencrypted, passphrase: "s3cr3t"},
+		{name: "encrypted key with passphrase", key: encrypted, passphrase: "s3cr3t"},
+		{name: "encrypted key without passphrase", key: encrypted, wantErr: "no passphrase was provided"},
+		{name: "encrypted key with wrong passphrase", key: encrypted, passphrase: "wrong", wantErr: "decryption"},
+		{name: "invalid key", key: "not a key", passphrase: "s3cr3t", wantErr: "no key found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			auth, err := publicKey(tt.key, tt.passphrase, "")
+
+			if tt.wantErr == "" {
+				if err != nil || auth == nil {
+					t.Fatalf("publicKey() error = %v", err)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("publicKey() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package scanagent
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/lucabrasi83/vscan-agent/config"
+	"golang.org/x/net/proxy"
+)
+
+func init() {
+
+	// HTTP CONNECT proxies are not natively supported by golang.org/x/net/proxy
+	proxy.RegisterDialerType("http", newHTTPConnectDialer)
+}
+
+// dialTCP establishes a TCP connection to addr through the given proxy URL, or the agent default SSH proxy
+// if empty, or directly if neither is set. The dial is aborted once ctx is done.
+func dialTCP(ctx context.Context, addr string, proxyURL string, timeout time.Duration) (net.Conn, error) {
+
+	forward := &net.Dialer{Timeout: timeout}
+
+	if proxyURL == "" {
+		proxyURL = config.Get().SSH.ProxyURL
+	}
+
+	if proxyURL == "" {
+		return forward.DialContext(ctx, "tcp", addr)
+	}
+
+	u, err := parseProxyURL(proxyURL)
+
+	if err != nil {
+		return nil, err
+	}
+
+	dialer, err := proxy.FromURL(u, forward)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to set up proxy %v: %v", redactProxyURL(u), err)
+	}
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	var conn net.Conn
+
+	if ctxDialer, ok := dialer.(proxy.ContextDialer); ok {
+		conn, err = ctxDialer.DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("proxy %v: %w", redactProxyURL(u), err)
+	}
+
+	return conn, nil
+}
+
+// parseProxyURL parses and validates a socks5 or http proxy URL
+func parseProxyURL(proxyURL string) (*url.URL, error) {
+
+	u, err := url.Parse(proxyURL)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse proxy URL: %v", err)
+	}
+
+	switch u.Scheme {
+	case "socks5", "socks5h", "http":
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q. Supported schemes: socks5, http", u.Scheme)
+	}
+
+	if u.Host == "" {
+		return nil, fmt.Errorf("proxy URL %v has no host", redactProxyURL(u))
+	}
+
+	return u, nil
+}
+
+// redactProxyURL returns the proxy URL without its credentials so it can be logged
+func redactProxyURL(u *url.URL) string {
+	return u.Scheme + "://" + u.Host
+}
+
+// httpConnectDialer establishes TCP connections through an HTTP proxy using the CONNECT method
+type httpConnectDialer struct {
+	proxyURL *url.URL
+	forward  proxy.Dialer
+}
+
+func newHTTPConnectDialer(u *url.URL, forward proxy.Dialer) (proxy.Dialer, error) {
+	return &httpConnectDialer{proxyURL: u, forward: forward}, nil
+}
+
+func (d *httpConnectDialer) Dial(network, addr string) (net.Conn, error) {
+	return d.DialContext(context.Background(), network, addr)
+}
+
+func (d *httpConnectDialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+
+	var conn net.Conn
+	var err error
+
+	if ctxDialer, ok := d.forward.(proxy.ContextDialer); ok {
+		conn, err = ctxDialer.DialContext(ctx, network, d.proxyURL.Host)
+	} else {
+		conn, err = d.forward.Dial(network, d.proxyURL.Host)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: make(http.Header),
+	}
+
+	if user := d.proxyURL.User; user != nil {
+		password, _ := user.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(user.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("unable to send CONNECT request: %v", err)
+	}
+
+	br := bufio.NewReader(conn)
+
+	res, err := http.ReadResponse(br, req)
+
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("unable to read CONNECT response: %v", err)
+	}
+
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, fmt.Errorf("CONNECT to %v rejected with status %v", addr, res.Status)
+	}
+
+	_ = conn.SetDeadline(time.Time{})
+
+	// The proxy may have sent data from the remote host along with the CONNECT response
+	if br.Buffered() > 0 {
+		return &bufferedConn{Conn: conn, r: br}, nil
+	}
+
+	return conn, nil
+}
+
+// bufferedConn is a net.Conn reading first from a buffered reader holding data already received
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
@@ -0,0 +1,226 @@
+package scanagent
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/lucabrasi83/vscan-agent/config"
+	"github.com/lucabrasi83/vscan-agent/logging"
+	agentpb "github.com/lucabrasi83/vscan-agent/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Registration retry backoff bounds while the controller is unreachable
+const (
+	registrationMinBackoff = time.Second
+	registrationMaxBackoff = time.Minute
+)
+
+// registrar registers the agent with the controller, reports its status with periodic heartbeats and
+// deregisters it on graceful shutdown
+type registrar struct {
+	conn   *grpc.ClientConn
+	client agentpb.VscanControllerServiceClient
+
+	agent  *AgentServer
+	health *healthChecker
+
+	advertiseAddr string
+	interval      time.Duration
+	timeout       time.Duration
+
+	mu      sync.Mutex
+	agentID string
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// newRegistrar returns a registrar connecting to the controller with the dial options, which set the transport
+// credentials
+func newRegistrar(controllerConfig config.Controller, agent *AgentServer, health *healthChecker,
+	opts ...grpc.DialOption) (*registrar, error) {
+
+	// The connection to the controller is established lazily and re-established by gRPC when lost
+	conn, err := grpc.Dial(controllerConfig.Addr, opts...)
+
+	if err != nil {
+		return nil, fmt.Errorf("error while creating controller client: %v", err)
+	}
+
+	advertiseAddr := controllerConfig.AdvertiseAddr
+
+	if reverseConfig := config.Get().Reverse; advertiseAddr == "" && !(reverseConfig.Enabled &&
+		reverseConfig.DisableInbound) {
+		advertiseAddr = net.JoinHostPort(hostname, config.Get().Server.BindPort)
+	}
+
+	return &registrar{
+		conn:          conn,
+		client:        agentpb.NewVscanControllerServiceClient(conn),
+		agent:         agent,
+		health:        health,
+		advertiseAddr: advertiseAddr,
+		interval:      controllerConfig.HeartbeatInterval,
+		timeout:       controllerConfig.RequestTimeout,
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}, nil
+}
+
+// run registers the agent then sends heartbeats until deregister is called
+func (r *registrar) run() {
+
+	go func() {
+		defer close(r.done)
+
+		if !r.registerWithRetry() {
+			return
+		}
+
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-r.stop:
+				return
+			case <-ticker.C:
+			}
+
+			err := r.heartbeat()
+
+			switch {
+			case err == nil:
+			case status.Code(err) == codes.NotFound:
+				logging.VSCANLog("warning", "controller does not know VSCAN Agent anymore, registering again")
+
+				if !r.registerWithRetry() {
+					return
+				}
+			default:
+				logging.VSCANLog("warning", "unable to send heartbeat to controller: %v", err)
+			}
+
+			// The controller may have changed the heartbeat interval on registration
+			ticker.Reset(r.interval)
+		}
+	}()
+}
+
+// registerWithRetry registers the agent until it succeeds. It returns false if the registrar was stopped first.
+func (r *registrar) registerWithRetry() bool {
+
+	backoff := registrationMinBackoff
+
+	for {
+		err := r.register()
+
+		if err == nil {
+			return true
+		}
+
+		logging.VSCANLog("error", "unable to register VSCAN Agent with controller: %v. Retrying in %v",
+			err, backoff)
+
+		select {
+		case <-r.stop:
+			return false
+		case <-time.After(backoff):
+		}
+
+		if backoff *= 2; backoff > registrationMaxBackoff {
+			backoff = registrationMaxBackoff
+		}
+	}
+}
+
+// register sends the agent details to the controller
+func (r *registrar) register() error {
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	resp, err := r.client.RegisterAgent(ctx, &agentpb.AgentRegistration{
+		AgentName:     hostname,
+		AdvertiseAddr: r.advertiseAddr,
+		AgentInfo:     r.agent.agentInfo(),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.agentID = resp.GetAgentId()
+	r.mu.Unlock()
+
+	if resp.GetHeartbeatIntervalSeconds() > 0 {
+		r.interval = time.Duration(resp.GetHeartbeatIntervalSeconds()) * time.Second
+	}
+
+	logging.VSCANLog("info", "VSCAN Agent registered with controller as %v, sending heartbeats every %v",
+		resp.GetAgentId(), r.interval)
+
+	return nil
+}
+
+// heartbeat reports the agent load, disk, running jobs and serving status to the controller
+func (r *registrar) heartbeat() error {
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	jobs := scanJobs.counts()
+
+	_, err := r.client.Heartbeat(ctx, &agentpb.AgentHeartbeat{
+		AgentId:     r.id(),
+		AgentName:   hostname,
+		Resources:   resourceUsage(),
+		QueueDepth:  jobs.GetRunning(),
+		RunningJobs: jobs.GetRunningJobs(),
+		Draining:    scanJobs.isDraining(),
+		Serving:     r.health.serving(),
+	})
+
+	return err
+}
+
+// deregister stops the heartbeats, removes the agent from the controller and closes the controller connection
+func (r *registrar) deregister() {
+
+	close(r.stop)
+	<-r.done
+
+	defer r.conn.Close()
+
+	agentID := r.id()
+
+	if agentID == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	_, err := r.client.DeregisterAgent(ctx, &agentpb.AgentDeregistration{AgentId: agentID, AgentName: hostname})
+
+	if err != nil && status.Code(err) != codes.NotFound {
+		logging.VSCANLog("error", "unable to deregister VSCAN Agent from controller: %v", err)
+		return
+	}
+
+	logging.VSCANLog("info", "VSCAN Agent deregistered from controller")
+}
+
+func (r *registrar) id() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.agentID
+}
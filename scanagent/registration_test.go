@@ -0,0 +1,218 @@
+package scanagent
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lucabrasi83/vscan-agent/config"
+	agentpb "github.com/lucabrasi83/vscan-agent/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// standInController is an in-process VscanControllerService recording the requests it received
+type standInController struct {
+	agentpb.UnimplementedVscanControllerServiceServer
+
+	registrations   chan *agentpb.AgentRegistration
+	heartbeats      chan *agentpb.AgentHeartbeat
+	deregistrations chan *agentpb.AgentDeregistration
+
+	mu            sync.Mutex
+	registered    int
+	agentID       string
+	forgetAgentID bool
+}
+
+func newStandInController() *standInController {
+	return &standInController{
+		registrations:   make(chan *agentpb.AgentRegistration, 10),
+		heartbeats:      make(chan *agentpb.AgentHeartbeat, 100),
+		deregistrations: make(chan *agentpb.AgentDeregistration, 10),
+	}
+}
+
+func (c *standInController) RegisterAgent(ctx context.Context,
+	req *agentpb.AgentRegistration) (*agentpb.AgentRegistrationResponse, error) {
+
+	c.mu.Lock()
+	c.registered++
+	c.agentID = fmt.Sprintf("agent-%d", c.registered)
+	c.forgetAgentID = false
+	resp := &agentpb.AgentRegistrationResponse{AgentId: c.agentID}
+	c.mu.Unlock()
+
+	c.registrations <- req
+
+	return resp, nil
+}
+
+func (c *standInController) Heartbeat(ctx context.Context,
+	req *agentpb.AgentHeartbeat) (*agentpb.AgentHeartbeatResponse, error) {
+
+	c.mu.Lock()
+	known := req.GetAgentId() == c.agentID && !c.forgetAgentID
+	c.mu.Unlock()
+
+	if !known {
+		return nil, status.Errorf(codes.NotFound, "unknown agent ID %v", req.GetAgentId())
+	}
+
+	select {
+	case c.heartbeats <- req:
+	default:
+	}
+
+	return &agentpb.AgentHeartbeatResponse{}, nil
+}
+
+func (c *standInController) DeregisterAgent(ctx context.Context,
+	req *agentpb.AgentDeregistration) (*agentpb.AgentDeregistrationResponse, error) {
+
+	c.deregistrations <- req
+
+	return &agentpb.AgentDeregistrationResponse{}, nil
+}
+
+// forget makes the controller reply NotFound to the heartbeats until the agent registers again
+func (c *standInController) forget() {
+	c.mu.Lock()
+	c.forgetAgentID = true
+	c.mu.Unlock()
+}
+
+// startStandInController serves the controller over an in-memory listener and returns the dial options to reach it
+func startStandInController(t *testing.T, controller *standInController) []grpc.DialOption {
+
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+
+	srv := grpc.NewServer()
+	agentpb.RegisterVscanControllerServiceServer(srv, controller)
+
+	go func() { _ = srv.Serve(lis) }()
+
+	t.Cleanup(srv.Stop)
+
+	return []grpc.DialOption{
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+	}
+}
+
+func receive(t *testing.T, name string, c interface{}) interface{} {
+
+	t.Helper()
+
+	timer := time.NewTimer(5 * time.Second)
+	defer timer.Stop()
+
+	switch c := c.(type) {
+	case chan *agentpb.AgentRegistration:
+		select {
+		case v := <-c:
+			return v
+		case <-timer.C:
+		}
+	case chan *agentpb.AgentHeartbeat:
+		select {
+		case v := <-c:
+			return v
+		case <-timer.C:
+		}
+	case chan *agentpb.AgentDeregistration:
+		select {
+		case v := <-c:
+			return v
+		case <-timer.C:
+		}
+	}
+
+	t.Fatalf("controller did not receive %v", name)
+
+	return nil
+}
+
+func TestRegistrar(t *testing.T) {
+
+	const jobID = "registration-job"
+
+	if _, err := scanJobs.start(&agentpb.ScanRequest{JobId: jobID}); err != nil {
+		t.Fatalf("unable to start scan job: %v", err)
+	}
+
+	defer scanJobs.finish(jobID, true)
+
+	controller := newStandInController()
+
+	healthChecker := &healthChecker{server: health.NewServer()}
+	healthChecker.server.SetServingStatus(agentServiceName, healthpb.HealthCheckResponse_SERVING)
+
+	r, err := newRegistrar(config.Controller{
+		Addr:              "controller.vscan.test:443",
+		AdvertiseAddr:     "agent.vscan.test:50051",
+		HeartbeatInterval: 10 * time.Millisecond,
+		RequestTimeout:    5 * time.Second,
+	}, &AgentServer{startedAt: time.Now()}, healthChecker, startStandInController(t, controller)...)
+
+	if err != nil {
+		t.Fatalf("unable to create registrar: %v", err)
+	}
+
+	r.run()
+
+	registration := receive(t, "registration", controller.registrations).(*agentpb.AgentRegistration)
+
+	if registration.GetAgentName() != hostname || registration.GetAdvertiseAddr() != "agent.vscan.test:50051" {
+		t.Errorf("registration = %v (%v), want %v (agent.vscan.test:50051)", registration.GetAgentName(),
+			registration.GetAdvertiseAddr(), hostname)
+	}
+
+	if info := registration.GetAgentInfo(); info.GetBuild() == nil || info.GetJobs().GetRunning() != 1 {
+		t.Errorf("registration carries agent info %v, want the build and running jobs", info)
+	}
+
+	heartbeat := receive(t, "heartbeat", controller.heartbeats).(*agentpb.AgentHeartbeat)
+
+	if heartbeat.GetAgentId() != "agent-1" || !heartbeat.GetServing() || heartbeat.GetDraining() {
+		t.Errorf("heartbeat = %v, want serving agent-1", heartbeat)
+	}
+
+	if heartbeat.GetQueueDepth() != 1 || len(heartbeat.GetRunningJobs()) != 1 ||
+		heartbeat.GetRunningJobs()[0].GetJobId() != jobID {
+		t.Errorf("heartbeat queue depth %v and running jobs %v, want 1 and %v", heartbeat.GetQueueDepth(),
+			heartbeat.GetRunningJobs(), jobID)
+	}
+
+	// A heartbeat unknown to the controller makes the agent register again
+	controller.forget()
+
+	receive(t, "registration after NotFound heartbeat", controller.registrations)
+
+	for {
+		heartbeat = receive(t, "heartbeat after registering again", controller.heartbeats).(*agentpb.AgentHeartbeat)
+
+		if heartbeat.GetAgentId() == "agent-2" {
+			break
+		}
+	}
+
+	r.deregister()
+
+	deregistration := receive(t, "deregistration", controller.deregistrations).(*agentpb.AgentDeregistration)
+
+	if deregistration.GetAgentId() != "agent-2" || deregistration.GetAgentName() != hostname {
+		t.Errorf("deregistration = %v, want agent-2", deregistration)
+	}
+}
@@ -1,113 +1,339 @@
 package scanagent
 
 import (
+	"context"
 	"crypto/tls"
-	"crypto/x509"
-	"fmt"
-	"io/ioutil"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"syscall"
+	"time"
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"github.com/lucabrasi83/vscan-agent/config"
+	"github.com/lucabrasi83/vscan-agent/initializer"
 	"github.com/lucabrasi83/vscan-agent/logging"
+	"github.com/lucabrasi83/vscan-agent/metrics"
 	"github.com/lucabrasi83/vscan-agent/middleware"
 	agentpb "github.com/lucabrasi83/vscan-agent/proto"
+	"github.com/lucabrasi83/vscan-agent/reverse"
+	"github.com/lucabrasi83/vscan-agent/tracing"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
-// default GRPC Listening Port if not specified in environment variable
-var grpcListenPort = "50051"
-
-const (
-	certFile   = "/opt/certs/vscan-agent.pem"
-	keyFile    = "/opt/certs/vscan-agent.key"
-	caCertFile = "/opt/certs/TCL-ENT-CA.pem"
-)
+// gracefulStopTimeout is the time given to the requests in progress, other than scan jobs, to complete on shutdown
+const gracefulStopTimeout = 30 * time.Second
 
 func StartServer() {
 
-	if os.Getenv("VSCAN_AGENT_BIND_PORT") != "" {
-		grpcListenPort = os.Getenv("VSCAN_AGENT_BIND_PORT")
+	grpcListenPort := config.Get().Server.BindPort
+	reverseConfig := config.Get().Reverse
+
+	var listeners []net.Listener
+
+	if !reverseConfig.Enabled || !reverseConfig.DisableInbound {
+
+		lis, err := net.Listen("tcp", ":"+grpcListenPort)
+
+		if err != nil {
+			logging.VSCANLog("fatal", "unable to open TCP socket: %v", err)
+		}
+
+		listeners = append(listeners, lis)
 	}
 
-	lis, err := net.Listen("tcp", ":"+grpcListenPort)
+	// Channel to stop the certificates background routines on shutdown
+	stopWatch := make(chan struct{})
+
+	certManager := loadCertificates(stopWatch)
+
+	tlsCredentials := credentials.NewTLS(certManager.TLSConfig())
+
+	// Reverse-connect tunnels are already secured when dialing the controller and bypass the server handshake
+	if reverseConfig.Enabled {
 
-	if err != nil {
-		logging.VSCANLog("fatal", "unable to open TCP socket: %v", err)
+		tlsCredentials = reverse.Credentials(tlsCredentials)
+
+		listeners = append(listeners, reverse.Listen(reverse.Options{
+			ControllerAddr: reverseConfig.ControllerAddr,
+			TLSConfig: func() *tls.Config {
+				return certManager.ClientTLSConfig(reverseConfig.ServerName)
+			},
+			Registration: reverse.Registration{
+				AgentName: hostname,
+				Version:   initializer.Version,
+				Service:   agentServiceName,
+			},
+			Tunnels:     reverseConfig.Tunnels,
+			DialTimeout: reverseConfig.DialTimeout,
+			MinBackoff:  reverseConfig.MinBackoff,
+			MaxBackoff:  reverseConfig.MaxBackoff,
+		}))
+	}
+
+	admission := newAdmissionController(config.Get().Admission)
+
+	var limiters []middleware.Limiter
+
+	// Client rate limits apply before the admission control so rejected clients do not hold scan quota slots
+	if rateLimitConfig := config.Get().RateLimit; rateLimitConfig.Enabled {
+		limiters = append(limiters, newIdentityLimiter(rateLimitConfig))
 	}
 
-	tlsCredentials, err := serverCertLoad()
+	limiter := middleware.Chain(append(limiters, admission)...)
 
-	if err != nil {
-		logging.VSCANLog("fatal", "unable to load TLS certificates: %v", err)
+	unaryInterceptors := []grpc.UnaryServerInterceptor{middleware.UnaryServerInterceptor(limiter)}
+	streamInterceptors := []grpc.StreamServerInterceptor{middleware.StreamServerInterceptor(limiter)}
+
+	// Client identity authorization is enforced before any other interceptor
+	if authzConfig := config.Get().Authz; authzConfig.Enabled {
+
+		authorizer := middleware.NewAuthorizer(authzConfig.Policies)
+
+		unaryInterceptors = append([]grpc.UnaryServerInterceptor{middleware.UnaryAuthzInterceptor(authorizer)},
+			unaryInterceptors...)
+		streamInterceptors = append([]grpc.StreamServerInterceptor{middleware.StreamAuthzInterceptor(authorizer)},
+			streamInterceptors...)
+	} else {
+		logging.VSCANLog("warning", "client identity authorization is disabled. "+
+			"Any client certificate signed by the CA is allowed to call every RPC")
 	}
 
-	limiter := middleware.AlwaysPassLimiter{}
+	// Metrics interceptors run first to record the requests rejected by the other interceptors
+	unaryInterceptors = append([]grpc.UnaryServerInterceptor{metrics.UnaryServerInterceptor()}, unaryInterceptors...)
+	streamInterceptors = append([]grpc.StreamServerInterceptor{metrics.StreamServerInterceptor()},
+		streamInterceptors...)
+
+	var shutdownTracing func(context.Context) error
+	var err error
+
+	// Tracing interceptors run before the metrics ones so every other interceptor runs within the request span
+	if tracingConfig := config.Get().Tracing; tracingConfig.Enabled {
+
+		shutdownTracing, err = tracing.Init(tracing.Options{
+			Endpoint:       tracingConfig.Endpoint,
+			Insecure:       tracingConfig.Insecure,
+			CACertFile:     tracingConfig.CACertFile,
+			SampleRatio:    tracingConfig.SampleRatio,
+			ServiceName:    tracingConfig.ServiceName,
+			ServiceVersion: initializer.Version,
+		})
+
+		if err != nil {
+			logging.VSCANLog("fatal", "unable to initialize distributed tracing: %v", err)
+		}
+
+		logging.VSCANLog("info", "exporting traces to OTLP collector %v", tracingConfig.Endpoint)
+
+		unaryInterceptors = append([]grpc.UnaryServerInterceptor{tracing.UnaryServerInterceptor()},
+			unaryInterceptors...)
+		streamInterceptors = append([]grpc.StreamServerInterceptor{tracing.StreamServerInterceptor()},
+			streamInterceptors...)
+	}
 
 	s := grpc.NewServer(
 		grpc.Creds(tlsCredentials),
-		grpc_middleware.WithUnaryServerChain(
-			middleware.UnaryServerInterceptor(&limiter),
-		),
-		grpc_middleware.WithStreamServerChain(
-			middleware.StreamServerInterceptor(&limiter),
-		),
+		grpc_middleware.WithUnaryServerChain(unaryInterceptors...),
+		grpc_middleware.WithStreamServerChain(streamInterceptors...),
 	)
 
-	agentpb.RegisterVscanAgentServiceServer(s, &AgentServer{})
+	healthChecker := newHealthChecker(certManager, admission)
+	healthpb.RegisterHealthServer(s, healthChecker.server)
+	healthChecker.run(config.Get().Server.HealthCheckInterval, stopWatch)
+
+	agentDrainer := newDrainer(scanJobs, healthChecker)
+
+	agentServer := &AgentServer{
+		certManager: certManager,
+		drainer:     agentDrainer,
+		startedAt:   time.Now(),
+	}
+
+	agentpb.RegisterVscanAgentServiceServer(s, agentServer)
 
-	logging.VSCANLog("info", "starting VSCAN Agent on port %v...\n", grpcListenPort)
+	var metricsServer *http.Server
+
+	if metricsConfig := config.Get().Metrics; metricsConfig.Enabled {
+		metrics.RegisterCertificateExpiry(certManager)
+		metricsServer = metrics.StartServer(metricsConfig.ListenAddr)
+	}
+
+	if len(listeners) > 1 || !reverseConfig.Enabled {
+		logging.VSCANLog("info", "starting VSCAN Agent on port %v...\n", grpcListenPort)
+	}
+
+	if reverseConfig.Enabled {
+		logging.VSCANLog("info", "starting VSCAN Agent reverse-connect to controller %v with %v tunnel(s)...",
+			reverseConfig.ControllerAddr, reverseConfig.Tunnels)
+	}
 
 	// Channel to handle graceful shutdown of GRPC Server
 	ch := make(chan os.Signal, 1)
 
-	// Write in Channel in case of OS request to shut process
-	signal.Notify(ch, os.Interrupt)
+	// Write in Channel in case of OS request to shut process. Container runtimes send SIGTERM.
+	signal.Notify(ch, os.Interrupt, syscall.SIGTERM)
+
+	// Reload TLS certificates on SIGHUP
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
 
 	go func() {
-		if err := s.Serve(lis); err != nil {
-			logging.VSCANLog("fatal", "unable to start GRPC server: %v", err)
+		for range hup {
+			logging.VSCANLog("info", "received SIGHUP, reloading TLS certificates...")
+
+			if err := certManager.Reload(); err != nil {
+				logging.VSCANLog("error", "unable to reload TLS certificates, keeping previous ones: %v", err)
+			}
 		}
 	}()
 
+	var controllerRegistrar *registrar
+
+	if controllerConfig := config.Get().Controller; controllerConfig.Enabled {
+
+		controllerRegistrar, err = newRegistrar(controllerConfig, agentServer, healthChecker,
+			grpc.WithTransportCredentials(credentials.NewTLS(certManager.ClientTLSConfig(controllerConfig.ServerName))))
+
+		if err != nil {
+			logging.VSCANLog("fatal", "unable to register with controller: %v", err)
+		}
+	}
+
+	for _, lis := range listeners {
+		go func(lis net.Listener) {
+			if err := s.Serve(lis); err != nil {
+				logging.VSCANLog("fatal", "unable to start GRPC server: %v", err)
+			}
+		}(lis)
+	}
+
+	if controllerRegistrar != nil {
+		controllerRegistrar.run()
+	}
+
 	// Block main function from exiting until ch receives value
 	<-ch
 	logging.VSCANLog("info", "Gracefully shutting down VSCAN Agent...")
 
-	// Stop GRPC server and TCP listener
-	s.GracefulStop()
-	lis.Close()
+	// Reject new scan jobs and let the running ones finish within the grace period, unless a drain was already
+	// requested by RPC in which case its grace period applies
+	agentDrainer.start(config.Get().Server.DrainGracePeriod)
+
+	// A second signal skips the remaining grace period
+	drainCtx, cancelDrain := context.WithCancel(context.Background())
+
+	go func() {
+		select {
+		case <-ch:
+			logging.VSCANLog("warning", "received second shutdown signal, stopping VSCAN Agent immediately")
+			cancelDrain()
+		case <-drainCtx.Done():
+		}
+	}()
+
+	// On forced shutdown, the running scan jobs are interrupted so their Joval processes do not outlive the agent
+	if err := agentDrainer.wait(drainCtx); err != nil {
+		agentDrainer.interrupt()
+	}
+
+	// Heartbeats keep reporting the drain progress until no scan job is running
+	if controllerRegistrar != nil {
+		controllerRegistrar.deregister()
+	}
+
+	stopServer(drainCtx, s)
+	cancelDrain()
+
+	for _, lis := range listeners {
+		lis.Close()
+	}
+	close(stopWatch)
+
+	if metricsServer != nil {
+		metrics.Shutdown(metricsServer)
+	}
+
+	// Flush the spans not exported yet
+	if shutdownTracing != nil {
+		if err := shutdownTracing(context.Background()); err != nil {
+			logging.VSCANLog("error", "unable to flush pending traces: %v", err)
+		}
+	}
 }
 
-// serverCertLoad will load the TLS certificates for the gRPC server
-func serverCertLoad() (credentials.TransportCredentials, error) {
+// stopServer stops the gRPC server once the requests in progress completed, or forcefully after
+// gracefulStopTimeout or once ctx is done
+func stopServer(ctx context.Context, s *grpc.Server) {
+
+	stopped := make(chan struct{})
+
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+
+	timer := time.NewTimer(gracefulStopTimeout)
+	defer timer.Stop()
 
-	// Load the certificates from disk
-	certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+	select {
+	case <-stopped:
+	case <-timer.C:
+		logging.VSCANLog("warning", "requests still in progress after %v, closing remaining connections",
+			gracefulStopTimeout)
+		s.Stop()
+	case <-ctx.Done():
+		s.Stop()
+	}
+}
+
+// newAdmissionController converts the admission settings into the admission controller policies
+func newAdmissionController(admissionConfig config.Admission) *middleware.AdmissionController {
 
-	if err != nil {
-		return nil, fmt.Errorf("error while loading VSCAN agent server certificate: %v", err)
+	toPolicy := func(p config.AdmissionPolicy) middleware.AdmissionPolicy {
+		return middleware.AdmissionPolicy{
+			Exempt:            p.Exempt,
+			MaxLoadPerCPU:     p.MaxLoadPerCPU,
+			MaxMemoryPercent:  p.MaxMemoryPercent,
+			MinFreeDiskBytes:  uint64(p.MinFreeDiskMB) << 20,
+			MaxJovalProcesses: p.MaxJovalProcesses,
+		}
 	}
 
-	// Create a certificate pool from the certificate authority
-	certPool := x509.NewCertPool()
+	methods := make(map[string]middleware.AdmissionPolicy, len(admissionConfig.Methods))
 
-	ca, err := ioutil.ReadFile(caCertFile)
-	if err != nil {
-		return nil, fmt.Errorf("error while loading VSCAN agent root Certificate Authority: %v", err)
+	for method, p := range admissionConfig.Methods {
+		methods[method] = toPolicy(p)
 	}
 
-	// Append the client certificates from the CA
-	if ok := certPool.AppendCertsFromPEM(ca); !ok {
-		return nil, fmt.Errorf("failed to append Root CA %v certs", ca)
+	return middleware.NewAdmissionController(middleware.AdmissionOptions{
+		Default:        toPolicy(admissionConfig.Default),
+		Methods:        methods,
+		DiskPath:       admissionConfig.DiskPath,
+		RetryAfter:     admissionConfig.RetryAfter,
+		JovalProcesses: metrics.JovalProcessCount,
+	})
+}
+
+// newIdentityLimiter converts the rate limiting settings into the client identity quotas
+func newIdentityLimiter(rateLimitConfig config.RateLimit) *middleware.IdentityLimiter {
+
+	toQuota := func(q config.ClientQuota) middleware.IdentityQuota {
+		return middleware.IdentityQuota{
+			RequestsPerSecond:  q.RequestsPerSecond,
+			Burst:              q.Burst,
+			MaxConcurrentScans: q.MaxConcurrentScans,
+		}
 	}
 
-	return credentials.NewTLS(&tls.Config{
-		ClientAuth:   tls.RequireAndVerifyClientCert,
-		Certificates: []tls.Certificate{certificate},
-		ClientCAs:    certPool,
-	}), nil
+	quotas := make(map[string]middleware.IdentityQuota, len(rateLimitConfig.Identities))
+
+	for identity, q := range rateLimitConfig.Identities {
+		quotas[identity] = toQuota(q)
+	}
 
+	return middleware.NewIdentityLimiter(toQuota(rateLimitConfig.Default), quotas, rateLimitConfig.ExemptMethods,
+		rateLimitConfig.RetryAfter)
 }
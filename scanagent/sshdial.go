@@ -0,0 +1,276 @@
+package scanagent
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	agentpb "github.com/lucabrasi83/vscan-agent/proto"
+	"golang.org/x/crypto/ssh"
+)
+
+// maxKexInitCapture is the maximum number of bytes captured per direction to find the KEXINIT message
+const maxKexInitCapture = 64 * 1024
+
+// msgKexInit is the SSH message number of the key exchange initialization message
+const msgKexInit = 20
+
+// kexInitMsg represents the SSH_MSG_KEXINIT message as defined in RFC 4253 section 7.1
+type kexInitMsg struct {
+	Cookie                  [16]byte `sshtype:"20"`
+	KexAlgos                []string
+	ServerHostKeyAlgos      []string
+	CiphersClientServer     []string
+	CiphersServerClient     []string
+	MACsClientServer        []string
+	MACsServerClient        []string
+	CompressionClientServer []string
+	CompressionServerClient []string
+	LanguagesClientServer   []string
+	LanguagesServerClient   []string
+	FirstKexFollows         bool
+	Reserved                uint32
+}
+
+// sshDialStats holds the timing breakdown and negotiated algorithms of an SSH connection establishment
+type sshDialStats struct {
+	tcpConnect time.Duration
+	handshake  time.Duration
+	auth       time.Duration
+	algorithms *agentpb.SSHNegotiatedAlgorithms
+}
+
+// dialSSH establishes an SSH connection to addr, through the proxy if any, and returns the connection
+// establishment details.
+// The timeout applies to the TCP connection and to the SSH handshake and authentication, which are aborted once ctx
+// is done.
+func dialSSH(ctx context.Context, addr string, proxyURL string, config *ssh.ClientConfig,
+	timeout time.Duration) (*ssh.Client, *sshDialStats, error) {
+
+	stats := &sshDialStats{}
+
+	start := time.Now()
+
+	conn, err := dialTCP(ctx, addr, proxyURL, timeout)
+
+	stats.tcpConnect = time.Since(start)
+
+	if err != nil {
+		return nil, stats, err
+	}
+
+	// The host key is verified once the key exchange completed and before user authentication starts
+	var kexDone time.Time
+
+	sshConfig := *config
+	sshConfig.HostKeyCallback = func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		kexDone = time.Now()
+		return config.HostKeyCallback(hostname, remote, key)
+	}
+
+	sniffer := &kexInitSniffer{Conn: conn}
+
+	if timeout > 0 {
+		_ = conn.SetDeadline(time.Now().Add(timeout))
+	}
+
+	handshakeStart := time.Now()
+
+	stop := closeOnCancel(ctx, conn)
+
+	c, chans, reqs, err := ssh.NewClientConn(sniffer, addr, &sshConfig)
+
+	if !stop() && err == nil {
+		c.Close()
+		err = ctx.Err()
+	}
+
+	if kexDone.IsZero() {
+		stats.handshake = time.Since(handshakeStart)
+	} else {
+		stats.handshake = kexDone.Sub(handshakeStart)
+		stats.auth = time.Since(kexDone)
+	}
+
+	stats.algorithms = sniffer.negotiated()
+
+	if err != nil {
+		conn.Close()
+		return nil, stats, err
+	}
+
+	_ = conn.SetDeadline(time.Time{})
+
+	return ssh.NewClient(c, chans, reqs), stats, nil
+}
+
+// closeOnCancel closes c once ctx is done, until the returned stop function is called.
+// stop returns false if c was closed.
+func closeOnCancel(ctx context.Context, c io.Closer) (stop func() bool) {
+
+	var mu sync.Mutex
+	stopped, closed := false, false
+
+	done := make(chan struct{})
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if !stopped {
+			closed = true
+			c.Close()
+		}
+	}()
+
+	return func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if !stopped {
+			stopped = true
+			close(done)
+		}
+
+		return !closed
+	}
+}
+
+// kexInitSniffer captures the beginning of the SSH traffic in both directions in order to decode the
+// unencrypted KEXINIT messages, as golang.org/x/crypto/ssh does not expose the negotiated algorithms
+type kexInitSniffer struct {
+	net.Conn
+	mu       sync.Mutex
+	sent     bytes.Buffer
+	received bytes.Buffer
+}
+
+func (s *kexInitSniffer) Read(b []byte) (int, error) {
+
+	n, err := s.Conn.Read(b)
+
+	s.mu.Lock()
+	if s.received.Len() < maxKexInitCapture {
+		s.received.Write(b[:n])
+	}
+	s.mu.Unlock()
+
+	return n, err
+}
+
+func (s *kexInitSniffer) Write(b []byte) (int, error) {
+
+	s.mu.Lock()
+	if s.sent.Len() < maxKexInitCapture {
+		s.sent.Write(b)
+	}
+	s.mu.Unlock()
+
+	return s.Conn.Write(b)
+}
+
+// negotiated returns the algorithms negotiated from the captured client and server KEXINIT messages
+// following RFC 4253 section 7.1: the first client algorithm also supported by the server is chosen.
+// It returns nil if any of the KEXINIT messages was not captured.
+func (s *kexInitSniffer) negotiated() *agentpb.SSHNegotiatedAlgorithms {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	client := parseKexInit(s.sent.Bytes())
+	server := parseKexInit(s.received.Bytes())
+
+	if client == nil || server == nil {
+		return nil
+	}
+
+	algs := &agentpb.SSHNegotiatedAlgorithms{
+		KexAlgorithm:         firstCommon(client.KexAlgos, server.KexAlgos),
+		HostKeyAlgorithm:     firstCommon(client.ServerHostKeyAlgos, server.ServerHostKeyAlgos),
+		CipherClientToServer: firstCommon(client.CiphersClientServer, server.CiphersClientServer),
+		CipherServerToClient: firstCommon(client.CiphersServerClient, server.CiphersServerClient),
+		MacClientToServer:    firstCommon(client.MACsClientServer, server.MACsClientServer),
+		MacServerToClient:    firstCommon(client.MACsServerClient, server.MACsServerClient),
+	}
+
+	if isAEADCipher(algs.CipherClientToServer) {
+		algs.MacClientToServer = "implicit"
+	}
+
+	if isAEADCipher(algs.CipherServerToClient) {
+		algs.MacServerToClient = "implicit"
+	}
+
+	return algs
+}
+
+// parseKexInit decodes the KEXINIT message following the SSH identification lines of a captured stream
+func parseKexInit(stream []byte) *kexInitMsg {
+
+	// Skip the identification string and any preceding banner lines (RFC 4253 section 4.2)
+	for {
+		i := bytes.IndexByte(stream, '\n')
+
+		if i < 0 {
+			return nil
+		}
+
+		line := stream[:i]
+		stream = stream[i+1:]
+
+		if bytes.HasPrefix(line, []byte("SSH-")) {
+			break
+		}
+	}
+
+	// Binary packet: uint32 packet_length, byte padding_length, payload, padding (RFC 4253 section 6)
+	if len(stream) < 5 {
+		return nil
+	}
+
+	packetLen := int(binary.BigEndian.Uint32(stream[:4]))
+	paddingLen := int(stream[4])
+	payloadLen := packetLen - paddingLen - 1
+
+	if payloadLen <= 0 || len(stream) < 5+payloadLen || stream[5] != msgKexInit {
+		return nil
+	}
+
+	msg := &kexInitMsg{}
+
+	if err := ssh.Unmarshal(stream[5:5+payloadLen], msg); err != nil {
+		return nil
+	}
+
+	return msg
+}
+
+// firstCommon returns the first client algorithm supported by the server
+func firstCommon(client []string, server []string) string {
+
+	for _, c := range client {
+		for _, s := range server {
+			if c == s {
+				return c
+			}
+		}
+	}
+
+	return ""
+}
+
+// isAEADCipher returns whether the cipher provides its own integrity protection, making the MAC algorithm unused
+func isAEADCipher(cipher string) bool {
+	return strings.Contains(cipher, "gcm") || strings.HasPrefix(cipher, "chacha20-poly1305")
+}
@@ -0,0 +1,53 @@
+package scanagent
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"syscall"
+
+	agentpb "github.com/lucabrasi83/vscan-agent/proto"
+)
+
+// classifySSHError returns the failure reason of an SSH connection error.
+// golang.org/x/crypto/ssh does not wrap handshake errors so these are classified on their error message.
+func classifySSHError(err error) agentpb.SSHFailureReason {
+
+	if err == nil {
+		return agentpb.SSHFailureReason_SSH_FAILURE_NONE
+	}
+
+	var dnsErr *net.DNSError
+	var netErr net.Error
+
+	errMsg := err.Error()
+
+	switch {
+	case errors.As(err, &dnsErr):
+		return agentpb.SSHFailureReason_SSH_FAILURE_DNS
+
+	case errors.Is(err, syscall.ECONNREFUSED) || strings.Contains(errMsg, "connection refused"):
+		return agentpb.SSHFailureReason_SSH_FAILURE_CONNECTION_REFUSED
+
+	case errors.Is(err, errHostKeyMismatch) || strings.Contains(errMsg, errHostKeyMismatch.Error()):
+		return agentpb.SSHFailureReason_SSH_FAILURE_HOST_KEY_MISMATCH
+
+	// The client only attempts the methods accepted by the server so if none but the "none" method was
+	// attempted, none of the provided credentials types is supported by the server
+	case strings.Contains(errMsg, "ssh: unable to authenticate, attempted methods [none],"):
+		return agentpb.SSHFailureReason_SSH_FAILURE_NO_SUPPORTED_AUTH_METHODS
+
+	case strings.Contains(errMsg, "ssh: unable to authenticate"), errors.Is(err, errNoKbdAnswer),
+		strings.Contains(errMsg, errNoKbdAnswer.Error()):
+		return agentpb.SSHFailureReason_SSH_FAILURE_AUTH_REJECTED
+
+	case errors.As(err, &netErr) && netErr.Timeout(), strings.Contains(errMsg, "i/o timeout"):
+		return agentpb.SSHFailureReason_SSH_FAILURE_TIMEOUT
+
+	case strings.HasPrefix(errMsg, "ssh: handshake failed"):
+		return agentpb.SSHFailureReason_SSH_FAILURE_HANDSHAKE
+
+	default:
+		return agentpb.SSHFailureReason_SSH_FAILURE_OTHER
+	}
+}
@@ -0,0 +1,127 @@
+// Package tracing handles the VSCAN Agent OpenTelemetry distributed tracing and the OTLP traces export
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// instrumentationName identifies the VSCAN Agent tracer
+const instrumentationName = "github.com/lucabrasi83/vscan-agent"
+
+// Options represents the traces export settings
+type Options struct {
+	// Endpoint is the host:port of the OTLP gRPC collector
+	Endpoint string
+
+	// Insecure disables TLS on the connection to the collector
+	Insecure bool
+
+	// CACertFile is the CA bundle used to verify the collector certificate. The system roots are used if empty.
+	CACertFile string
+
+	// SampleRatio is the ratio of new traces sampled. Traces started by the controller follow its decision.
+	SampleRatio float64
+
+	ServiceName    string
+	ServiceVersion string
+}
+
+// Init sets up the global tracer provider exporting the spans to the OTLP collector, and the W3C trace context
+// propagation used to continue the controller traces.
+// The returned function flushes the pending spans and must be called on shutdown.
+func Init(opts Options) (func(context.Context) error, error) {
+
+	exporterOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(opts.Endpoint)}
+
+	switch {
+	case opts.Insecure:
+		exporterOpts = append(exporterOpts, otlptracegrpc.WithInsecure())
+	case opts.CACertFile != "":
+		creds, err := credentials.NewClientTLSFromFile(opts.CACertFile, "")
+
+		if err != nil {
+			return nil, fmt.Errorf("error while loading tracing collector CA certificate: %v", err)
+		}
+
+		exporterOpts = append(exporterOpts, otlptracegrpc.WithTLSCredentials(creds))
+	}
+
+	// The exporter connects in the background so the agent starts even if the collector is unreachable
+	exporter, err := otlptracegrpc.New(context.Background(), exporterOpts...)
+
+	if err != nil {
+		return nil, fmt.Errorf("error while creating OTLP traces exporter: %v", err)
+	}
+
+	hostname, _ := os.Hostname()
+
+	res := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceNameKey.String(opts.ServiceName),
+		semconv.ServiceVersionKey.String(opts.ServiceVersion),
+		semconv.HostNameKey.String(hostname),
+	)
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+
+		return provider.Shutdown(ctx)
+	}, nil
+}
+
+// StartSpan starts a child span of the span held in ctx, if any.
+// Spans are discarded when tracing is not initialized.
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// EndSpan records the error, if any, on the span and ends it
+func EndSpan(span trace.Span, err error) {
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// UnaryServerInterceptor returns a new unary server interceptor that starts a span per request,
+// continuing the trace context propagated by the client.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return otelgrpc.UnaryServerInterceptor()
+}
+
+// StreamServerInterceptor returns a new stream server interceptor that starts a span per request,
+// continuing the trace context propagated by the client.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return otelgrpc.StreamServerInterceptor()
+}